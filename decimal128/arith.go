@@ -0,0 +1,275 @@
+package decimal128
+
+import (
+	"github.com/Pilatuz/bigz/uint128"
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// Add returns the sum x+y, rounded to 34 digits, ties to even.
+// The exponent of the exact result is the lower exponent of operands.
+func (x Decimal128) Add(y Decimal128) Decimal128 {
+	if x.isSpecial() || y.isSpecial() {
+		switch {
+		case x.IsNaN(), y.IsNaN():
+			return NaN()
+		case x.IsInf(0) && y.IsInf(0) && x.Signbit() != y.Signbit():
+			return NaN() // Inf - Inf
+		case x.IsInf(0):
+			return inf(x.Signbit())
+		}
+		return inf(y.Signbit())
+	}
+
+	xneg, xc, xe := x.unpack()
+	yneg, yc, ye := y.unpack()
+	return add(xneg, xc, xe, yneg, yc, ye)
+}
+
+// Sub returns the difference x-y, rounded to 34 digits, ties to even.
+// The exponent of the exact result is the lower exponent of operands.
+func (x Decimal128) Sub(y Decimal128) Decimal128 {
+	return x.Add(y.Neg())
+}
+
+// add returns the sum of finite operands.
+func add(xneg bool, xc Uint128, xe int, yneg bool, yc Uint128, ye int) Decimal128 {
+	exp := xe
+	if ye < exp {
+		exp = ye
+	}
+
+	if xc.IsZero() || yc.IsZero() {
+		if xc.IsZero() && yc.IsZero() {
+			return pack(xneg && yneg, Uint128{}, exp)
+		}
+		if xc.IsZero() {
+			xneg, xc, xe = yneg, yc, ye
+		}
+		// the result is exact, get as close as possible to the lower exponent
+		k := maxDigits - digits(uint256.From128(xc))
+		if xe-exp < k {
+			k = xe - exp
+		}
+		return pack(xneg, xc.Mul(pow10[k].Lo), xe-k)
+	}
+
+	// make x the operand of the higher magnitude order
+	xd, yd := digits(uint256.From128(xc)), digits(uint256.From128(yc))
+	if ye+yd > xe+xd {
+		xneg, xc, xe, xd, yneg, yc, ye, yd = yneg, yc, ye, yd, xneg, xc, xe, xd
+	}
+
+	// if all the digits of y are below the 37 digits of x, then
+	// y is just a sticky bit for the rounding
+	if e := xe + xd - (maxDigits + 3); ye+yd <= e {
+		c := uint256.From128(xc).Mul(pow10[xe-e])
+		if xneg != yneg {
+			c = c.Sub128(uint128.One())
+		}
+		z, _ := round(xneg, c, e, expMin, true)
+		return z
+	}
+
+	// exact sum, both coefficients fit the 72 digits
+	a := uint256.From128(xc).Mul(pow10[xe-exp])
+	b := uint256.From128(yc).Mul(pow10[ye-exp])
+	neg := xneg
+	if xneg == yneg {
+		a = a.Add(b)
+	} else {
+		switch a.Cmp(b) {
+		case +1:
+			a = a.Sub(b)
+		case -1:
+			a, neg = b.Sub(a), yneg
+		default:
+			return pack(false, Uint128{}, exp) // exact zero
+		}
+	}
+
+	z, _ := round(neg, a, exp, expMin, false)
+	return z
+}
+
+// Mul returns the product x*y, rounded to 34 digits, ties to even.
+// The exponent of the exact result is the sum of exponents of operands.
+func (x Decimal128) Mul(y Decimal128) Decimal128 {
+	neg := x.Signbit() != y.Signbit()
+	if x.isSpecial() || y.isSpecial() {
+		switch {
+		case x.IsNaN(), y.IsNaN():
+			return NaN()
+		case x.IsZero(), y.IsZero():
+			return NaN() // 0 * Inf
+		}
+		return inf(neg)
+	}
+
+	_, xc, xe := x.unpack()
+	_, yc, ye := y.unpack()
+	hi, lo := uint128.Mul(xc, yc)
+	z, _ := round(neg, Uint256{Lo: lo, Hi: hi}, xe+ye, expMin, false)
+	return z
+}
+
+// Quo returns the quotient x/y, rounded to 34 digits, ties to even.
+// The exponent of the exact result is as close as possible
+// to the difference of exponents of operands.
+// Division of nonzero value by zero returns infinity, 0/0 is NaN.
+func (x Decimal128) Quo(y Decimal128) Decimal128 {
+	neg := x.Signbit() != y.Signbit()
+	if x.isSpecial() || y.isSpecial() {
+		switch {
+		case x.IsNaN(), y.IsNaN():
+			return NaN()
+		case x.IsInf(0) && y.IsInf(0):
+			return NaN() // Inf / Inf
+		case x.IsInf(0):
+			return inf(neg)
+		}
+		return pack(neg, Uint128{}, expMin) // x / Inf
+	}
+
+	_, xc, xe := x.unpack()
+	_, yc, ye := y.unpack()
+	switch {
+	case yc.IsZero() && xc.IsZero():
+		return NaN() // 0 / 0
+	case yc.IsZero():
+		return inf(neg)
+	case xc.IsZero():
+		z, _ := round(neg, Uint256{}, xe-ye, expMin, false)
+		return z
+	}
+
+	// scale x to get at least 35 digits of quotient
+	yd := digits(uint256.From128(yc))
+	k := yd + maxDigits + 1 - digits(uint256.From128(xc))
+	q, r := uint256.From128(xc).Mul(pow10[k]).QuoRem(uint256.From128(yc))
+	exp := xe - ye - k
+	if r.IsZero() {
+		// exact, remove the trailing zeros down to the preferred exponent
+		for exp < xe-ye {
+			t, m := q.QuoRem64(10)
+			if m != 0 {
+				break
+			}
+			q = t
+			exp++
+		}
+	}
+
+	z, _ := round(neg, q, exp, expMin, !r.IsZero())
+	return z
+}
+
+// Round returns x rounded to the given number of fractional digits,
+// ties to even, i.e. the value with exponent -scale. Negative scale
+// rounds to tens, hundreds and so on.
+// Round returns NaN if x is not finite or the result does not fit
+// the 34 digits or the exponent range.
+func (x Decimal128) Round(scale int) Decimal128 {
+	exp := -scale
+	if x.isSpecial() || exp < expMin || exp > expMax+maxDigits-1 {
+		return NaN()
+	}
+
+	neg, c, e := x.unpack()
+	coef := uint256.From128(c)
+	switch {
+	case e < exp:
+		coef, _ = roundDigits(coef, exp-e, false)
+	case e > exp && !c.IsZero():
+		if e-exp > maxDigits-digits(coef) {
+			return NaN()
+		}
+		coef = coef.Mul(pow10[e-exp])
+	}
+	if digits(coef) > maxDigits {
+		return NaN() // carry to 35 digits
+	}
+
+	// the exponent above the range is clamped if possible
+	z, _ := round(neg, coef, exp, exp, false)
+	if z.IsInf(0) {
+		return NaN()
+	}
+	return z
+}
+
+// Cmp compares x and y and returns:
+//
+//	-1 if x <  y
+//	 0 if x == y
+//	+1 if x >  y
+//
+// The values are compared numerically, regardless of exponent, so that
+// 1.0 and 1.00 are equal, as well as zeros of either sign. Unlike
+// Equal and Less, Cmp orders NaN below any other value and equal to NaN.
+func (x Decimal128) Cmp(y Decimal128) int {
+	switch xn, yn := x.IsNaN(), y.IsNaN(); {
+	case xn && yn:
+		return 0
+	case xn:
+		return -1
+	case yn:
+		return +1
+	}
+
+	xs, ys := x.Sign(), y.Sign()
+	if xs != ys {
+		if xs < ys {
+			return -1
+		}
+		return +1
+	}
+	if xs == 0 {
+		return 0
+	}
+
+	c := cmpAbs(x, y)
+	if xs < 0 {
+		return -c
+	}
+	return c
+}
+
+// cmpAbs compares the absolute values of nonzero x and y.
+func cmpAbs(x, y Decimal128) int {
+	switch xi, yi := x.IsInf(0), y.IsInf(0); {
+	case xi && yi:
+		return 0
+	case xi:
+		return +1
+	case yi:
+		return -1
+	}
+
+	_, xc, xe := x.unpack()
+	_, yc, ye := y.unpack()
+	a, b := uint256.From128(xc), uint256.From128(yc)
+	xa, ya := xe+digits(a), ye+digits(b)
+	switch {
+	case xa < ya:
+		return -1
+	case xa > ya:
+		return +1
+	case xe > ye:
+		a = a.Mul(pow10[xe-ye]) // less than 34 digits difference
+	case ye > xe:
+		b = b.Mul(pow10[ye-xe])
+	}
+	return a.Cmp(b)
+}
+
+// Equal reports whether x == y numerically.
+// Equal is false if x or y is NaN.
+func (x Decimal128) Equal(y Decimal128) bool {
+	return !x.IsNaN() && !y.IsNaN() && x.Cmp(y) == 0
+}
+
+// Less reports whether x < y.
+// Less is false if x or y is NaN.
+func (x Decimal128) Less(y Decimal128) bool {
+	return !x.IsNaN() && !y.IsNaN() && x.Cmp(y) < 0
+}
@@ -0,0 +1,261 @@
+package decimal128
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Pilatuz/bigz/uint128"
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// Errors returned by Parse, ParseExact and UnmarshalText
+// are of *strconv.NumError type, its Err field is one of the following errors.
+// ErrSyntax and ErrRange are the same errors as defined in strconv package.
+var (
+	// ErrSyntax indicates that a value does not have the right syntax.
+	ErrSyntax = strconv.ErrSyntax
+
+	// ErrRange indicates that a value is out of range.
+	ErrRange = strconv.ErrRange
+
+	// ErrInexact indicates that a value cannot be represented exactly.
+	ErrInexact = errors.New("value cannot be represented exactly")
+)
+
+// FromInt64 returns the integer v as a decimal value with zero exponent.
+func FromInt64(v int64) Decimal128 {
+	if v < 0 {
+		return pack(true, uint128.From64(uint64(-v)), 0)
+	}
+	return pack(false, uint128.From64(uint64(v)), 0)
+}
+
+// FromUint128 returns the decimal value v·10^-scale, that is the integer v
+// with scale fractional digits, e.g. cents for the scale 2. The value is
+// rounded to 34 digits, ties to even. It also reports whether the
+// result is exact.
+func FromUint128(v Uint128, scale int) (Decimal128, bool) {
+	z, inexact := round(false, uint256.From128(v), -scale, expMin, false)
+	return z, !inexact
+}
+
+// Uint128 returns the integer x·10^scale, e.g. the number of cents for the
+// scale 2, rounded to nearest, ties to even. It also reports whether
+// the result is exact. Negative values and NaN convert to Zero and
+// too large values saturate to Max, reporting false.
+func (x Decimal128) Uint128(scale int) (Uint128, bool) {
+	switch {
+	case x.IsNaN(), x.IsInf(-1):
+		return Uint128{}, false
+	case x.IsInf(+1):
+		return uint128.Max(), false
+	}
+
+	neg, c, exp := x.unpack()
+	exp += scale
+	switch {
+	case c.IsZero():
+		return Uint128{}, true
+	case neg:
+		return Uint128{}, false
+	case exp < 0:
+		q, inexact := roundDigits(uint256.From128(c), -exp, false)
+		return q.Lo, !inexact // less than 34 digits
+	case exp >= 39: // log10(2^128) < 39
+		return uint128.Max(), false
+	}
+
+	hi, lo := uint128.Mul(c, pow10[exp].Lo)
+	if !hi.IsZero() {
+		return uint128.Max(), false
+	}
+	return lo, true
+}
+
+// Parse parses the string s as a decimal value, rounded to 34 digits,
+// ties to even. The syntax is an optional sign followed by the digits with
+// optional decimal point and optional exponent, or one of "Inf",
+// "Infinity", "NaN" and "sNaN", case insensitive, e.g. "-1.25E+3".
+//
+// If s is syntactically well-formed but too large, Parse returns
+// the infinity of the appropriate sign and ErrRange error.
+// If s is malformed, Parse returns NaN and ErrSyntax error.
+func Parse(s string) (Decimal128, error) {
+	x, _, err := parse("Parse", s)
+	return x, err
+}
+
+// ParseExact is like Parse but also returns ErrInexact error if the value
+// is rounded. This is how the BSON specification parses decimal128.
+// Note, the trailing zeros are dropped exactly if necessary.
+func ParseExact(s string) (Decimal128, error) {
+	x, inexact, err := parse("ParseExact", s)
+	if err == nil && inexact {
+		err = &strconv.NumError{Func: "ParseExact", Num: s, Err: ErrInexact}
+	}
+	return x, err
+}
+
+// parse parses the decimal value and also reports whether it is inexact.
+func parse(fn, s string) (Decimal128, bool, error) {
+	num := s
+	neg := false
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	switch strings.ToLower(s) {
+	case "inf", "infinity":
+		return inf(neg), false, nil
+	case "nan", "snan":
+		x := NaN()
+		if s[0] != 'n' && s[0] != 'N' {
+			x.bits.Hi = snanHi
+		}
+		if neg {
+			x.bits.Hi |= signMask
+		}
+		return x, false, nil
+	}
+
+	var coef Uint128 // up to 35 significant digits
+	var nd, exp int  // number of significant digits and exponent
+	var sticky, point, seen bool
+	i := 0
+loop:
+	for ; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '.' && !point:
+			point = true
+			continue
+		case c >= '0' && c <= '9':
+			seen = true
+			if nd < maxDigits+1 {
+				if coef = coef.Mul64(10).Add64(uint64(c - '0')); !coef.IsZero() {
+					nd++ // leading zeros are not significant
+				}
+			} else {
+				sticky = sticky || c != '0'
+				exp++ // the digit is dropped
+			}
+			if point {
+				exp--
+			}
+		default:
+			break loop
+		}
+	}
+	if !seen {
+		return NaN(), false, &strconv.NumError{Func: fn, Num: num, Err: ErrSyntax}
+	}
+
+	if i < len(s) {
+		// exponent part, large values are limited, they overflow anyway
+		if s[i] != 'e' && s[i] != 'E' {
+			return NaN(), false, &strconv.NumError{Func: fn, Num: num, Err: ErrSyntax}
+		}
+		e := s[i+1:]
+		eneg := false
+		if e != "" && (e[0] == '+' || e[0] == '-') {
+			eneg = e[0] == '-'
+			e = e[1:]
+		}
+		if e == "" {
+			return NaN(), false, &strconv.NumError{Func: fn, Num: num, Err: ErrSyntax}
+		}
+		v := 0
+		for _, c := range []byte(e) {
+			if c < '0' || c > '9' {
+				return NaN(), false, &strconv.NumError{Func: fn, Num: num, Err: ErrSyntax}
+			}
+			if v < 1e8 {
+				v = v*10 + int(c-'0')
+			}
+		}
+		if eneg {
+			v = -v
+		}
+		exp += v
+	}
+
+	x, inexact := round(neg, uint256.From128(coef), exp, expMin, sticky)
+	if x.IsInf(0) {
+		return x, true, &strconv.NumError{Func: fn, Num: num, Err: ErrRange}
+	}
+	return x, inexact, nil
+}
+
+// String returns the string representation of x using the IEEE 754
+// to-scientific-string conversion, as BSON does. The plain notation
+// is used for exponents not above zero and adjusted exponents not below -6,
+// otherwise the scientific notation, e.g. "1.23", "-0.000123" and "1.23E+5".
+// The infinities are "Infinity" and "-Infinity", all NaNs are "NaN".
+func (x Decimal128) String() string {
+	var buf [48]byte // sign, 34 digits, point, "E+6144"
+	return string(x.Append(buf[:0]))
+}
+
+// Append appends the string representation of x,
+// as generated by String, to dst and returns the extended buffer.
+func (x Decimal128) Append(dst []byte) []byte {
+	switch {
+	case x.IsNaN():
+		return append(dst, "NaN"...)
+	case x.IsInf(+1):
+		return append(dst, "Infinity"...)
+	case x.IsInf(-1):
+		return append(dst, "-Infinity"...)
+	}
+
+	neg, coef, exp := x.unpack()
+	if neg {
+		dst = append(dst, '-')
+	}
+
+	var buf [39]byte // log10(2^128) < 39
+	d := uint128.AppendDecimal(buf[:0], coef)
+	adj := exp + len(d) - 1
+	switch {
+	case exp == 0:
+		return append(dst, d...)
+	case exp < 0 && adj >= -6:
+		if n := len(d) + exp; n > 0 {
+			dst = append(dst, d[:n]...)
+			dst = append(dst, '.')
+			return append(dst, d[n:]...)
+		}
+		dst = append(dst, "0."...)
+		for n := len(d) + exp; n < 0; n++ {
+			dst = append(dst, '0')
+		}
+		return append(dst, d...)
+	}
+
+	dst = append(dst, d[0])
+	if len(d) > 1 {
+		dst = append(dst, '.')
+		dst = append(dst, d[1:]...)
+	}
+	dst = append(dst, 'E')
+	if adj >= 0 {
+		dst = append(dst, '+')
+	}
+	return strconv.AppendInt(dst, int64(adj), 10)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (x Decimal128) MarshalText() ([]byte, error) {
+	return x.Append(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (x *Decimal128) UnmarshalText(text []byte) error {
+	v, _, err := parse("UnmarshalText", string(text))
+	if err != nil {
+		return err
+	}
+	*x = v
+	return nil
+}
@@ -0,0 +1,249 @@
+// Package decimal128 implements IEEE 754-2008 decimal128 floating-point
+// arithmetic in software on top of uint128.Uint128.
+//
+// A Decimal128 value is a sign, a coefficient of up to 34 decimal digits
+// and an exponent in the range [-6176, 6111], stored in the binary integer
+// decimal (BID) encoding, the one used by BSON and by the Intel decimal
+// floating-point library. Unlike binary floating-point, the decimal values
+// keep their exponent, so 1.0 and 1.00 are equal but distinct values.
+//
+// All the operations round to nearest, ties to even (banker's rounding),
+// and follow the IEEE 754 rules for the preferred exponent of exact results.
+package decimal128
+
+import (
+	"github.com/Pilatuz/bigz/uint128"
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// Uint128 is an alias for uint128.Uint128 type, the bits container.
+type Uint128 = uint128.Uint128
+
+// Uint256 is an alias for uint256.Uint256 type, the wide coefficient.
+type Uint256 = uint256.Uint256
+
+// Decimal128 is an IEEE 754-2008 decimal128 value in BID encoding.
+// The zero value is zero with the lowest exponent, i.e. 0E-6176.
+type Decimal128 struct {
+	bits Uint128
+}
+
+// decimal128 layout, as seen from the upper 64-bit half.
+const (
+	maxDigits = 34         // coefficient digits
+	expBias   = 6176       // exponent bias
+	expMin    = -expBias   // lowest exponent
+	expMax    = 6111       // highest exponent
+	expMask   = 1<<14 - 1  // exponent bits
+	expShift  = 113 - 64   // exponent position in Hi
+	coefMask  = 1<<49 - 1  // coefficient bits in Hi
+	signMask  = 1 << 63    // sign bit in Hi
+	largeMask = 3 << 61    // "11" combination, large coefficient form
+	specMask  = 0x1F << 58 // infinities and NaNs
+	infHi     = 0x1E << 58 // infinity in Hi
+	nanHi     = 0x1F << 58 // quiet NaN in Hi
+	snanHi    = 0x3F << 57 // signaling NaN in Hi
+)
+
+// pow10 contains powers of ten that fit into Uint256.
+var pow10 = func() (t [78]Uint256) {
+	t[0] = uint256.One()
+	for i := 1; i < len(t); i++ {
+		t[i] = t[i-1].Mul128(uint128.From64(10))
+	}
+	return
+}()
+
+// FromBits returns the decimal128 value with the given BID encoding.
+func FromBits(bits Uint128) Decimal128 {
+	return Decimal128{bits: bits}
+}
+
+// Bits returns the BID encoding of x.
+func (x Decimal128) Bits() Uint128 {
+	return x.bits
+}
+
+// Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func Inf(sign int) Decimal128 {
+	return inf(sign < 0)
+}
+
+// NaN returns the canonical quiet NaN value.
+func NaN() Decimal128 {
+	return Decimal128{bits: Uint128{Hi: nanHi}}
+}
+
+// inf returns infinity of the given sign.
+func inf(neg bool) Decimal128 {
+	x := Decimal128{bits: Uint128{Hi: infHi}}
+	if neg {
+		x.bits.Hi |= signMask
+	}
+	return x
+}
+
+// pack encodes the finite value, the coefficient must be canonical
+// and the exponent must be in range.
+func pack(neg bool, coef Uint128, exp int) Decimal128 {
+	hi := coef.Hi | uint64(exp+expBias)<<expShift
+	if neg {
+		hi |= signMask
+	}
+	return Decimal128{bits: Uint128{Lo: coef.Lo, Hi: hi}}
+}
+
+// unpack decodes the finite x.
+// The non-canonical coefficients, larger than 10^34-1, are decoded as zero.
+func (x Decimal128) unpack() (neg bool, coef Uint128, exp int) {
+	hi := x.bits.Hi
+	neg = hi&signMask != 0
+	if hi&largeMask == largeMask {
+		// the implicit "100" prefix makes the coefficient at least 2^113
+		return neg, Uint128{}, int(hi>>(expShift-2)&expMask) - expBias
+	}
+
+	exp = int(hi>>expShift&expMask) - expBias
+	coef = Uint128{Lo: x.bits.Lo, Hi: hi & coefMask}
+	if coef.Cmp(pow10[maxDigits].Lo) >= 0 {
+		coef = Uint128{}
+	}
+	return
+}
+
+// Coefficient returns the coefficient of the finite x, the integer of
+// up to 34 digits. Coefficient of infinity or NaN is zero.
+func (x Decimal128) Coefficient() Uint128 {
+	if x.isSpecial() {
+		return Uint128{}
+	}
+	_, coef, _ := x.unpack()
+	return coef
+}
+
+// Exponent returns the exponent of the finite x, so that the absolute
+// value of x is Coefficient()·10^Exponent(). Exponent of infinity or NaN is 0.
+func (x Decimal128) Exponent() int {
+	if x.isSpecial() {
+		return 0
+	}
+	_, _, exp := x.unpack()
+	return exp
+}
+
+// Signbit reports whether x is negative or negative zero.
+func (x Decimal128) Signbit() bool {
+	return x.bits.Hi&signMask != 0
+}
+
+// Sign returns -1, 0 or +1 depending on whether x is negative,
+// zero (of either sign) or positive. Sign of NaN is 0.
+func (x Decimal128) Sign() int {
+	switch {
+	case x.IsNaN(), x.IsZero():
+		return 0
+	case x.Signbit():
+		return -1
+	}
+	return +1
+}
+
+// IsZero reports whether x is zero of either sign and any exponent.
+func (x Decimal128) IsZero() bool {
+	return !x.isSpecial() && x.Coefficient().IsZero()
+}
+
+// IsNaN reports whether x is a NaN value.
+func (x Decimal128) IsNaN() bool {
+	return x.bits.Hi&specMask == nanHi
+}
+
+// IsInf reports whether x is an infinity, according to sign.
+// If sign > 0, IsInf reports whether x is positive infinity.
+// If sign < 0, IsInf reports whether x is negative infinity.
+// If sign == 0, IsInf reports whether x is either infinity.
+func (x Decimal128) IsInf(sign int) bool {
+	return x.bits.Hi&specMask == infHi &&
+		(sign >= 0 && !x.Signbit() || sign <= 0 && x.Signbit())
+}
+
+// isSpecial reports whether x is an infinity or a NaN.
+func (x Decimal128) isSpecial() bool {
+	return x.bits.Hi&infHi == infHi
+}
+
+// Neg returns x with its sign flipped.
+func (x Decimal128) Neg() Decimal128 {
+	x.bits.Hi ^= signMask
+	return x
+}
+
+// Abs returns the absolute value of x.
+func (x Decimal128) Abs() Decimal128 {
+	x.bits.Hi &^= signMask
+	return x
+}
+
+// digits returns the number of decimal digits of u, zero for zero.
+func digits(u Uint256) int {
+	n := u.BitLen() * 1233 >> 12 // log10(2) ≈ 1233/4096
+	if n < len(pow10) && u.Cmp(pow10[n]) >= 0 {
+		n++
+	}
+	return n
+}
+
+// roundDigits drops the drop lowest decimal digits of coef, ties to even.
+// The sticky tells that the exact value is a bit larger than coef.
+// It also reports whether the result is inexact.
+func roundDigits(coef Uint256, drop int, sticky bool) (Uint256, bool) {
+	if drop <= 0 {
+		return coef, sticky
+	}
+	if drop >= len(pow10) {
+		// coef < 2^256 < 10^drop/2, rounds to zero
+		return Uint256{}, sticky || !coef.IsZero()
+	}
+
+	q, r := coef.QuoRem(pow10[drop])
+	c := r.Cmp(pow10[drop].Rsh(1))
+	if c > 0 || c == 0 && (sticky || q.Lo.Lo&1 != 0) {
+		q = q.Add128(uint128.One())
+	}
+	return q, sticky || !r.IsZero()
+}
+
+// round rounds the value coef·10^exp to 34 digits and to the exponent
+// range, ties to even. The result exponent is at least emin, which is
+// not below the lowest exponent. The sticky tells that the exact value is
+// a bit larger than coef. It also reports whether the result is inexact.
+func round(neg bool, coef Uint256, exp, emin int, sticky bool) (Decimal128, bool) {
+	drop := digits(coef) - maxDigits
+	if d := emin - exp; d > drop {
+		drop = d
+	}
+
+	inexact := sticky
+	if drop > 0 {
+		coef, inexact = roundDigits(coef, drop, sticky)
+		exp += drop
+		if coef.Equals(pow10[maxDigits]) {
+			coef = pow10[maxDigits-1] // carry to 35 digits
+			exp++
+		}
+	}
+
+	if exp > expMax {
+		// clamp the exponent by padding the coefficient with zeros
+		if pad := exp - expMax; coef.IsZero() {
+			exp = expMax
+		} else if pad <= maxDigits-digits(coef) {
+			coef = coef.Mul(pow10[pad])
+			exp = expMax
+		} else {
+			return inf(neg), true
+		}
+	}
+
+	return pack(neg, coef.Lo, exp), inexact
+}
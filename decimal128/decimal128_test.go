@@ -0,0 +1,347 @@
+package decimal128
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// The BSON corpus in testdata/bson-corpus is the specification test
+// corpus, vendored verbatim. The arithmetic vectors in testdata/arith.txt
+// are generated by testdata/gen.py with Python's decimal module, an
+// independent implementation of the IEEE 754 decimal arithmetic.
+
+// corpus is the BSON specification test corpus for decimal128 type.
+type corpus struct {
+	Valid []struct {
+		Description       string `json:"description"`
+		CanonicalBSON     string `json:"canonical_bson"`
+		CanonicalExtJSON  string `json:"canonical_extjson"`
+		DegenerateExtJSON string `json:"degenerate_extjson"`
+		Lossy             bool   `json:"lossy"`
+	} `json:"valid"`
+	ParseErrors []struct {
+		Description string `json:"description"`
+		String      string `json:"string"`
+	} `json:"parseErrors"`
+}
+
+// corpusBits extracts the decimal128 bits from the {"d": decimal} BSON document.
+func corpusBits(doc string) (Uint128, error) {
+	b, err := hex.DecodeString(doc)
+	if err != nil {
+		return Uint128{}, err
+	}
+	if len(b) != 24 || b[4] != 0x13 || string(b[5:7]) != "d\x00" {
+		return Uint128{}, errors.New("unexpected BSON document")
+	}
+	return uint128.LoadLittleEndian(b[7:23]), nil
+}
+
+// corpusString extracts the decimal string from the extended JSON.
+func corpusString(doc string) (string, error) {
+	var v struct {
+		D struct {
+			Value string `json:"$numberDecimal"`
+		} `json:"d"`
+	}
+	err := json.Unmarshal([]byte(doc), &v)
+	return v.D.Value, err
+}
+
+// TestCorpus round-trips the BSON corpus.
+func TestCorpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "bson-corpus", "decimal128-*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find corpus: %v", err)
+	}
+
+	var valid, parseErrors int
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("failed to open corpus: %s", err)
+		}
+		var c corpus
+		err = json.NewDecoder(f).Decode(&c)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: failed to parse corpus: %s", path, err)
+		}
+
+		for _, v := range c.Valid {
+			bits, err := corpusBits(v.CanonicalBSON)
+			if err != nil {
+				t.Fatalf("%s: %s: %s", path, v.Description, err)
+			}
+			s, err := corpusString(v.CanonicalExtJSON)
+			if err != nil {
+				t.Fatalf("%s: %s: %s", path, v.Description, err)
+			}
+
+			// bson -> string
+			if got := FromBits(bits).String(); got != s {
+				t.Errorf("%s: %s: %#x should be %q, got %q", path, v.Description, bits, s, got)
+			}
+
+			// string -> bson, lossy cases (NaN payloads and signs) are not round-tripped
+			if v.Lossy {
+				continue
+			}
+			if x, err := ParseExact(s); err != nil || x.Bits() != bits {
+				t.Errorf("%s: %s: ParseExact(%q) should be %#x, got %#x (%v)", path, v.Description, s, bits, x.Bits(), err)
+			}
+
+			// non-canonical string -> bson
+			if v.DegenerateExtJSON != "" {
+				d, err := corpusString(v.DegenerateExtJSON)
+				if err != nil {
+					t.Fatalf("%s: %s: %s", path, v.Description, err)
+				}
+				if x, err := ParseExact(d); err != nil || x.Bits() != bits {
+					t.Errorf("%s: %s: ParseExact(%q) should be %#x, got %#x (%v)", path, v.Description, d, bits, x.Bits(), err)
+				}
+			}
+		}
+		valid += len(c.Valid)
+
+		for _, v := range c.ParseErrors {
+			if x, err := ParseExact(v.String); err == nil {
+				t.Errorf("%s: %s: ParseExact(%q) should fail, got %s", path, v.Description, v.String, x)
+			}
+		}
+		parseErrors += len(c.ParseErrors)
+	}
+
+	if valid == 0 || parseErrors == 0 {
+		t.Errorf("empty corpus")
+	}
+}
+
+// TestArith checks the arithmetic against the test vectors.
+func TestArith(t *testing.T) {
+	path := filepath.Join("testdata", "arith.txt")
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open vectors: %s", err)
+	}
+	defer f.Close()
+
+	mustParse := func(line int, s string) Decimal128 {
+		x, err := ParseExact(s)
+		if err != nil {
+			t.Fatalf("%s:%d: bad operand: %s", path, line, err)
+		}
+		return x
+	}
+
+	count := 0
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		if text == "" || text[0] == '#' {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 5 || fields[3] != "->" {
+			t.Fatalf("%s:%d: bad line", path, line)
+		}
+
+		x := mustParse(line, fields[1])
+		var got string
+		switch fields[0] {
+		case "add":
+			got = x.Add(mustParse(line, fields[2])).String()
+		case "sub":
+			got = x.Sub(mustParse(line, fields[2])).String()
+		case "mul":
+			got = x.Mul(mustParse(line, fields[2])).String()
+		case "div":
+			got = x.Quo(mustParse(line, fields[2])).String()
+		case "round":
+			scale, err := strconv.Atoi(fields[2])
+			if err != nil {
+				t.Fatalf("%s:%d: bad scale: %s", path, line, err)
+			}
+			got = x.Round(scale).String()
+		case "cmp":
+			got = strconv.Itoa(x.Cmp(mustParse(line, fields[2])))
+		default:
+			t.Fatalf("%s:%d: unknown operation %q", path, line, fields[0])
+		}
+
+		if got != fields[4] {
+			t.Errorf("%s:%d: %s(%s, %s) should be %s, got %s", path, line,
+				fields[0], fields[1], fields[2], fields[4], got)
+		}
+		count++
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("failed to read vectors: %s", err)
+	}
+	if count == 0 {
+		t.Fatalf("no vectors in %s", path)
+	}
+}
+
+// TestClassify checks the classification and special values.
+func TestClassify(t *testing.T) {
+	var zero Decimal128
+	if !zero.IsZero() || zero.Sign() != 0 || zero.String() != "0E-6176" {
+		t.Errorf("zero value should be 0E-6176, got %s", zero)
+	}
+
+	one := FromInt64(1)
+	if one.IsZero() || one.Sign() != +1 || one.Neg().Sign() != -1 || one.Neg().Abs() != one {
+		t.Errorf("bad sign of %s", one)
+	}
+	if !Inf(+1).IsInf(+1) || Inf(+1).IsInf(-1) || !Inf(-1).IsInf(-1) || !Inf(-1).IsInf(0) {
+		t.Errorf("bad infinities")
+	}
+	if !NaN().IsNaN() || NaN().IsInf(0) || NaN().IsZero() || NaN().Sign() != 0 {
+		t.Errorf("bad NaN")
+	}
+	if x, _ := Parse("-sNaN"); !x.IsNaN() || x.Bits().Hi != 0xFE00000000000000 {
+		t.Errorf("bad signaling NaN %#x", x.Bits())
+	}
+
+	x, _ := Parse("-123.4500")
+	if x.Coefficient() != uint128.From64(1234500) || x.Exponent() != -4 || !x.Signbit() {
+		t.Errorf("bad parts of %s", x)
+	}
+	if !Inf(+1).Coefficient().IsZero() || NaN().Exponent() != 0 {
+		t.Errorf("bad parts of specials")
+	}
+
+	// comparisons
+	a, _ := Parse("1.0")
+	b, _ := Parse("1.00")
+	if !a.Equal(b) || a.Bits() == b.Bits() || a.Less(b) || !a.Less(Inf(+1)) {
+		t.Errorf("bad comparison of %s and %s", a, b)
+	}
+	if NaN().Equal(NaN()) || NaN().Less(one) || one.Less(NaN()) {
+		t.Errorf("NaN should be unordered")
+	}
+	if NaN().Cmp(NaN()) != 0 || NaN().Cmp(Inf(-1)) != -1 || Inf(-1).Cmp(NaN()) != +1 {
+		t.Errorf("NaN should be the lowest for Cmp")
+	}
+}
+
+// TestIntegers checks the integer conversions.
+func TestIntegers(t *testing.T) {
+	max := uint128.Max()
+	tests := []struct {
+		v     Uint128
+		scale int
+		s     string
+		exact bool
+	}{
+		{uint128.From64(12345), 2, "123.45", true},
+		{uint128.From64(12345), 0, "12345", true},
+		{uint128.From64(12345), -3, "1.2345E+7", true},
+		{uint128.From64(0), 2, "0.00", true},
+		{uint128.From64(1), 6176, "1E-6176", true},
+		{uint128.From64(15), 6177, "2E-6176", false},
+		{uint128.From64(25), 6177, "2E-6176", false},
+		{max, 0, "3.402823669209384634633746074317682E+38", false},
+		{max, 38, "3.402823669209384634633746074317682", false},
+		{pow10[33].Lo, -6111, "1.000000000000000000000000000000000E+6144", true},
+		{pow10[33].Lo, -6112, "Infinity", false},
+	}
+	for _, tt := range tests {
+		x, exact := FromUint128(tt.v, tt.scale)
+		if x.String() != tt.s || exact != tt.exact {
+			t.Errorf("FromUint128(%s, %d) should be %s (%t), got %s (%t)",
+				tt.v, tt.scale, tt.s, tt.exact, x, exact)
+		}
+	}
+
+	back := []struct {
+		s     string
+		scale int
+		v     Uint128
+		exact bool
+	}{
+		{"123.45", 2, uint128.From64(12345), true},
+		{"123.45", 1, uint128.From64(1234), false},
+		{"123.55", 1, uint128.From64(1236), false},
+		{"1.5", 0, uint128.From64(2), false},
+		{"2.5", 0, uint128.From64(2), false},
+		{"1E+5", -2, uint128.From64(1000), true},
+		{"-0.00", 2, uint128.Zero(), true},
+		{"-1", 0, uint128.Zero(), false},
+		{"0.4", -1000, uint128.Zero(), false},
+		{"3.402823669209384634633746074317682E+38", 0, mustUint("340282366920938463463374607431768200000"), true},
+		{"3.402823669209384634633746074317682E+38", 1, max, false},
+		{"1E+6144", 0, max, false},
+		{"Infinity", 0, max, false},
+		{"-Infinity", 0, uint128.Zero(), false},
+		{"NaN", 0, uint128.Zero(), false},
+	}
+	for _, tt := range back {
+		x, err := Parse(tt.s)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %s", tt.s, err)
+		}
+		if v, exact := x.Uint128(tt.scale); v != tt.v || exact != tt.exact {
+			t.Errorf("%s.Uint128(%d) should be %s (%t), got %s (%t)",
+				tt.s, tt.scale, tt.v, tt.exact, v, exact)
+		}
+	}
+}
+
+// mustUint parses the 128-bit integer.
+func mustUint(s string) Uint128 {
+	v, err := uint128.FromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TestParse checks the parse errors and text marshaling.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s   string
+		out string
+		err error
+	}{
+		{"1.23", "1.23", nil},
+		{"12345678901234567890123456789012345", "1.234567890123456789012345678901234E+34", nil},
+		{"12345678901234567890123456789012355", "1.234567890123456789012345678901236E+34", nil},
+		{"1E-6177", "0E-6176", nil},
+		{"-1e6145", "-Infinity", ErrRange},
+		{"1e", "NaN", ErrSyntax},
+		{"0x10", "NaN", ErrSyntax},
+	}
+	for _, tt := range tests {
+		x, err := Parse(tt.s)
+		if x.String() != tt.out || !errors.Is(err, tt.err) && err != tt.err {
+			t.Errorf("Parse(%q) should be %s (%v), got %s (%v)", tt.s, tt.out, tt.err, x, err)
+		}
+	}
+
+	if _, err := ParseExact("12345678901234567890123456789012345"); !errors.Is(err, ErrInexact) {
+		t.Errorf("ParseExact should fail with ErrInexact, got %v", err)
+	}
+
+	var x Decimal128
+	if err := x.UnmarshalText([]byte("-1.50E+3")); err != nil || x.String() != "-1.50E+3" {
+		t.Errorf("UnmarshalText failed: %s (%v)", x, err)
+	}
+	if b, err := x.MarshalText(); err != nil || string(b) != "-1.50E+3" {
+		t.Errorf("MarshalText failed: %s (%v)", b, err)
+	}
+	if err := x.UnmarshalText([]byte("abc")); !errors.Is(err, ErrSyntax) {
+		t.Errorf("UnmarshalText should fail, got %v", err)
+	}
+}
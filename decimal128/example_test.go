@@ -0,0 +1,64 @@
+package decimal128_test
+
+import (
+	"fmt"
+
+	"github.com/Pilatuz/bigz/decimal128"
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// ExampleDecimal128 is an example for Decimal128 arithmetic.
+func ExampleDecimal128() {
+	price, _ := decimal128.Parse("19.99")
+	qty := decimal128.FromInt64(3)
+	total := price.Mul(qty)
+	fmt.Println(total)
+	fmt.Println(total.Quo(decimal128.FromInt64(7)))
+	fmt.Println(total.Quo(decimal128.FromInt64(7)).Round(2))
+	fmt.Printf("%#x\n", total.Bits())
+	// Output:
+	// 59.97
+	// 8.567142857142857142857142857142857
+	// 8.57
+	// 0x303c000000000000000000000000176d
+}
+
+// ExampleDecimal128_Round is an example for banker's rounding.
+func ExampleDecimal128_Round() {
+	for _, s := range []string{"0.125", "0.135", "-0.125", "1234.5"} {
+		x, _ := decimal128.Parse(s)
+		fmt.Println(x.Round(2), x.Round(0), x.Round(-2))
+	}
+	// Output:
+	// 0.12 0 0E+2
+	// 0.14 0 0E+2
+	// -0.12 -0 -0E+2
+	// 1234.50 1234 1.2E+3
+}
+
+// ExampleFromUint128 is an example for integer conversions with scale.
+func ExampleFromUint128() {
+	cents := uint128.From64(123456)
+	x, exact := decimal128.FromUint128(cents, 2)
+	fmt.Println(x, exact)
+	fmt.Println(x.Uint128(2))
+	fmt.Println(x.Uint128(0))
+	// Output:
+	// 1234.56 true
+	// 123456 true
+	// 1235 false
+}
+
+// ExampleParseExact is an example for BSON compatible parsing.
+func ExampleParseExact() {
+	x, err := decimal128.ParseExact("1.50E+3")
+	fmt.Println(x, err)
+	_, err = decimal128.ParseExact("1.0000000000000000000000000000000001")
+	fmt.Println(err)
+	x, err = decimal128.Parse("1.0000000000000000000000000000000001")
+	fmt.Println(x, err)
+	// Output:
+	// 1.50E+3 <nil>
+	// strconv.ParseExact: parsing "1.0000000000000000000000000000000001": value cannot be represented exactly
+	// 1.000000000000000000000000000000000 <nil>
+}
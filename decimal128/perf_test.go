@@ -0,0 +1,98 @@
+package decimal128
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// DummyOutput is exported to avoid unwanted optimizations.
+var DummyOutput int
+
+// randDecimalSlice generates slice of random nonzero decimal128 values
+// with 34 digits and small exponents.
+func randDecimalSlice(count int) []Decimal128 {
+	r := rand.New(rand.NewSource(1))
+	out := make([]Decimal128, 0, count)
+	for len(out) < count {
+		c := Uint128{Lo: r.Uint64(), Hi: r.Uint64() & coefMask}
+		if c.Cmp(pow10[maxDigits].Lo) < 0 && c.Cmp(pow10[maxDigits-1].Lo) >= 0 {
+			out = append(out, pack(r.Intn(2) == 0, c, r.Intn(40)-36))
+		}
+	}
+	return out
+}
+
+// BenchmarkArith performance tests for arithmetic operations.
+func BenchmarkArith(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := randDecimalSlice(K)
+	yy := randDecimalSlice(K + 1)[1:]
+	ss := make([]string, K)
+	for i, x := range xx {
+		ss[i] = x.String()
+	}
+
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Add(yy[i%K]).bits.Lo & 1)
+		}
+	})
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Mul(yy[i%K]).bits.Lo & 1)
+		}
+	})
+
+	b.Run("Quo", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Quo(yy[i%K]).bits.Lo & 1)
+		}
+	})
+
+	b.Run("Big.Quo", func(b *testing.B) {
+		rr := make([]*big.Rat, K)
+		for i, x := range xx {
+			rr[i], _ = new(big.Rat).SetString(x.String())
+		}
+		z := new(big.Rat)
+		for i := 0; i < b.N; i++ {
+			z.Quo(rr[i%K], rr[(i+1)%K])
+			DummyOutput += z.Sign()
+		}
+	})
+
+	b.Run("Cmp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += xx[i%K].Cmp(yy[i%K])
+		}
+	})
+
+	b.Run("Round", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Round(2).bits.Lo & 1)
+		}
+	})
+
+	b.Run("String", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += len(xx[i%K].String())
+		}
+	})
+
+	b.Run("Parse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x, _ := Parse(ss[i%K])
+			DummyOutput += int(x.bits.Lo & 1)
+		}
+	})
+
+	b.Run("Uint128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v, _ := xx[i%K].Abs().Uint128(40)
+			DummyOutput += int(v.Lo & 1)
+		}
+	})
+
+}
@@ -0,0 +1,87 @@
+#!/usr/bin/env python3
+"""Generates the decimal128 test data with Python's decimal module.
+
+The decimal module (libmpdec) is an independent implementation of the
+IEEE 754-2008 decimal arithmetic, configured here for decimal128:
+34 digits, Emax 6144 and clamping, rounding half to even.
+
+	python3 gen.py
+
+writes arith.txt in the current directory. The BSON corpus in
+bson-corpus/ is vendored from the specification, not generated.
+"""
+
+import random
+from decimal import Context, Decimal, ROUND_HALF_EVEN
+
+CTX = Context(prec=34, rounding=ROUND_HALF_EVEN, Emin=-6143, Emax=6144,
+              clamp=1, traps=[])
+
+
+def operand(rnd):
+    """Generates a random decimal128 operand biased to the edge cases."""
+    r = rnd.random()
+    if r < 0.02:
+        return Decimal(rnd.choice(['NaN', 'Infinity', '-Infinity', '0', '-0', 'sNaN']))
+    ndigits = rnd.choice([1, 2, 3, 5, 10, 17, 20, 33, 34, 34, 34])
+    coef = rnd.randrange(10**(ndigits - 1), 10**ndigits)
+    if rnd.random() < 0.1:
+        coef = 10**34 - 1 - rnd.randrange(10)
+    if rnd.random() < 0.05:
+        coef = 0
+    if rnd.random() < 0.15:
+        coef *= 10**rnd.randrange(0, 34 - len(str(coef)) + 1)
+    r = rnd.random()
+    if r < 0.6:
+        exp = rnd.randrange(-40, 10)
+    elif r < 0.8:
+        exp = rnd.choice([-6176, -6175, -6150, -6143, 6111, 6110, 6100, 6080])
+    else:
+        exp = rnd.randrange(-6176, 6112)
+    assert coef < 10**34
+    return Decimal((rnd.randrange(2), tuple(map(int, str(coef))), exp))
+
+
+def arith():
+    rnd = random.Random(46)
+    ops = {
+        'add': CTX.add,
+        'sub': CTX.subtract,
+        'mul': CTX.multiply,
+        'div': CTX.divide,
+    }
+    lines = [
+        "# decimal128 arithmetic test vectors generated by gen.py with",
+        "# Python's decimal module, rounding half to even:",
+        "# <operation> <operands...> -> <result>",
+    ]
+    for name, fn in ops.items():
+        for i in range(600):
+            x, y = operand(rnd), operand(rnd)
+            if i % 3 == 0 and x.is_finite() and y.is_finite():
+                # close exponents exercise the cancellation and carries
+                y = y.scaleb(x.adjusted() - y.adjusted() + rnd.randrange(-3, 4), CTX)
+                y = CTX.plus(y)
+                if not y.is_finite():
+                    continue
+            r = fn(x, y)
+            lines.append("%s %s %s -> %s" % (name, x, y, r))
+    for i in range(600):
+        x = operand(rnd)
+        scale = rnd.choice([0, 1, 2, 4, 8, 18, 33, 40, -1, -3, rnd.randrange(-6176, 6177)])
+        r = CTX.quantize(x, Decimal((0, (1,), -scale)))
+        lines.append("round %s %d -> %s" % (x, scale, r))
+    for i in range(300):
+        x, y = operand(rnd), operand(rnd)
+        if i % 2 == 0 and x.is_finite():
+            y = CTX.plus(x.scaleb(rnd.randrange(-1, 2)) if i % 4 else x.quantize(Decimal(1).scaleb(x.as_tuple().exponent - rnd.randrange(0, 3)), context=CTX))
+        r = CTX.compare(x, y)
+        if r.is_nan():
+            continue
+        lines.append("cmp %s %s -> %s" % (x, y, r))
+    return '\n'.join(lines) + '\n'
+
+
+if __name__ == '__main__':
+    with open('arith.txt', 'w') as f:
+        f.write(arith())
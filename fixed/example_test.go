@@ -0,0 +1,41 @@
+package fixed_test
+
+import (
+	"fmt"
+
+	"github.com/Pilatuz/bigz/fixed"
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// ExampleUQ64x64 is an example for Q64.64 price math.
+func ExampleUQ64x64() {
+	// price of token A in token B from the pool reserves
+	price, _ := fixed.FromRatio64x64(uint128.From64(3_000_000), uint128.From64(1_250))
+	fmt.Println(price, price.Text(4))
+
+	amount := fixed.FromInteger64x64(7)
+	out, _ := price.Mul(amount)
+	fmt.Println(out.Uint64(), out.Frac().Text(6))
+
+	fmt.Println(fixed.FromInteger64x64(2).Sqrt().Text(18))
+	l, neg := price.Log2()
+	fmt.Println(l.Text(12), neg)
+	// Output:
+	// 2400 2400.0000
+	// 16800 0.000000
+	// 1.414213562373095049
+	// 11.228818690496 false
+}
+
+// ExampleParse128x128 is an example for Q128.128 decimal strings.
+func ExampleParse128x128() {
+	x, _ := fixed.Parse128x128("0.1")
+	fmt.Println(x.Text(40))
+	fmt.Println(x.Float64())
+	y, ok := x.Div(fixed.FromInteger128x128(uint128.From64(3)))
+	fmt.Println(y.Text(40), ok)
+	// Output:
+	// 0.1000000000000000000000000000000000000012
+	// 0.1
+	// 0.0333333333333333333333333333333333333318 true
+}
@@ -0,0 +1,169 @@
+// Package fixed implements unsigned binary fixed-point numbers on top of
+// uint128.Uint128 and uint256.Uint256.
+//
+// UQ64x64 is a Q64.64 number: a Uint128 with 64 integer bits and 64
+// fractional bits. UQ128x128 is a Q128.128 number: a Uint256 with 128
+// integer bits and 128 fractional bits. Their products and quotients are
+// computed with the full-width intermediates, so no precision is lost
+// before the final truncation.
+//
+// The arithmetic truncates toward zero and reports overflows with the
+// ok flag, like the rounding helpers of the uint128 package do.
+package fixed
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/Pilatuz/bigz/uint128"
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// Uint128 is an alias for uint128.Uint128 type.
+type Uint128 = uint128.Uint128
+
+// Uint256 is an alias for uint256.Uint256 type.
+type Uint256 = uint256.Uint256
+
+// Errors returned by Parse functions are of *strconv.NumError type,
+// its Err field is one of the following errors.
+// ErrSyntax and ErrRange are the same errors as defined in strconv package.
+var (
+	// ErrSyntax indicates that a value does not have the right syntax.
+	ErrSyntax = strconv.ErrSyntax
+
+	// ErrRange indicates that a value is out of range.
+	ErrRange = strconv.ErrRange
+)
+
+// isqrt256 returns the integer square root of n,
+// starting from the seed estimate.
+func isqrt256(n Uint256, seed float64) Uint256 {
+	if n.IsZero() {
+		return n
+	}
+
+	x, _ := uint256.FromFloat64(seed)
+	if x.IsZero() {
+		x = uint256.One()
+	}
+
+	// one Newton step from any guess gives the upper bound,
+	// then the iterations decrease down to the root
+	y := x.Add(n.Div(x)).Rsh(1)
+	for {
+		x = y
+		if y = x.Add(n.Div(x)).Rsh(1); y.Cmp(x) >= 0 {
+			return x
+		}
+	}
+}
+
+// isqrt512 returns the integer square root of hi:lo, starting from
+// the seed estimate. The root must be greater than hi.
+func isqrt512(hi, lo Uint256, seed float64) Uint256 {
+	if hi.IsZero() {
+		return isqrt256(lo, seed)
+	}
+
+	x, _ := uint256.FromFloat64(seed)
+	if x.Cmp(hi) <= 0 {
+		x = hi.Add(uint256.One())
+	}
+
+	q, _ := uint256.Div(hi, lo, x)
+	y := x.Add(q).Rsh(1)
+	for {
+		x = y
+		q, _ = uint256.Div(hi, lo, x)
+		if y = x.Add(q).Rsh(1); y.Cmp(x) >= 0 {
+			return x
+		}
+	}
+}
+
+// formatFixed formats the value i/2^n in decimal. With negative prec
+// all the digits are printed, this is always exact, trailing zeros are
+// trimmed. Otherwise the value is rounded to prec fractional digits,
+// ties to even.
+func formatFixed(i *big.Int, n uint, prec int) string {
+	one := new(big.Int).Lsh(big.NewInt(1), n)
+	if prec < 0 {
+		ip, fp := new(big.Int).QuoRem(i, one, new(big.Int))
+		if fp.Sign() == 0 {
+			return ip.String()
+		}
+
+		// fp/2^n = fp·5^n/10^n, exactly n fractional digits
+		fp.Mul(fp, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(n)), nil))
+		digits := fp.String()
+		buf := make([]byte, 0, 80+n)
+		buf = ip.Append(buf, 10)
+		buf = append(buf, '.')
+		for k := len(digits); k < int(n); k++ {
+			buf = append(buf, '0')
+		}
+		buf = append(buf, digits...)
+		for buf[len(buf)-1] == '0' {
+			buf = buf[:len(buf)-1]
+		}
+		return string(buf)
+	}
+
+	// round i·10^prec/2^n to integer
+	s := new(big.Int).Mul(i, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil))
+	q, r := s.QuoRem(s, one, new(big.Int))
+	if c := r.Lsh(r, 1).Cmp(one); c > 0 || c == 0 && q.Bit(0) != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	digits := q.String()
+	if prec == 0 {
+		return digits
+	}
+	for len(digits) <= prec {
+		digits = "0" + digits
+	}
+	k := len(digits) - prec
+	return digits[:k] + "." + digits[k:]
+}
+
+// parseFixed parses the decimal string as i/2^n value, rounded to
+// nearest, ties to even. The syntax is the digits with optional
+// decimal point, e.g. "123.456", ".5" or "7.".
+func parseFixed(fn, s string, n uint) (*big.Int, error) {
+	i := new(big.Int)
+	digits, frac, point := 0, 0, false
+	for k := 0; k < len(s); k++ {
+		switch c := s[k]; {
+		case c == '.' && !point:
+			point = true
+		case c >= '0' && c <= '9':
+			i.Mul(i, big.NewInt(10))
+			i.Add(i, big.NewInt(int64(c-'0')))
+			digits++
+			if point {
+				frac++
+			}
+		default:
+			return nil, &strconv.NumError{Func: fn, Num: s, Err: ErrSyntax}
+		}
+	}
+	if digits == 0 {
+		return nil, &strconv.NumError{Func: fn, Num: s, Err: ErrSyntax}
+	}
+
+	// i·2^n/10^frac rounded to nearest
+	i.Lsh(i, n)
+	if frac > 0 {
+		d := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(frac)), nil)
+		q, r := i.QuoRem(i, d, new(big.Int))
+		if c := r.Lsh(r, 1).Cmp(d); c > 0 || c == 0 && q.Bit(0) != 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if i.BitLen() > int(2*n) {
+		return nil, &strconv.NumError{Func: fn, Num: s, Err: ErrRange}
+	}
+	return i, nil
+}
@@ -0,0 +1,349 @@
+package fixed
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/Pilatuz/bigz/uint128"
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// rand128 generates random 128-bit value with random bit length.
+func rand128(r *rand.Rand) Uint128 {
+	u := Uint128{Lo: r.Uint64(), Hi: r.Uint64()}
+	return u.Rsh(uint(r.Intn(129)))
+}
+
+// rand256 generates random 256-bit value with random bit length.
+func rand256(r *rand.Rand) Uint256 {
+	u := Uint256{Lo: Uint128{Lo: r.Uint64(), Hi: r.Uint64()}, Hi: Uint128{Lo: r.Uint64(), Hi: r.Uint64()}}
+	return u.Rsh(uint(r.Intn(257)))
+}
+
+// refMul returns x·y/2^n truncated and whether it fits 2n bits.
+func refMul(x, y *big.Int, n uint) (*big.Int, bool) {
+	z := new(big.Int).Mul(x, y)
+	z.Rsh(z, n)
+	return z, z.BitLen() <= int(2*n)
+}
+
+// refDiv returns x·2^n/y truncated and whether it fits 2n bits.
+func refDiv(x, y *big.Int, n uint) (*big.Int, bool) {
+	if y.Sign() == 0 {
+		return new(big.Int), false
+	}
+	z := new(big.Int).Lsh(x, n)
+	z.Quo(z, y)
+	return z, z.BitLen() <= int(2*n)
+}
+
+// refSqrt returns sqrt(x·2^n) truncated.
+func refSqrt(x *big.Int, n uint) *big.Int {
+	z := new(big.Int).Lsh(x, n)
+	return z.Sqrt(z)
+}
+
+// TestArith64x64 checks Q64.64 arithmetic against math/big.
+func TestArith64x64(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	for i := 0; i < 20000; i++ {
+		a, b := rand128(r), rand128(r)
+		x, y := FromBits64x64(a), FromBits64x64(b)
+		ab, bb := a.Big(), b.Big()
+
+		if z, ok := x.Mul(y); true {
+			e, eok := refMul(ab, bb, 64)
+			if ok != eok || ok && z.Bits().Big().Cmp(e) != 0 || !ok && !z.IsZero() {
+				t.Fatalf("%#x.Mul(%#x) should be %#x (%t), got %#x (%t)", a, b, e, eok, z.Bits(), ok)
+			}
+		}
+
+		if z, ok := x.Div(y); true {
+			e, eok := refDiv(ab, bb, 64)
+			if ok != eok || ok && z.Bits().Big().Cmp(e) != 0 || !ok && !z.IsZero() {
+				t.Fatalf("%#x.Div(%#x) should be %#x (%t), got %#x (%t)", a, b, e, eok, z.Bits(), ok)
+			}
+		}
+
+		if z := x.Sqrt(); z.Bits().Big().Cmp(refSqrt(ab, 64)) != 0 {
+			t.Fatalf("%#x.Sqrt() should be %#x, got %#x", a, refSqrt(ab, 64), z.Bits())
+		}
+
+		if z, ok := x.Add(y); ok != (a.Add(b).Cmp(a) >= 0) || z.Bits() != a.Add(b) {
+			t.Fatalf("%#x.Add(%#x) failed", a, b)
+		}
+		if z, ok := x.Sub(y); ok != (a.Cmp(b) >= 0) || z.Bits() != a.Sub(b) {
+			t.Fatalf("%#x.Sub(%#x) failed", a, b)
+		}
+		if x.Cmp(y) != a.Cmp(b) {
+			t.Fatalf("%#x.Cmp(%#x) failed", a, b)
+		}
+
+		// floor + frac
+		if s, _ := x.Floor().Add(x.Frac()); s != x || x.Floor().Uint64() != x.Uint64() || !x.Floor().Frac().IsZero() {
+			t.Fatalf("%#x floor/frac failed", a)
+		}
+		if c, ok := x.Ceil(); ok && (c.Cmp(x) < 0 || !c.Frac().IsZero() || c.Uint64()-x.Uint64() > 1) {
+			t.Fatalf("%#x.Ceil() failed: %#x", a, c.Bits())
+		}
+
+		// exact string round-trip
+		if p, err := Parse64x64(x.String()); err != nil || p != x {
+			t.Fatalf("Parse64x64(%q) should be %#x, got %#x (%v)", x.String(), a, p.Bits(), err)
+		}
+
+		// float64 conversions
+		f := new(big.Float).SetInt(ab)
+		f.SetMantExp(f, -64)
+		if e, _ := f.Float64(); x.Float64() != e {
+			t.Fatalf("%#x.Float64() should be %g, got %g", a, e, x.Float64())
+		}
+	}
+}
+
+// TestArith128x128 checks Q128.128 arithmetic against math/big.
+func TestArith128x128(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	for i := 0; i < 10000; i++ {
+		a, b := rand256(r), rand256(r)
+		x, y := FromBits128x128(a), FromBits128x128(b)
+		ab, bb := a.Big(), b.Big()
+
+		if z, ok := x.Mul(y); true {
+			e, eok := refMul(ab, bb, 128)
+			if ok != eok || ok && z.Bits().Big().Cmp(e) != 0 || !ok && !z.IsZero() {
+				t.Fatalf("%#x.Mul(%#x) should be %#x (%t), got %#x (%t)", ab, bb, e, eok, z.Bits().Big(), ok)
+			}
+		}
+
+		if z, ok := x.Div(y); true {
+			e, eok := refDiv(ab, bb, 128)
+			if ok != eok || ok && z.Bits().Big().Cmp(e) != 0 || !ok && !z.IsZero() {
+				t.Fatalf("%#x.Div(%#x) should be %#x (%t), got %#x (%t)", ab, bb, e, eok, z.Bits().Big(), ok)
+			}
+		}
+
+		if z := x.Sqrt(); z.Bits().Big().Cmp(refSqrt(ab, 128)) != 0 {
+			t.Fatalf("%#x.Sqrt() should be %#x, got %#x", ab, refSqrt(ab, 128), z.Bits().Big())
+		}
+
+		if z, ok := x.Add(y); ok != (a.Add(b).Cmp(a) >= 0) || z.Bits() != a.Add(b) {
+			t.Fatalf("%#x.Add(%#x) failed", ab, bb)
+		}
+		if z, ok := x.Sub(y); ok != (a.Cmp(b) >= 0) || z.Bits() != a.Sub(b) {
+			t.Fatalf("%#x.Sub(%#x) failed", ab, bb)
+		}
+		if x.Cmp(y) != a.Cmp(b) {
+			t.Fatalf("%#x.Cmp(%#x) failed", ab, bb)
+		}
+
+		if s, _ := x.Floor().Add(x.Frac()); s != x || x.Floor().Uint128() != x.Uint128() || !x.Floor().Frac().IsZero() {
+			t.Fatalf("%#x floor/frac failed", ab)
+		}
+		if c, ok := x.Ceil(); ok && (c.Cmp(x) < 0 || !c.Frac().IsZero() || c.Uint128().Sub(x.Uint128()).Cmp64(1) > 0) {
+			t.Fatalf("%#x.Ceil() failed: %#x", ab, c.Bits().Big())
+		}
+
+		if p, err := Parse128x128(x.String()); err != nil || p != x {
+			t.Fatalf("Parse128x128(%q) should be %#x, got %#x (%v)", x.String(), ab, p.Bits().Big(), err)
+		}
+
+		f := new(big.Float).SetInt(ab)
+		f.SetMantExp(f, -128)
+		if e, _ := f.Float64(); x.Float64() != e {
+			t.Fatalf("%#x.Float64() should be %g, got %g", ab, e, x.Float64())
+		}
+	}
+}
+
+// TestEdges checks the boundary cases.
+func TestEdges(t *testing.T) {
+	max64 := FromBits64x64(uint128.Max())
+	if _, ok := max64.Ceil(); ok {
+		t.Errorf("Max.Ceil() should overflow")
+	}
+	if c, ok := max64.Floor().Ceil(); !ok || c != max64.Floor() {
+		t.Errorf("Floor(Max).Ceil() should be exact")
+	}
+	if _, ok := FromInteger64x64(1).Div(FromBits64x64(uint128.Zero())); ok {
+		t.Errorf("division by zero should fail")
+	}
+	if _, ok := FromRatio64x64(uint128.From64(1), uint128.Zero()); ok {
+		t.Errorf("division by zero should fail")
+	}
+	if x, ok := FromRatio64x64(uint128.Max(), uint128.Max()); !ok || x != FromInteger64x64(1) {
+		t.Errorf("Max/Max should be 1, got %s", x)
+	}
+	if x := max64.Sqrt(); x.Uint64() != math.MaxUint32 {
+		t.Errorf("Max.Sqrt() should be near 2^32, got %s", x)
+	}
+
+	max128 := FromBits128x128(uint256.Max())
+	if _, ok := max128.Ceil(); ok {
+		t.Errorf("Max.Ceil() should overflow")
+	}
+	if _, ok := FromRatio128x128(uint256.One(), uint256.Zero()); ok {
+		t.Errorf("division by zero should fail")
+	}
+	if x := max128.Sqrt(); x.Uint128() != uint128.Max().Rsh(64) {
+		t.Errorf("Max.Sqrt() should be near 2^64, got %s", x)
+	}
+
+	// float64 conversions
+	floats := []struct {
+		f  float64
+		s  string
+		ok bool
+	}{
+		{0, "0", true},
+		{1.5, "1.5", true},
+		{0x1p-64, "0.0000000000000000000542101086242752217003726400434970855712890625", true},
+		{0x1p-65, "0", true},
+		{-1, "0", false},
+		{math.NaN(), "0", false},
+		{0x1p64, "18446744073709551615.9999999999999999999457898913757247782996273599565029144287109375", false},
+		{math.Inf(+1), "18446744073709551615.9999999999999999999457898913757247782996273599565029144287109375", false},
+	}
+	for _, tt := range floats {
+		if x, ok := FromFloat64x64(tt.f); x.String() != tt.s || ok != tt.ok {
+			t.Errorf("FromFloat64x64(%g) should be %s (%t), got %s (%t)", tt.f, tt.s, tt.ok, x, ok)
+		}
+	}
+	if x, ok := FromFloat128x128(0x1p-128); !ok || x.Bits() != uint256.One() {
+		t.Errorf("FromFloat128x128(2^-128) should be the least value, got %s", x)
+	}
+	if x, ok := FromFloat128x128(1e100); ok || x != max128 {
+		t.Errorf("FromFloat128x128(1e100) should overflow, got %s", x)
+	}
+}
+
+// TestLog2 checks the binary logarithm.
+func TestLog2(t *testing.T) {
+	// log2(3) = 1.5849625007211561814537389439478165087598...
+	log3, _ := new(big.Float).SetPrec(200).SetString("1.58496250072115618145373894394781650875981440769248106045575265454")
+
+	l, neg := FromInteger64x64(3).Log2()
+	if e, _ := new(big.Float).Mul(log3, big.NewFloat(0x1p64)).Int(nil); neg || new(big.Int).Sub(e, l.Bits().Big()).CmpAbs(big.NewInt(1)) > 0 {
+		t.Errorf("Log2(3) should be %s, got %s", log3.Text('f', 25), l.Text(25))
+	}
+	l2, neg := FromInteger128x128(uint128.From64(3)).Log2()
+	if e, _ := new(big.Float).Mul(log3, new(big.Float).SetMantExp(big.NewFloat(1), 128)).Int(nil); neg || new(big.Int).Sub(e, l2.Bits().Big()).CmpAbs(big.NewInt(1)) > 0 {
+		t.Errorf("Log2(3) should be %s, got %s", log3.Text('f', 45), l2.Text(45))
+	}
+
+	// exact powers of two
+	for n := -64; n < 64; n++ {
+		x := FromBits64x64(uint128.One().Lsh(uint(n + 64)))
+		if l, neg := x.Log2(); l != FromInteger64x64(uint64(abs(n))) || neg != (n < 0) {
+			t.Errorf("Log2(2^%d) should be %d, got %s (%t)", n, n, l, neg)
+		}
+	}
+	for n := -128; n < 128; n++ {
+		x := FromBits128x128(uint256.One().Lsh(uint(n + 128)))
+		if l, neg := x.Log2(); l != FromInteger128x128(uint128.From64(uint64(abs(n)))) || neg != (n < 0) {
+			t.Errorf("Log2(2^%d) should be %d, got %s (%t)", n, n, l, neg)
+		}
+	}
+
+	if l, neg := FromBits64x64(uint128.Zero()).Log2(); !neg || l.Bits() != uint128.Max() {
+		t.Errorf("Log2(0) should be -Inf")
+	}
+	if l, neg := FromBits128x128(uint256.Zero()).Log2(); !neg || l.Bits() != uint256.Max() {
+		t.Errorf("Log2(0) should be -Inf")
+	}
+
+	// random values against float64
+	r := rand.New(rand.NewSource(47))
+	for i := 0; i < 2000; i++ {
+		x := FromBits64x64(rand128(r).Or64(1))
+		l, neg := x.Log2()
+		got := l.Float64()
+		if neg {
+			got = -got
+		}
+		if e := math.Log2(x.Float64()); math.Abs(got-e) > 1e-14*math.Max(1, math.Abs(e)) {
+			t.Fatalf("Log2(%s) should be %g, got %g", x, e, got)
+		}
+
+		y := FromBits128x128(rand256(r).Or128(uint128.One()))
+		l2, neg := y.Log2()
+		got = l2.Float64()
+		if neg {
+			got = -got
+		}
+		if e := math.Log2(y.Float64()); math.Abs(got-e) > 1e-14*math.Max(1, math.Abs(e)) {
+			t.Fatalf("Log2(%s) should be %g, got %g", y, e, got)
+		}
+	}
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// TestText checks the decimal string conversions.
+func TestText(t *testing.T) {
+	third, _ := FromRatio64x64(uint128.From64(1), uint128.From64(3))
+	tests := []struct {
+		x    UQ64x64
+		prec int
+		s    string
+	}{
+		{FromInteger64x64(0), -1, "0"},
+		{FromInteger64x64(42), -1, "42"},
+		{FromInteger64x64(42), 2, "42.00"},
+		{third, 0, "0"},
+		{third, 5, "0.33333"},
+		{third, 19, "0.3333333333333333333"},
+		{third, -1, "0.3333333333333333333152632971252415927665424533188343048095703125"},
+		{FromBits64x64(uint128.From64(1 << 63)), 0, "0"}, // 0.5 ties to even
+		{FromBits64x64(Uint128{Lo: 1 << 63, Hi: 1}), 0, "2"},
+		{FromBits64x64(Uint128{Lo: 1 << 62, Hi: 7}), 1, "7.2"}, // 7.25 ties to even
+		{FromBits64x64(uint128.From64(1)), 3, "0.000"},
+	}
+	for _, tt := range tests {
+		if s := tt.x.Text(tt.prec); s != tt.s {
+			t.Errorf("%#x.Text(%d) should be %q, got %q", tt.x.Bits(), tt.prec, tt.s, s)
+		}
+	}
+
+	parse := []struct {
+		s    string
+		bits Uint128
+		err  error
+	}{
+		{"1", Uint128{Hi: 1}, nil},
+		{"1.5", Uint128{Lo: 1 << 63, Hi: 1}, nil},
+		{".25", Uint128{Lo: 1 << 62}, nil},
+		{"7.", Uint128{Hi: 7}, nil},
+		{"0.0000000000000000000271050543121376108501863200217485427856445312", uint128.Zero(), nil}, // below half of 2^-64
+		{"0.0000000000000000000271050543121376108501863200217485427856445313", uint128.One(), nil},
+		{"18446744073709551615.99999999999999999997", uint128.Max(), nil},
+		{"18446744073709551616", uint128.Zero(), ErrRange},
+		{"", uint128.Zero(), ErrSyntax},
+		{".", uint128.Zero(), ErrSyntax},
+		{"-1", uint128.Zero(), ErrSyntax},
+		{"1.2.3", uint128.Zero(), ErrSyntax},
+		{"1e5", uint128.Zero(), ErrSyntax},
+	}
+	for _, tt := range parse {
+		x, err := Parse64x64(tt.s)
+		if x.Bits() != tt.bits || !errors.Is(err, tt.err) {
+			t.Errorf("Parse64x64(%q) should be %#x (%v), got %#x (%v)", tt.s, tt.bits, tt.err, x.Bits(), err)
+		}
+	}
+
+	if x, err := Parse128x128("340282366920938463463374607431768211455.5"); err != nil || x.Uint128() != uint128.Max() || x.Frac().Bits() != uint256.One().Lsh(127) {
+		t.Errorf("Parse128x128 failed: %s (%v)", x, err)
+	}
+	if _, err := Parse128x128("340282366920938463463374607431768211456"); !errors.Is(err, ErrRange) {
+		t.Errorf("Parse128x128 should overflow, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package fixed
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// DummyOutput is exported to avoid unwanted optimizations.
+var DummyOutput int
+
+// BenchmarkArith performance tests for arithmetic operations.
+func BenchmarkArith(b *testing.B) {
+	const K = 1024 // should be power of 2
+	r := rand.New(rand.NewSource(1))
+	xx := make([]UQ64x64, K)
+	yy := make([]UQ128x128, K)
+	for i := range xx {
+		xx[i] = FromBits64x64(rand128(r))
+		yy[i] = FromBits128x128(rand256(r))
+	}
+
+	b.Run("Mul64x64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z, _ := xx[i%K].Mul(xx[(i+1)%K])
+			DummyOutput += int(z.bits.Lo & 1)
+		}
+	})
+
+	b.Run("Div64x64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z, _ := xx[i%K].Div(xx[(i+1)%K])
+			DummyOutput += int(z.bits.Lo & 1)
+		}
+	})
+
+	b.Run("Sqrt64x64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Sqrt().bits.Lo & 1)
+		}
+	})
+
+	b.Run("Log2_64x64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z, _ := xx[i%K].Log2()
+			DummyOutput += int(z.bits.Lo & 1)
+		}
+	})
+
+	b.Run("Mul128x128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z, _ := yy[i%K].Mul(yy[(i+1)%K])
+			DummyOutput += int(z.bits.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("Div128x128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z, _ := yy[i%K].Div(yy[(i+1)%K])
+			DummyOutput += int(z.bits.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("Sqrt128x128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(yy[i%K].Sqrt().bits.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("Log2_128x128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z, _ := yy[i%K].Log2()
+			DummyOutput += int(z.bits.Lo.Lo & 1)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package fixed
+
+import (
+	"math"
+
+	"github.com/Pilatuz/bigz/uint128"
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// UQ128x128 is an unsigned Q128.128 fixed-point number,
+// the Uint256 value u represents u/2^128.
+// The zero value is zero.
+type UQ128x128 struct {
+	bits Uint256
+}
+
+// FromBits128x128 returns the Q128.128 number with the given raw bits,
+// i.e. bits/2^128.
+func FromBits128x128(bits Uint256) UQ128x128 {
+	return UQ128x128{bits: bits}
+}
+
+// Bits returns the raw bits of x, i.e. x·2^128.
+func (x UQ128x128) Bits() Uint256 {
+	return x.bits
+}
+
+// FromInteger128x128 returns the integer v as a Q128.128 number.
+func FromInteger128x128(v Uint128) UQ128x128 {
+	return UQ128x128{bits: Uint256{Hi: v}}
+}
+
+// FromRatio128x128 returns num/den as a Q128.128 number, truncated toward zero.
+// Provides ok successful flag as a second return value.
+// If den is zero or the result overflows then Zero and ok=false returned.
+func FromRatio128x128(num, den Uint256) (UQ128x128, bool) {
+	hi := Uint256{Lo: num.Hi} // num·2^128
+	lo := Uint256{Hi: num.Lo}
+	if hi.Cmp(den) >= 0 {
+		return UQ128x128{}, false // also division by zero
+	}
+	q, _ := uint256.Div(hi, lo, den)
+	return UQ128x128{bits: q}, true
+}
+
+// Uint128 returns the integer part of x.
+func (x UQ128x128) Uint128() Uint128 {
+	return x.bits.Hi
+}
+
+// Floor returns the greatest integer value less than or equal to x.
+func (x UQ128x128) Floor() UQ128x128 {
+	return UQ128x128{bits: Uint256{Hi: x.bits.Hi}}
+}
+
+// Ceil returns the least integer value greater than or equal to x.
+// Provides ok successful flag as a second return value.
+// If the result overflows then Zero and ok=false returned.
+func (x UQ128x128) Ceil() (UQ128x128, bool) {
+	if x.bits.Lo.IsZero() {
+		return x, true
+	}
+	if x.bits.Hi.Equals(uint128.Max()) {
+		return UQ128x128{}, false
+	}
+	return UQ128x128{bits: Uint256{Hi: x.bits.Hi.Add64(1)}}, true
+}
+
+// Frac returns the fractional part of x.
+func (x UQ128x128) Frac() UQ128x128 {
+	return UQ128x128{bits: Uint256{Lo: x.bits.Lo}}
+}
+
+// IsZero returns true if x is zero.
+func (x UQ128x128) IsZero() bool {
+	return x.bits.IsZero()
+}
+
+// Cmp compares x and y and returns:
+//
+//	-1 if x <  y
+//	 0 if x == y
+//	+1 if x >  y
+func (x UQ128x128) Cmp(y UQ128x128) int {
+	return x.bits.Cmp(y.bits)
+}
+
+// Add returns the sum x+y.
+// Provides ok successful flag as a second return value.
+// If the result overflows then the wrapped sum and ok=false returned.
+func (x UQ128x128) Add(y UQ128x128) (UQ128x128, bool) {
+	s, carry := uint256.Add(x.bits, y.bits, 0)
+	return UQ128x128{bits: s}, carry == 0
+}
+
+// Sub returns the difference x-y.
+// Provides ok successful flag as a second return value.
+// If y is greater than x then the wrapped difference and ok=false returned.
+func (x UQ128x128) Sub(y UQ128x128) (UQ128x128, bool) {
+	d, borrow := uint256.Sub(x.bits, y.bits, 0)
+	return UQ128x128{bits: d}, borrow == 0
+}
+
+// Mul returns the product x*y, truncated toward zero.
+// Provides ok successful flag as a second return value.
+// If the result overflows then Zero and ok=false returned.
+func (x UQ128x128) Mul(y UQ128x128) (UQ128x128, bool) {
+	hi, lo := uint256.Mul(x.bits, y.bits) // 512-bit product
+	if !hi.Hi.IsZero() {
+		return UQ128x128{}, false
+	}
+	return UQ128x128{bits: Uint256{Lo: lo.Hi, Hi: hi.Lo}}, true
+}
+
+// Div returns the quotient x/y, truncated toward zero.
+// Provides ok successful flag as a second return value.
+// If y is zero or the result overflows then Zero and ok=false returned.
+func (x UQ128x128) Div(y UQ128x128) (UQ128x128, bool) {
+	return FromRatio128x128(x.bits, y.bits)
+}
+
+// Sqrt returns the square root of x, truncated toward zero.
+func (x UQ128x128) Sqrt() UQ128x128 {
+	hi := Uint256{Lo: x.bits.Hi} // bits·2^128
+	lo := Uint256{Hi: x.bits.Lo}
+	r := isqrt512(hi, lo, math.Sqrt(x.bits.Float64())*0x1p64)
+	return UQ128x128{bits: r}
+}
+
+// Log2 returns the absolute value of the binary logarithm of x
+// and whether the logarithm is negative, i.e. x < 1. The result has
+// all 128 fractional bits, the error is less than 2^-127.
+// Log2 of zero is minus infinity, returned as Max and true.
+func (x UQ128x128) Log2() (UQ128x128, bool) {
+	if x.bits.IsZero() {
+		return UQ128x128{bits: uint256.Max()}, true
+	}
+
+	// x = 2^n·m, where m is in [1, 2) with 255 fractional bits
+	n := x.bits.BitLen() - 1 - 128
+	m := x.bits.Lsh(uint(x.bits.LeadingZeros()))
+
+	// each squaring of m gives the next bit of log2(m)
+	var f Uint128
+	for i := 127; i >= 0; i-- {
+		hi, lo := uint256.Mul(m, m) // m² in [1, 4) with 510 fractional bits
+		if hi.Hi.Hi&(1<<63) != 0 {
+			f = f.SetBit(uint(i), 1)
+			m = hi
+		} else {
+			m = hi.Lsh(1).Or128(uint128.From64(lo.Hi.Hi >> 63))
+		}
+	}
+
+	if n >= 0 {
+		return UQ128x128{bits: Uint256{Lo: f, Hi: uint128.From64(uint64(n))}}, false
+	}
+	// -(n + f) = -n - f
+	r, _ := uint256.Sub(Uint256{Hi: uint128.From64(uint64(-n))}, Uint256{Lo: f}, 0)
+	return UQ128x128{bits: r}, true
+}
+
+// Float64 returns the nearest float64 value of x,
+// halfway values are rounded to even.
+func (x UQ128x128) Float64() float64 {
+	return x.bits.Float64() * 0x1p-128 // exact scaling
+}
+
+// FromFloat128x128 converts float64 to Q128.128 number truncating toward zero.
+// Provides ok successful flag as a second return value.
+// If input is NaN or negative then Zero and ok=false returned.
+// If input overflows (including +Inf) then Max and ok=false returned.
+func FromFloat128x128(f float64) (UQ128x128, bool) {
+	u, ok := uint256.FromFloat64(f * 0x1p128) // exact scaling
+	return UQ128x128{bits: u}, ok
+}
+
+// String returns the exact decimal representation of x,
+// without trailing zeros, e.g. "1.5".
+func (x UQ128x128) String() string {
+	return formatFixed(x.bits.Big(), 128, -1)
+}
+
+// Text returns the decimal representation of x rounded to prec
+// fractional digits, ties to even. Negative prec is the same as String.
+func (x UQ128x128) Text(prec int) string {
+	return formatFixed(x.bits.Big(), 128, prec)
+}
+
+// Parse128x128 parses the decimal string, e.g. "123.456", as a Q128.128
+// number, rounded to nearest, ties to even.
+func Parse128x128(s string) (UQ128x128, error) {
+	i, err := parseFixed("Parse128x128", s, 128)
+	if err != nil {
+		return UQ128x128{}, err
+	}
+	return UQ128x128{bits: uint256.FromBig(i)}, nil
+}
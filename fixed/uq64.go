@@ -0,0 +1,196 @@
+package fixed
+
+import (
+	"math"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// UQ64x64 is an unsigned Q64.64 fixed-point number,
+// the Uint128 value u represents u/2^64.
+// The zero value is zero.
+type UQ64x64 struct {
+	bits Uint128
+}
+
+// FromBits64x64 returns the Q64.64 number with the given raw bits,
+// i.e. bits/2^64.
+func FromBits64x64(bits Uint128) UQ64x64 {
+	return UQ64x64{bits: bits}
+}
+
+// Bits returns the raw bits of x, i.e. x·2^64.
+func (x UQ64x64) Bits() Uint128 {
+	return x.bits
+}
+
+// FromInteger64x64 returns the integer v as a Q64.64 number.
+func FromInteger64x64(v uint64) UQ64x64 {
+	return UQ64x64{bits: Uint128{Hi: v}}
+}
+
+// FromRatio64x64 returns num/den as a Q64.64 number, truncated toward zero.
+// Provides ok successful flag as a second return value.
+// If den is zero or the result overflows then Zero and ok=false returned.
+func FromRatio64x64(num, den Uint128) (UQ64x64, bool) {
+	hi := Uint128{Lo: num.Hi} // num·2^64
+	lo := Uint128{Hi: num.Lo}
+	if hi.Cmp(den) >= 0 {
+		return UQ64x64{}, false // also division by zero
+	}
+	q, _ := uint128.Div(hi, lo, den)
+	return UQ64x64{bits: q}, true
+}
+
+// Uint64 returns the integer part of x.
+func (x UQ64x64) Uint64() uint64 {
+	return x.bits.Hi
+}
+
+// Floor returns the greatest integer value less than or equal to x.
+func (x UQ64x64) Floor() UQ64x64 {
+	return UQ64x64{bits: Uint128{Hi: x.bits.Hi}}
+}
+
+// Ceil returns the least integer value greater than or equal to x.
+// Provides ok successful flag as a second return value.
+// If the result overflows then Zero and ok=false returned.
+func (x UQ64x64) Ceil() (UQ64x64, bool) {
+	if x.bits.Lo == 0 {
+		return x, true
+	}
+	if x.bits.Hi == math.MaxUint64 {
+		return UQ64x64{}, false
+	}
+	return UQ64x64{bits: Uint128{Hi: x.bits.Hi + 1}}, true
+}
+
+// Frac returns the fractional part of x.
+func (x UQ64x64) Frac() UQ64x64 {
+	return UQ64x64{bits: Uint128{Lo: x.bits.Lo}}
+}
+
+// IsZero returns true if x is zero.
+func (x UQ64x64) IsZero() bool {
+	return x.bits.IsZero()
+}
+
+// Cmp compares x and y and returns:
+//
+//	-1 if x <  y
+//	 0 if x == y
+//	+1 if x >  y
+func (x UQ64x64) Cmp(y UQ64x64) int {
+	return x.bits.Cmp(y.bits)
+}
+
+// Add returns the sum x+y.
+// Provides ok successful flag as a second return value.
+// If the result overflows then the wrapped sum and ok=false returned.
+func (x UQ64x64) Add(y UQ64x64) (UQ64x64, bool) {
+	s, carry := uint128.Add(x.bits, y.bits, 0)
+	return UQ64x64{bits: s}, carry == 0
+}
+
+// Sub returns the difference x-y.
+// Provides ok successful flag as a second return value.
+// If y is greater than x then the wrapped difference and ok=false returned.
+func (x UQ64x64) Sub(y UQ64x64) (UQ64x64, bool) {
+	d, borrow := uint128.Sub(x.bits, y.bits, 0)
+	return UQ64x64{bits: d}, borrow == 0
+}
+
+// Mul returns the product x*y, truncated toward zero.
+// Provides ok successful flag as a second return value.
+// If the result overflows then Zero and ok=false returned.
+func (x UQ64x64) Mul(y UQ64x64) (UQ64x64, bool) {
+	hi, lo := uint128.Mul(x.bits, y.bits) // 256-bit product
+	if hi.Hi != 0 {
+		return UQ64x64{}, false
+	}
+	return UQ64x64{bits: Uint128{Lo: lo.Hi, Hi: hi.Lo}}, true
+}
+
+// Div returns the quotient x/y, truncated toward zero.
+// Provides ok successful flag as a second return value.
+// If y is zero or the result overflows then Zero and ok=false returned.
+func (x UQ64x64) Div(y UQ64x64) (UQ64x64, bool) {
+	return FromRatio64x64(x.bits, y.bits)
+}
+
+// Sqrt returns the square root of x, truncated toward zero.
+func (x UQ64x64) Sqrt() UQ64x64 {
+	n := Uint256{Lo: Uint128{Hi: x.bits.Lo}, Hi: Uint128{Lo: x.bits.Hi}} // bits·2^64
+	r := isqrt256(n, math.Sqrt(x.bits.Float64())*0x1p32)
+	return UQ64x64{bits: r.Lo}
+}
+
+// Log2 returns the absolute value of the binary logarithm of x
+// and whether the logarithm is negative, i.e. x < 1. The result has
+// all 64 fractional bits, the error is less than 2^-63.
+// Log2 of zero is minus infinity, returned as Max and true.
+func (x UQ64x64) Log2() (UQ64x64, bool) {
+	if x.bits.IsZero() {
+		return UQ64x64{bits: uint128.Max()}, true
+	}
+
+	// x = 2^n·m, where m is in [1, 2) with 127 fractional bits
+	n := x.bits.BitLen() - 1 - 64
+	m := x.bits.Lsh(uint(x.bits.LeadingZeros()))
+
+	// each squaring of m gives the next bit of log2(m)
+	var f uint64
+	for bit := uint64(1) << 63; bit != 0; bit >>= 1 {
+		hi, lo := uint128.Mul(m, m) // m² in [1, 4) with 254 fractional bits
+		if hi.Hi&(1<<63) != 0 {
+			f |= bit
+			m = hi
+		} else {
+			m = hi.Lsh(1).Or64(lo.Hi >> 63)
+		}
+	}
+
+	if n >= 0 {
+		return UQ64x64{bits: Uint128{Lo: f, Hi: uint64(n)}}, false
+	}
+	// -(n + f) = -n - f
+	r, _ := uint128.Sub(Uint128{Hi: uint64(-n)}, uint128.From64(f), 0)
+	return UQ64x64{bits: r}, true
+}
+
+// Float64 returns the nearest float64 value of x,
+// halfway values are rounded to even.
+func (x UQ64x64) Float64() float64 {
+	return x.bits.Float64() * 0x1p-64 // exact scaling
+}
+
+// FromFloat64x64 converts float64 to Q64.64 number truncating toward zero.
+// Provides ok successful flag as a second return value.
+// If input is NaN or negative then Zero and ok=false returned.
+// If input overflows (including +Inf) then Max and ok=false returned.
+func FromFloat64x64(f float64) (UQ64x64, bool) {
+	u, ok := uint128.FromFloat64(f * 0x1p64) // exact scaling
+	return UQ64x64{bits: u}, ok
+}
+
+// String returns the exact decimal representation of x,
+// without trailing zeros, e.g. "1.5" or "0.0000000000000000000542101086242752217003726400434970855712890625".
+func (x UQ64x64) String() string {
+	return formatFixed(x.bits.Big(), 64, -1)
+}
+
+// Text returns the decimal representation of x rounded to prec
+// fractional digits, ties to even. Negative prec is the same as String.
+func (x UQ64x64) Text(prec int) string {
+	return formatFixed(x.bits.Big(), 64, prec)
+}
+
+// Parse64x64 parses the decimal string, e.g. "123.456", as a Q64.64
+// number, rounded to nearest, ties to even.
+func Parse64x64(s string) (UQ64x64, error) {
+	i, err := parseFixed("Parse64x64", s, 64)
+	if err != nil {
+		return UQ64x64{}, err
+	}
+	return UQ64x64{bits: uint128.FromBig(i)}, nil
+}
@@ -0,0 +1,416 @@
+package float128
+
+import (
+	"math"
+
+	"github.com/Pilatuz/bigz/uint128"
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// Add returns the sum (x+y).
+func (x Float128) Add(y Float128) Float128 {
+	z, _ := add(x, y, ToNearestEven)
+	return z
+}
+
+// Sub returns the difference (x-y).
+func (x Float128) Sub(y Float128) Float128 {
+	z, _ := sub(x, y, ToNearestEven)
+	return z
+}
+
+// Mul returns the product (x*y).
+func (x Float128) Mul(y Float128) Float128 {
+	z, _ := mul(x, y, ToNearestEven)
+	return z
+}
+
+// Div returns the quotient (x/y).
+func (x Float128) Div(y Float128) Float128 {
+	z, _ := div(x, y, ToNearestEven)
+	return z
+}
+
+// Sqrt returns the square root of x.
+func (x Float128) Sqrt() Float128 {
+	z, _ := sqrt(x, ToNearestEven)
+	return z
+}
+
+// FMA returns the fused multiply-add (x*y+z), computed with only one rounding.
+func (x Float128) FMA(y, z Float128) Float128 {
+	r, _ := fma(x, y, z, ToNearestEven)
+	return r
+}
+
+// Add returns the sum (x+y).
+func (c *Context) Add(x, y Float128) Float128 {
+	return c.raise(add(x, y, c.Mode))
+}
+
+// Sub returns the difference (x-y).
+func (c *Context) Sub(x, y Float128) Float128 {
+	return c.raise(sub(x, y, c.Mode))
+}
+
+// Mul returns the product (x*y).
+func (c *Context) Mul(x, y Float128) Float128 {
+	return c.raise(mul(x, y, c.Mode))
+}
+
+// Div returns the quotient (x/y).
+func (c *Context) Div(x, y Float128) Float128 {
+	return c.raise(div(x, y, c.Mode))
+}
+
+// Sqrt returns the square root of x.
+func (c *Context) Sqrt(x Float128) Float128 {
+	return c.raise(sqrt(x, c.Mode))
+}
+
+// FMA returns the fused multiply-add (x*y+z), computed with only one rounding.
+// Inf * 0 raises the invalid exception even if z is quiet NaN.
+func (c *Context) FMA(x, y, z Float128) Float128 {
+	return c.raise(fma(x, y, z, c.Mode))
+}
+
+// exactZero returns the sign of an exact zero sum of operands with opposite signs.
+func exactZero(mode RoundingMode) Float128 {
+	return zero(mode == ToNegativeInf)
+}
+
+// add returns the rounded sum (x+y).
+func add(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	if x.IsNaN() || y.IsNaN() {
+		return propagateNaN(x, y)
+	}
+
+	switch {
+	case x.IsInf(0):
+		if y.IsInf(0) && x.Signbit() != y.Signbit() {
+			return NaN(), Invalid // (+Inf) + (-Inf)
+		}
+		return x, 0
+	case y.IsInf(0):
+		return y, 0
+	case x.IsZero():
+		if y.IsZero() && x.Signbit() != y.Signbit() {
+			return exactZero(mode), 0
+		}
+		return y, 0
+	case y.IsZero():
+		return x, 0
+	}
+
+	xneg, xexp, xsig := x.unpack()
+	yneg, yexp, ysig := y.unpack()
+	if xexp < yexp || (xexp == yexp && xsig.Cmp(ysig) < 0) {
+		xneg, xexp, xsig, yneg, yexp, ysig = yneg, yexp, ysig, xneg, xexp, xsig
+	}
+
+	// |x| >= |y|, 13 extra bits are enough for the exact cancellation
+	xsig = xsig.Lsh(13)
+	ysig = shiftRightJam(ysig.Lsh(13), uint(xexp-yexp))
+	if xneg == yneg {
+		xsig = xsig.Add(ysig)
+	} else {
+		xsig = xsig.Sub(ysig)
+		if xsig.IsZero() {
+			return exactZero(mode), 0
+		}
+	}
+
+	return round128(xneg, xexp+1, xsig, mode)
+}
+
+// sub returns the rounded difference (x-y).
+func sub(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	if x.IsNaN() || y.IsNaN() {
+		return propagateNaN(x, y)
+	}
+	return add(x, y.Neg(), mode)
+}
+
+// mul returns the rounded product (x*y).
+func mul(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	if x.IsNaN() || y.IsNaN() {
+		return propagateNaN(x, y)
+	}
+
+	neg := x.Signbit() != y.Signbit()
+	switch {
+	case x.IsInf(0), y.IsInf(0):
+		if x.IsZero() || y.IsZero() {
+			return NaN(), Invalid // Inf * 0
+		}
+		return inf(neg), 0
+	case x.IsZero(), y.IsZero():
+		return zero(neg), 0
+	}
+
+	_, xexp, xsig := x.unpack()
+	_, yexp, ysig := y.unpack()
+
+	// the product has 225 or 226 bits, keep the upper 127 bits
+	hi, lo := uint128.Mul(xsig, ysig)
+	sig := hi.Lsh(29).Or(lo.Rsh(99))
+	if !lo.And(uint128.Mask(99)).IsZero() {
+		sig.Lo |= 1 // sticky
+	}
+
+	return round128(neg, xexp+yexp+1, sig, mode)
+}
+
+// div returns the rounded quotient (x/y).
+func div(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	if x.IsNaN() || y.IsNaN() {
+		return propagateNaN(x, y)
+	}
+
+	neg := x.Signbit() != y.Signbit()
+	switch {
+	case x.IsInf(0):
+		if y.IsInf(0) {
+			return NaN(), Invalid // Inf / Inf
+		}
+		return inf(neg), 0
+	case y.IsInf(0):
+		return zero(neg), 0
+	case y.IsZero():
+		if x.IsZero() {
+			return NaN(), Invalid // 0 / 0
+		}
+		return inf(neg), DivByZero
+	case x.IsZero():
+		return zero(neg), 0
+	}
+
+	_, xexp, xsig := x.unpack()
+	_, yexp, ysig := y.unpack()
+
+	// (xsig << 126) / ysig is in range [2^125, 2^127)
+	q, r := uint128.Div(xsig.Rsh(2), xsig.Lsh(126), ysig)
+	if !r.IsZero() {
+		q.Lo |= 1 // sticky
+	}
+
+	return round128(neg, xexp-yexp, q, mode)
+}
+
+// sqrt returns the rounded square root of x.
+func sqrt(x Float128, mode RoundingMode) (Float128, Flags) {
+	switch {
+	case x.IsNaN():
+		return propagateNaN(x)
+	case x.IsZero():
+		return x, 0 // sqrt(-0) = -0
+	case x.Signbit():
+		return NaN(), Invalid
+	case x.IsInf(0):
+		return x, 0
+	}
+
+	_, exp, sig := x.unpack()
+
+	// n = sig << k with even (exp-112-k), n = hi << 128
+	k := 138 + exp&1
+	hi := sig.Lsh(uint(k - 128))
+	r := isqrt(hi)
+	if rh, rl := uint128.Mul(r, r); !rl.IsZero() || !rh.Equals(hi) {
+		r.Lo |= 1 // sticky, the root is irrational
+	}
+
+	return round128(false, (exp-112-k)/2+126, r, mode)
+}
+
+// isqrt returns the integer square root of (hi << 128).
+// The hi has to be in range [2^122, 2^124).
+func isqrt(hi Uint128) Uint128 {
+	// initial estimate from the leading 64 bits, it is never less than the root
+	s := uint(hi.BitLen() - 64)
+	top := hi.Rsh(s).Lo
+	if s&1 != 0 {
+		top >>= 1
+		s++
+	}
+	x := uint128.From64(uint64(math.Sqrt(float64(top))) + 2).Lsh((s + 128) / 2)
+
+	// Newton's iterations are decreasing until the root
+	for {
+		q, _ := uint128.Div(hi, uint128.Zero(), x)
+		y := x.Add(q).Rsh(1)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x = y
+	}
+}
+
+// fma returns the rounded fused multiply-add (x*y+z).
+func fma(x, y, z Float128, mode RoundingMode) (Float128, Flags) {
+	if x.IsNaN() || y.IsNaN() || z.IsNaN() {
+		// Inf * 0 is invalid even if z is quiet NaN, the same as SoftFloat does
+		if (x.IsInf(0) && y.IsZero()) || (x.IsZero() && y.IsInf(0)) {
+			return NaN(), Invalid
+		}
+		return propagateNaN(x, y, z)
+	}
+
+	neg := x.Signbit() != y.Signbit()
+	switch {
+	case x.IsInf(0), y.IsInf(0):
+		if x.IsZero() || y.IsZero() {
+			return NaN(), Invalid // Inf * 0
+		}
+		if z.IsInf(0) && z.Signbit() != neg {
+			return NaN(), Invalid // (+Inf) + (-Inf)
+		}
+		return inf(neg), 0
+	case z.IsInf(0):
+		return z, 0
+	case x.IsZero(), y.IsZero():
+		if z.IsZero() && z.Signbit() != neg {
+			return exactZero(mode), 0
+		}
+		if z.IsZero() {
+			return zero(neg), 0
+		}
+		return z, 0 // exact zero product
+	case z.IsZero():
+		return mul(x, y, mode) // the sum is the product
+	}
+
+	_, xexp, xsig := x.unpack()
+	_, yexp, ysig := y.unpack()
+	zneg, zexp, zsig := z.unpack()
+
+	// the product is p * 2^pexp with the leading one at bit 252 or 253,
+	// the addend is q * 2^qexp with the leading one at bit 253
+	hi, lo := uint128.Mul(xsig, ysig)
+	p := uint256.Uint256{Lo: lo, Hi: hi}.Lsh(28)
+	pexp := xexp + yexp - 224 - 28
+	q := uint256.From128(zsig).Lsh(141)
+	qexp := zexp - 112 - 141
+
+	exp := pexp
+	if pexp >= qexp {
+		q = shiftRightJam256(q, uint(pexp-qexp))
+	} else {
+		p = shiftRightJam256(p, uint(qexp-pexp))
+		exp = qexp
+	}
+
+	if neg == zneg {
+		p = p.Add(q)
+	} else {
+		switch p.Cmp(q) {
+		case 0:
+			return exactZero(mode), 0
+		case -1:
+			p, neg = q.Sub(p), zneg
+		default:
+			p = p.Sub(q)
+		}
+	}
+
+	// keep the upper 127 bits
+	n := 0
+	if l := p.BitLen(); l > 127 {
+		n = l - 127
+		p = shiftRightJam256(p, uint(n))
+	}
+
+	return round128(neg, exp+n+126, p.Lo, mode)
+}
+
+// shiftRightJam256 returns x>>n with the sticky bit.
+func shiftRightJam256(x uint256.Uint256, n uint) uint256.Uint256 {
+	if n >= 256 {
+		if x.IsZero() {
+			return x
+		}
+		return uint256.One()
+	}
+	if n != 0 && x.TrailingZeros() < int(n) {
+		x = x.Rsh(n)
+		x.Lo.Lo |= 1
+		return x
+	}
+	return x.Rsh(n)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// comparison ////////////////////////////////////////////////////////////////
+
+// Equal reports whether x is equal to y. Zeros of either sign are equal.
+// NaN is not equal to anything including itself.
+func (x Float128) Equal(y Float128) bool {
+	c, ok := compare(x, y)
+	return ok && c == 0
+}
+
+// Less reports whether x is less than y. It is false if x or y is NaN.
+func (x Float128) Less(y Float128) bool {
+	c, ok := compare(x, y)
+	return ok && c < 0
+}
+
+// LessEqual reports whether x is less than or equal to y.
+// It is false if x or y is NaN.
+func (x Float128) LessEqual(y Float128) bool {
+	c, ok := compare(x, y)
+	return ok && c <= 0
+}
+
+// Equal reports whether x is equal to y. It is a quiet comparison:
+// only signaling NaN operands raise the invalid exception.
+func (c *Context) Equal(x, y Float128) bool {
+	if x.isSignaling() || y.isSignaling() {
+		c.Flags |= Invalid
+	}
+	return x.Equal(y)
+}
+
+// Less reports whether x is less than y. It is a signaling comparison:
+// any NaN operand raises the invalid exception.
+func (c *Context) Less(x, y Float128) bool {
+	if x.IsNaN() || y.IsNaN() {
+		c.Flags |= Invalid
+	}
+	return x.Less(y)
+}
+
+// LessEqual reports whether x is less than or equal to y. It is a signaling
+// comparison: any NaN operand raises the invalid exception.
+func (c *Context) LessEqual(x, y Float128) bool {
+	if x.IsNaN() || y.IsNaN() {
+		c.Flags |= Invalid
+	}
+	return x.LessEqual(y)
+}
+
+// compare returns -1, 0 or +1 depending on whether x is less than,
+// equal to or greater than y. It returns false if x or y is NaN.
+func compare(x, y Float128) (int, bool) {
+	if x.IsNaN() || y.IsNaN() {
+		return 0, false
+	}
+	if x.IsZero() && y.IsZero() {
+		return 0, true
+	}
+
+	xneg, yneg := x.Signbit(), y.Signbit()
+	if xneg != yneg {
+		if xneg {
+			return -1, true
+		}
+		return +1, true
+	}
+
+	// the same sign, the magnitudes are ordered as integers
+	c := x.Abs().bits.Cmp(y.Abs().bits)
+	if xneg {
+		c = -c
+	}
+	return c, true
+}
@@ -0,0 +1,586 @@
+package float128
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// FromFloat64 returns the binary128 value of f. The conversion is exact,
+// except that a signaling NaN is converted to a quiet NaN.
+func FromFloat64(f float64) Float128 {
+	x, _ := fromFloat64(f)
+	return x
+}
+
+// FromFloat64 returns the binary128 value of f. The conversion is exact,
+// a signaling NaN raises the invalid exception.
+func (c *Context) FromFloat64(f float64) Float128 {
+	return c.raise(fromFloat64(f))
+}
+
+// fromFloat64 converts float64 value.
+func fromFloat64(f float64) (Float128, Flags) {
+	b := math.Float64bits(f)
+	neg := b>>63 != 0
+	exp := int(b>>52) & 0x7FF
+	frac := b & (1<<52 - 1)
+
+	switch {
+	case exp == 0x7FF && frac == 0:
+		return inf(neg), 0
+	case exp == 0x7FF: // NaN, keep the payload
+		x := Float128{bits: uint128.From64(frac).Lsh(fracBits - 52)}
+		x.bits.Hi |= infHi
+		if neg {
+			x.bits.Hi |= signMask
+		}
+		if x.isSignaling() {
+			x.bits.Hi |= quietBit
+			return x, Invalid
+		}
+		return x, 0
+	case exp == 0: // zero or subnormal
+		exp = 1
+	default:
+		frac |= 1 << 52
+	}
+
+	// value = frac * 2^(exp-1075), exact
+	return round128(neg, exp-1075+126, uint128.From64(frac), ToNearestEven)
+}
+
+// Float64 returns the nearest float64 value of x, ties to even.
+// A NaN payload is truncated, the result is always a quiet NaN.
+func (x Float128) Float64() float64 {
+	f, _ := toFloat64(x, ToNearestEven)
+	return f
+}
+
+// Float64 returns x rounded to float64 value.
+// A signaling NaN raises the invalid exception.
+func (c *Context) Float64(x Float128) float64 {
+	f, flags := toFloat64(x, c.Mode)
+	c.Flags |= flags
+	return f
+}
+
+// toFloat64 converts to float64 value.
+func toFloat64(x Float128, mode RoundingMode) (float64, Flags) {
+	switch {
+	case x.IsNaN():
+		b := x.bits.Rsh(fracBits-52).Lo&(1<<52-1) | 0x7FF<<52 | 1<<51
+		if x.Signbit() {
+			b |= 1 << 63
+		}
+		var flags Flags
+		if x.isSignaling() {
+			flags = Invalid
+		}
+		return math.Float64frombits(b), flags
+	case x.IsInf(0):
+		return math.Inf(x.Sign()), 0
+	case x.IsZero():
+		if x.Signbit() {
+			return math.Copysign(0, -1), 0
+		}
+		return 0, 0
+	}
+
+	neg, exp, sig := x.unpack()
+	b, flags := binary64.roundPack(neg, exp, sig.Lsh(126-fracBits), mode)
+	return math.Float64frombits(b.Lo), flags
+}
+
+// FromUint64 returns the binary128 value of v. The conversion is exact.
+func FromUint64(v uint64) Float128 {
+	x, _ := round128(false, 126, uint128.From64(v), ToNearestEven)
+	return x
+}
+
+// FromInt64 returns the binary128 value of v. The conversion is exact.
+func FromInt64(v int64) Float128 {
+	m := uint64(v)
+	if v < 0 {
+		m = -m
+	}
+	x, _ := round128(v < 0, 126, uint128.From64(m), ToNearestEven)
+	return x
+}
+
+// FromUint128 returns the nearest binary128 value of v, ties to even.
+// Values greater than 2^113 might be rounded.
+func FromUint128(v Uint128) Float128 {
+	x, _ := round128(false, 126, v, ToNearestEven)
+	return x
+}
+
+// FromUint128 returns v rounded to binary128 value.
+func (c *Context) FromUint128(v Uint128) Float128 {
+	return c.raise(round128(false, 126, v, c.Mode))
+}
+
+// FromInt128 returns the nearest binary128 value of v, ties to even.
+// The v is a signed 128-bit integer in two's complement form.
+func FromInt128(v Uint128) Float128 {
+	var c Context
+	return c.FromInt128(v)
+}
+
+// FromInt128 returns v rounded to binary128 value.
+// The v is a signed 128-bit integer in two's complement form.
+func (c *Context) FromInt128(v Uint128) Float128 {
+	neg := v.Hi>>63 != 0
+	if neg {
+		v = uint128.Zero().Sub(v)
+	}
+	return c.raise(round128(neg, 126, v, c.Mode))
+}
+
+// Uint128 returns x truncated to an unsigned integer. The ok is false
+// if x is NaN (the result is zero), x <= -1 (zero) or x >= 2^128 (maximum).
+func (x Float128) Uint128() (Uint128, bool) {
+	c := Context{Mode: ToZero}
+	v := c.Uint128(x)
+	return v, c.Flags&Invalid == 0
+}
+
+// Int128 returns x truncated to a signed integer in two's complement form.
+// The ok is false if x is NaN (the result is zero) or x is out of
+// [-2^127, 2^127) range (the result is saturated).
+func (x Float128) Int128() (Uint128, bool) {
+	c := Context{Mode: ToZero}
+	v := c.Int128(x)
+	return v, c.Flags&Invalid == 0
+}
+
+// Uint128 returns x rounded to an unsigned integer according to the
+// rounding mode. A non-integer x raises the inexact exception. NaN (zero
+// result), negative (zero) and too large (maximum) values raise the invalid
+// exception.
+func (c *Context) Uint128(x Float128) Uint128 {
+	if x.IsNaN() {
+		c.Flags |= Invalid
+		return uint128.Zero()
+	}
+
+	neg, mag, inexact, ok := toInteger(x, c.Mode)
+	switch {
+	case !ok && !neg:
+		c.Flags |= Invalid
+		return uint128.Max()
+	case !ok, neg && !mag.IsZero():
+		c.Flags |= Invalid
+		return uint128.Zero()
+	}
+
+	if inexact {
+		c.Flags |= Inexact
+	}
+	return mag
+}
+
+// Int128 returns x rounded to a signed integer in two's complement form
+// according to the rounding mode. A non-integer x raises the inexact exception.
+// NaN (zero result) and out of range (saturated) values raise the invalid
+// exception.
+func (c *Context) Int128(x Float128) Uint128 {
+	if x.IsNaN() {
+		c.Flags |= Invalid
+		return uint128.Zero()
+	}
+
+	minInt := Uint128{Hi: 1 << 63} // -2^127
+	neg, mag, inexact, ok := toInteger(x, c.Mode)
+	switch {
+	case neg && (!ok || mag.Cmp(minInt) > 0):
+		c.Flags |= Invalid
+		return minInt
+	case !neg && (!ok || mag.Cmp(minInt) >= 0):
+		c.Flags |= Invalid
+		return minInt.Sub64(1) // 2^127-1
+	}
+
+	if inexact {
+		c.Flags |= Inexact
+	}
+	if neg {
+		return uint128.Zero().Sub(mag)
+	}
+	return mag
+}
+
+// toInteger rounds non-NaN x to an integer and returns its sign and
+// magnitude. The ok is false if the magnitude does not fit into 128 bits.
+func toInteger(x Float128, mode RoundingMode) (neg bool, mag Uint128, inexact, ok bool) {
+	switch {
+	case x.IsInf(0):
+		return x.Signbit(), uint128.Zero(), false, false
+	case x.IsZero():
+		return x.Signbit(), uint128.Zero(), false, true
+	}
+
+	neg, exp, sig := x.unpack()
+	if exp >= 128 {
+		return neg, uint128.Zero(), false, false
+	}
+	if exp >= fracBits {
+		return neg, sig.Lsh(uint(exp - fracBits)), false, true
+	}
+
+	n := fracBits - exp // bits to round off
+	if n > fracBits+2 {
+		sig, n = uint128.One(), 2 // less than 1/4, just sticky
+	}
+	mag, inexact = roundSig(sig, uint(n), neg, mode)
+	return neg, mag, inexact, true
+}
+
+// BigFloat returns the exact value of x as *big.Float with 113-bit precision.
+// The result is nil if x is NaN.
+func (x Float128) BigFloat() *big.Float {
+	f := new(big.Float).SetPrec(fracBits + 1)
+	switch {
+	case x.IsNaN():
+		return nil
+	case x.IsInf(0):
+		return f.SetInf(x.Signbit())
+	case x.IsZero():
+		if x.Signbit() {
+			return f.Neg(f)
+		}
+		return f
+	}
+
+	neg, exp, sig := x.unpack()
+	f.SetInt(sig.Big())
+	f.SetMantExp(f, exp-fracBits)
+	if neg {
+		f.Neg(f)
+	}
+	return f
+}
+
+// FromBigFloat returns the nearest binary128 value of f, ties to even.
+func FromBigFloat(f *big.Float) Float128 {
+	var c Context
+	return c.FromBigFloat(f)
+}
+
+// FromBigFloat returns f rounded to binary128 value.
+func (c *Context) FromBigFloat(f *big.Float) Float128 {
+	neg := f.Signbit()
+	switch {
+	case f.IsInf():
+		return inf(neg)
+	case f.Sign() == 0:
+		return zero(neg)
+	}
+
+	// f = n * 2^(exp-prec) with integer n
+	exp := f.MantExp(nil)
+	prec := int(f.MinPrec())
+	n, _ := new(big.Float).SetMantExp(f, prec-exp).Int(nil)
+	return c.raise(roundBig(neg, n.Abs(n), exp-prec, c.Mode))
+}
+
+// roundBig rounds the value n * 2^exp for nonnegative n.
+func roundBig(neg bool, n *big.Int, exp int, mode RoundingMode) (Float128, Flags) {
+	if l := n.BitLen(); l > 127 {
+		s := uint(l - 127)
+		sticky := n.TrailingZeroBits() < s
+		n = new(big.Int).Rsh(n, s)
+		exp += int(s)
+		sig := uint128.FromBig(n)
+		if sticky {
+			sig.Lo |= 1
+		}
+		return round128(neg, exp+126, sig, mode)
+	}
+	return round128(neg, exp+126, uint128.FromBig(n), mode)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// decimal strings ///////////////////////////////////////////////////////////
+
+// Parse converts the string s to the nearest binary128 value, ties to even.
+//
+// The s is a decimal number "[+-]digits[.digits][(e|E)[+-]digits]",
+// or "Inf", "Infinity", "NaN" in any letter case, with optional sign.
+// Errors are of *strconv.NumError type. Like strconv.ParseFloat, if s is
+// syntactically well-formed but is out of range, the result is infinity
+// of the corresponding sign and the error is strconv.ErrRange.
+func Parse(s string) (Float128, error) {
+	var c Context
+	x, err := c.Parse(s)
+	if err == nil && c.Flags&Overflow != 0 {
+		err = &strconv.NumError{Func: "Parse", Num: s, Err: strconv.ErrRange}
+	}
+	return x, err
+}
+
+// Parse converts the string s to binary128 value rounded according
+// to the rounding mode. See package level Parse for the syntax.
+// Only the syntax errors are reported, overflow raises the exception.
+func (c *Context) Parse(s string) (Float128, error) {
+	x, flags, ok := parse(s, c.Mode)
+	if !ok {
+		return Float128{}, &strconv.NumError{Func: "Parse", Num: s, Err: strconv.ErrSyntax}
+	}
+	return c.raise(x, flags), nil
+}
+
+// parse parses decimal string.
+func parse(s string, mode RoundingMode) (Float128, Flags, bool) {
+	t, neg := s, false
+	if len(t) != 0 && (t[0] == '+' || t[0] == '-') {
+		t, neg = t[1:], t[0] == '-'
+	}
+
+	switch lower(t) {
+	case "inf", "infinity":
+		return inf(neg), 0, true
+	case "nan":
+		return NaN(), 0, true
+	}
+
+	// mantissa
+	var digits []byte
+	dexp, seenDigit, seenDot := 0, false, false
+	i := 0
+loop:
+	for ; i < len(t); i++ {
+		switch ch := t[i]; {
+		case '0' <= ch && ch <= '9':
+			seenDigit = true
+			if ch == '0' && len(digits) == 0 { // skip leading zeros
+				if seenDot {
+					dexp--
+				}
+				continue
+			}
+			digits = append(digits, ch)
+			if seenDot {
+				dexp--
+			}
+			continue
+		case ch == '.' && !seenDot:
+			seenDot = true
+			continue
+		}
+		break loop
+	}
+	if !seenDigit {
+		return Float128{}, 0, false
+	}
+
+	// exponent
+	if i < len(t) && (t[i] == 'e' || t[i] == 'E') {
+		i++
+		eneg := false
+		if i < len(t) && (t[i] == '+' || t[i] == '-') {
+			eneg = t[i] == '-'
+			i++
+		}
+		if i == len(t) {
+			return Float128{}, 0, false
+		}
+		e := 0
+		for ; i < len(t) && '0' <= t[i] && t[i] <= '9'; i++ {
+			if e < 1e8 { // saturate, far beyond the range
+				e = e*10 + int(t[i]-'0')
+			}
+		}
+		if eneg {
+			e = -e
+		}
+		dexp += e
+	}
+	if i != len(t) {
+		return Float128{}, 0, false
+	}
+
+	if len(digits) == 0 {
+		return zero(neg), 0, true
+	}
+
+	// value = digits * 10^dexp, check the range roughly
+	// binary128 is in range [6.4e-4966, 1.2e4932]
+	switch mag := len(digits) + dexp; {
+	case mag > 4934:
+		x, flags := round128(neg, 1<<20, uint128.One(), mode) // overflow
+		return x, flags, true
+	case mag < -4967:
+		x, flags := round128(neg, -1<<20, uint128.One(), mode) // underflow
+		return x, flags, true
+	}
+
+	n, _ := new(big.Int).SetString(string(digits), 10)
+	if dexp >= 0 {
+		n.Mul(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dexp)), nil))
+		x, flags := roundBig(neg, n, 0, mode)
+		return x, flags, true
+	}
+
+	// the quotient (n << k) / 10^-dexp has at least 128 bits,
+	// the extra lowest bit is sticky
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-dexp)), nil)
+	k := p.BitLen() - n.BitLen() + 129
+	if k < 0 {
+		k = 0
+	}
+	q, r := n.QuoRem(n.Lsh(n, uint(k)), p, new(big.Int))
+	q.Lsh(q, 1)
+	if r.Sign() != 0 {
+		q.SetBit(q, 0, 1)
+	}
+	x, flags := roundBig(neg, q, -k-1, mode)
+	return x, flags, true
+}
+
+// lower returns ASCII lower case of short strings only.
+func lower(s string) string {
+	if len(s) > len("infinity") {
+		return s
+	}
+	var buf [8]byte
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if 'A' <= ch && ch <= 'Z' {
+			ch += 'a' - 'A'
+		}
+		buf[i] = ch
+	}
+	return string(buf[:len(s)])
+}
+
+// String returns the shortest decimal representation of x that parses
+// back to the same value, like strconv.FormatFloat(f, 'g', -1, 64).
+func (x Float128) String() string {
+	return x.Text('g', -1)
+}
+
+// Text converts x to a string according to the format and precision,
+// see big.Float.Text for details. A negative precision selects the
+// smallest number of digits necessary to represent x uniquely.
+// NaN is "NaN", infinities are "+Inf" and "-Inf".
+func (x Float128) Text(format byte, prec int) string {
+	if x.IsNaN() {
+		return "NaN"
+	}
+
+	f := x.BigFloat()
+	if prec < 0 {
+		if v, p, ok := x.shortest(f, rune(format)); ok {
+			return f.Text(byte(v), p)
+		}
+	}
+	return f.Text(format, prec)
+}
+
+// Format implements fmt.Formatter, see big.Float.Format for the verbs.
+func (x Float128) Format(s fmt.State, verb rune) {
+	if x.IsNaN() {
+		if w, ok := s.Width(); ok && w > 3 {
+			fmt.Fprintf(s, "%*s", w, "NaN")
+			return
+		}
+		fmt.Fprint(s, "NaN")
+		return
+	}
+
+	f := x.BigFloat() // via big.Float, unefficient!
+	if _, ok := s.Precision(); !ok {
+		if v, p, ok := x.shortest(f, verb); ok {
+			f.Format(precisionState{State: s, prec: p}, v)
+			return
+		}
+	}
+	f.Format(s, verb)
+}
+
+// precisionState overrides the precision of fmt.State.
+type precisionState struct {
+	fmt.State
+	prec int
+}
+
+// Precision returns the overridden precision.
+func (s precisionState) Precision() (int, bool) {
+	return s.prec, true
+}
+
+// shortest returns the explicit verb and precision of the shortest decimal
+// representation of x, if big.Float cannot find it. big.Float assumes the
+// rounding interval symmetric, but the lower neighbour of a power of two is
+// twice closer, so the digits found might be not enough to parse x back.
+func (x Float128) shortest(f *big.Float, verb rune) (rune, int, bool) {
+	if x.bits.Lo != 0 || x.bits.Hi&fracMask != 0 ||
+		x.bits.Hi&infHi <= 1<<expShift || !x.IsFinite() {
+		return verb, -1, false // not a power of two, or symmetric
+	}
+	switch verb {
+	case 'e', 'E', 'f', 'F', 'g', 'G', 'v':
+	default:
+		return verb, -1, false
+	}
+
+	// big.Float digits are enough for the upper neighbour,
+	// 36 significant digits are always enough
+	n, e10 := 0, 0
+	for _, ch := range f.Text('e', -1) {
+		if ch == 'e' {
+			break
+		}
+		if '0' <= ch && ch <= '9' {
+			n++
+		}
+	}
+	for ; n < 36; n++ {
+		s := f.Text('e', n-1)
+		if y, _, _ := parse(s, ToNearestEven); y.bits.Equals(x.bits) {
+			e10, _ = strconv.Atoi(s[strings.LastIndexByte(s, 'e')+1:])
+			break
+		}
+	}
+
+	switch verb {
+	case 'e', 'E':
+		return verb, n - 1, true
+	case 'g', 'v':
+		verb = 'e'
+	case 'G':
+		verb = 'E'
+	}
+	if (verb == 'e' || verb == 'E') && (e10 < -4 || e10 >= 6) { // like %g
+		return verb, n - 1, true
+	}
+	if verb != 'F' {
+		verb = 'f'
+	}
+	if p := n - 1 - e10; p > 0 {
+		return verb, p, true
+	}
+	return verb, 0, true
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (x Float128) MarshalText() (text []byte, err error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Out of range values are reported as error.
+func (x *Float128) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*x = v
+	return nil
+}
@@ -0,0 +1,50 @@
+package float128_test
+
+import (
+	"fmt"
+
+	"github.com/Pilatuz/bigz/float128"
+)
+
+// ExampleFloat128 is an example for Float128 arithmetic.
+func ExampleFloat128() {
+	one, three := float128.FromInt64(1), float128.FromInt64(3)
+	fmt.Println(one.Div(three))
+	fmt.Println(float128.FromInt64(2).Sqrt())
+	fmt.Println(float128.FromFloat64(0.1))
+	fmt.Printf("%#x\n", one.Bits())
+	// Output:
+	// 0.3333333333333333333333333333333333
+	// 1.414213562373095048801688724209698
+	// 0.1000000000000000055511151231257827
+	// 0x3fff0000000000000000000000000000
+}
+
+// ExampleContext is an example for rounding modes and exception flags.
+func ExampleContext() {
+	c := float128.Context{Mode: float128.ToPositiveInf}
+	x := c.Div(float128.FromInt64(1), float128.FromInt64(3))
+	fmt.Printf("%.40f %s\n", x, c.Flags)
+
+	c = float128.Context{}
+	c.Div(float128.FromInt64(1), float128.FromInt64(0))
+	c.Sqrt(float128.FromInt64(-1))
+	fmt.Println(c.Flags)
+	// Output:
+	// 0.3333333333333333333333333333333333654322 Inexact
+	// DivByZero|Invalid
+}
+
+// ExampleParse is an example for Parse.
+func ExampleParse() {
+	x, err := float128.Parse("1.189731495357231765085759326628007e4932")
+	fmt.Println(x, err)
+	x, err = float128.Parse("1e5000")
+	fmt.Println(x, err)
+	_, err = float128.Parse("0x10")
+	fmt.Println(err)
+	// Output:
+	// 1.189731495357231765085759326628007e+4932 <nil>
+	// +Inf strconv.Parse: parsing "1e5000": value out of range
+	// strconv.Parse: parsing "0x10": invalid syntax
+}
@@ -0,0 +1,407 @@
+// Package float128 implements IEEE 754 binary128 (quadruple precision)
+// floating-point arithmetic in software on top of uint128.Uint128.
+//
+// A Float128 value consists of 1 sign bit, 15 exponent bits and 112
+// fraction bits. All operations are correctly rounded and handle signed
+// zeros, subnormals, infinities and NaNs as IEEE 754 requires.
+// Tininess is detected after rounding.
+//
+// The Float128 methods round to nearest, ties to even, and discard the
+// exception flags. Use Context to select another rounding mode and to
+// accumulate the exception flags.
+package float128
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// Uint128 is an alias for uint128.Uint128 type, the bits container.
+type Uint128 = uint128.Uint128
+
+// Float128 is an IEEE 754 binary128 floating-point value.
+// The zero value is positive zero.
+type Float128 struct {
+	bits Uint128
+}
+
+// binary128 layout, as seen from the upper 64-bit half.
+const (
+	fracBits = 112                 // explicit fraction bits
+	expBits  = 15                  // exponent bits
+	expBias  = 1<<(expBits-1) - 1  // 16383
+	expMax   = 1<<expBits - 1      // infinities and NaNs
+	expShift = fracBits - 64       // exponent position in Hi
+	signMask = 1 << 63             // sign bit in Hi
+	fracMask = 1<<expShift - 1     // fraction bits in Hi
+	quietBit = 1 << (expShift - 1) // quiet NaN bit in Hi
+	infHi    = expMax << expShift  // infinity exponent in Hi
+	nanHi    = infHi | quietBit    // default quiet NaN in Hi
+)
+
+// FromBits returns the floating-point value with the given IEEE 754
+// binary128 representation.
+func FromBits(b Uint128) Float128 {
+	return Float128{bits: b}
+}
+
+// Bits returns the IEEE 754 binary128 representation of x.
+func (x Float128) Bits() Uint128 {
+	return x.bits
+}
+
+// Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func Inf(sign int) Float128 {
+	return inf(sign < 0)
+}
+
+// NaN returns the default quiet NaN value.
+func NaN() Float128 {
+	return Float128{bits: Uint128{Hi: nanHi}}
+}
+
+// inf returns infinity of the given sign.
+func inf(neg bool) Float128 {
+	x := Float128{bits: Uint128{Hi: infHi}}
+	if neg {
+		x.bits.Hi |= signMask
+	}
+	return x
+}
+
+// zero returns zero of the given sign.
+func zero(neg bool) Float128 {
+	var x Float128
+	if neg {
+		x.bits.Hi |= signMask
+	}
+	return x
+}
+
+// Signbit reports whether x is negative or negative zero.
+func (x Float128) Signbit() bool {
+	return x.bits.Hi&signMask != 0
+}
+
+// Sign returns -1, 0 or +1 depending on whether x is negative,
+// zero (of either sign) or positive. Sign of NaN is 0.
+func (x Float128) Sign() int {
+	switch {
+	case x.IsNaN(), x.IsZero():
+		return 0
+	case x.Signbit():
+		return -1
+	}
+	return +1
+}
+
+// IsZero reports whether x is zero of either sign.
+func (x Float128) IsZero() bool {
+	return x.bits.Lo == 0 && x.bits.Hi&^signMask == 0
+}
+
+// IsNaN reports whether x is a NaN value.
+func (x Float128) IsNaN() bool {
+	hi := x.bits.Hi &^ signMask
+	return hi > infHi || (hi == infHi && x.bits.Lo != 0)
+}
+
+// IsInf reports whether x is an infinity, according to sign.
+// If sign > 0, IsInf reports whether x is positive infinity.
+// If sign < 0, IsInf reports whether x is negative infinity.
+// If sign == 0, IsInf reports whether x is either infinity.
+func (x Float128) IsInf(sign int) bool {
+	if x.bits.Lo != 0 || x.bits.Hi&^signMask != infHi {
+		return false
+	}
+	return sign == 0 || (sign < 0) == x.Signbit()
+}
+
+// IsFinite reports whether x is neither infinity nor NaN.
+func (x Float128) IsFinite() bool {
+	return x.bits.Hi&infHi != infHi
+}
+
+// IsSubnormal reports whether x is a nonzero subnormal value.
+func (x Float128) IsSubnormal() bool {
+	return x.bits.Hi&infHi == 0 && !x.IsZero()
+}
+
+// isSignaling reports whether x is a signaling NaN.
+func (x Float128) isSignaling() bool {
+	return x.IsNaN() && x.bits.Hi&quietBit == 0
+}
+
+// Neg returns x with its sign flipped. It never raises exceptions.
+func (x Float128) Neg() Float128 {
+	x.bits.Hi ^= signMask
+	return x
+}
+
+// Abs returns x with its sign cleared. It never raises exceptions.
+func (x Float128) Abs() Float128 {
+	x.bits.Hi &^= signMask
+	return x
+}
+
+// unpack returns the sign, the unbiased exponent and the significand
+// of finite nonzero x. The significand is normalized to have its leading
+// one at bit 112, so x = sig * 2^(exp-112).
+func (x Float128) unpack() (neg bool, exp int, sig Uint128) {
+	neg = x.Signbit()
+	e := int(x.bits.Hi>>expShift) & expMax
+	sig = Uint128{Lo: x.bits.Lo, Hi: x.bits.Hi & fracMask}
+	if e == 0 { // subnormal
+		n := sig.LeadingZeros() - (127 - fracBits)
+		return neg, 1 - expBias - n, sig.Lsh(uint(n))
+	}
+
+	sig.Hi |= 1 << expShift // implicit bit
+	return neg, e - expBias, sig
+}
+
+// propagateNaN returns the first NaN operand quieted.
+// Signaling NaN operands raise the invalid exception.
+func propagateNaN(xs ...Float128) (Float128, Flags) {
+	var flags Flags
+	res := NaN()
+	found := false
+	for _, x := range xs {
+		if x.isSignaling() {
+			flags |= Invalid
+		}
+		if !found && x.IsNaN() {
+			res, found = x, true
+			res.bits.Hi |= quietBit
+		}
+	}
+	return res, flags
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// rounding //////////////////////////////////////////////////////////////////
+
+// RoundingMode determines how a result is rounded to fit the destination
+// format. The modes are the IEEE 754 rounding-direction attributes.
+type RoundingMode byte
+
+// The rounding modes.
+const (
+	ToNearestEven RoundingMode = iota // roundTiesToEven, the default
+	ToNearestAway                     // roundTiesToAway
+	ToZero                            // roundTowardZero
+	ToPositiveInf                     // roundTowardPositive
+	ToNegativeInf                     // roundTowardNegative
+)
+
+// String returns the name of rounding mode.
+func (m RoundingMode) String() string {
+	switch m {
+	case ToNearestEven:
+		return "ToNearestEven"
+	case ToNearestAway:
+		return "ToNearestAway"
+	case ToZero:
+		return "ToZero"
+	case ToPositiveInf:
+		return "ToPositiveInf"
+	case ToNegativeInf:
+		return "ToNegativeInf"
+	}
+	return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// Flags is a set of IEEE 754 exception flags.
+// The bit values are the same as used by Berkeley TestFloat.
+type Flags uint8
+
+// The exception flags.
+const (
+	Inexact   Flags = 1 << iota // the result is rounded
+	Underflow                   // the result is tiny and inexact
+	Overflow                    // the result exceeds the largest finite value
+	DivByZero                   // an infinite result from finite operands
+	Invalid                     // the operation has no useful result
+)
+
+// String returns the names of the flags set, separated by '|'.
+func (f Flags) String() string {
+	if f == 0 {
+		return "0"
+	}
+
+	var names []string
+	for i, name := range [...]string{"Inexact", "Underflow", "Overflow", "DivByZero", "Invalid"} {
+		if f&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if rest := f &^ (1<<5 - 1); rest != 0 {
+		names = append(names, "0x"+strconv.FormatUint(uint64(rest), 16))
+	}
+	return strings.Join(names, "|")
+}
+
+// format describes binary interchange format used as the rounding destination.
+type format struct {
+	fracBits uint // explicit fraction bits
+	expBits  uint // exponent bits
+}
+
+// The supported destination formats.
+var (
+	binary128 = format{fracBits: 112, expBits: 15}
+	binary64  = format{fracBits: 52, expBits: 11}
+)
+
+// shiftRightJam returns x>>n with all the shifted out bits ORed
+// into the least significant bit of the result (sticky bit).
+func shiftRightJam(x Uint128, n uint) Uint128 {
+	if n >= 128 {
+		if x.IsZero() {
+			return x
+		}
+		return uint128.One()
+	}
+	if n != 0 && x.TrailingZeros() < int(n) {
+		x = x.Rsh(n)
+		x.Lo |= 1
+		return x
+	}
+	return x.Rsh(n)
+}
+
+// roundSig drops the lower n bits (0 < n < 128) of the significand, rounding
+// the rest according to the mode. It also reports whether the result is inexact.
+func roundSig(sig Uint128, n uint, neg bool, mode RoundingMode) (Uint128, bool) {
+	m, rem := sig.Rsh(n), sig.And(uint128.Mask(n))
+	if rem.IsZero() {
+		return m, false
+	}
+
+	var up bool
+	switch mode {
+	case ToNearestEven:
+		c := rem.Cmp(uint128.One().Lsh(n - 1))
+		up = c > 0 || (c == 0 && m.Lo&1 != 0)
+	case ToNearestAway:
+		up = rem.Cmp(uint128.One().Lsh(n-1)) >= 0
+	case ToPositiveInf:
+		up = !neg
+	case ToNegativeInf:
+		up = neg
+	}
+	if up {
+		m = m.Add64(1)
+	}
+	return m, true
+}
+
+// roundPack rounds the value sig * 2^(exp-126) to the format and returns
+// its representation with the exception flags raised.
+// The sig may be not normalized, it has to be nonzero.
+func (f format) roundPack(neg bool, exp int, sig Uint128, mode RoundingMode) (Uint128, Flags) {
+	// normalize to have the leading one at bit 126
+	if n := sig.LeadingZeros() - 1; n > 0 {
+		sig = sig.Lsh(uint(n))
+		exp -= n
+	} else if n < 0 {
+		sig = shiftRightJam(sig, 1)
+		exp++
+	}
+
+	bias := 1<<(f.expBits-1) - 1
+	emax := 1<<f.expBits - 1
+	guard := 126 - f.fracBits // bits to round off
+	e := exp + bias           // biased exponent
+
+	if e >= emax {
+		return f.overflow(neg, mode)
+	}
+
+	var flags Flags
+	if e <= 0 { // subnormal or zero
+		tiny := e < 0
+		if !tiny { // tininess is detected after rounding
+			m, _ := roundSig(sig, guard, neg, mode)
+			tiny = m.BitLen() <= int(f.fracBits)+1 // no carry to 2^emin
+		}
+		sig = shiftRightJam(sig, uint(1-e))
+		e = 1
+		if tiny && !sig.And(uint128.Mask(guard)).IsZero() {
+			flags |= Underflow
+		}
+	}
+
+	m, inexact := roundSig(sig, guard, neg, mode)
+	if inexact {
+		flags |= Inexact
+	}
+	if m.BitLen() > int(f.fracBits)+1 { // carry to the next binade
+		m = m.Rsh(1)
+		e++
+		if e >= emax {
+			return f.overflow(neg, mode)
+		}
+	}
+
+	// the implicit bit (if any) increments the exponent field
+	res := uint128.From64(uint64(e - 1)).Lsh(f.fracBits).Add(m)
+	if neg {
+		res = res.Or(f.signBit())
+	}
+	return res, flags
+}
+
+// overflow returns the overflowed result: an infinity or the largest
+// finite value, depending on the rounding mode.
+func (f format) overflow(neg bool, mode RoundingMode) (Uint128, Flags) {
+	emax := uint64(1<<f.expBits - 1)
+	res := uint128.From64(emax).Lsh(f.fracBits) // infinity
+	switch {
+	case mode == ToZero,
+		mode == ToPositiveInf && neg,
+		mode == ToNegativeInf && !neg:
+		res = res.Sub64(1) // largest finite
+	}
+	if neg {
+		res = res.Or(f.signBit())
+	}
+	return res, Overflow | Inexact
+}
+
+// signBit returns the sign bit of the format.
+func (f format) signBit() Uint128 {
+	return uint128.One().Lsh(f.fracBits + f.expBits)
+}
+
+// round128 rounds the value sig * 2^(exp-126) to the binary128 format.
+// The sig may be not normalized, zero sig gives zero of the given sign.
+func round128(neg bool, exp int, sig Uint128, mode RoundingMode) (Float128, Flags) {
+	if sig.IsZero() {
+		return zero(neg), 0
+	}
+	b, flags := binary128.roundPack(neg, exp, sig, mode)
+	return Float128{bits: b}, flags
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// context ///////////////////////////////////////////////////////////////////
+
+// Context provides the rounding mode and accumulates the exception flags
+// for the operations performed through it. The zero value rounds to
+// nearest, ties to even, and has no flags raised.
+//
+// A Context must not be used concurrently.
+type Context struct {
+	Mode  RoundingMode // rounding mode
+	Flags Flags        // sticky exception flags
+}
+
+// raise accumulates flags and returns the value.
+func (c *Context) raise(x Float128, flags Flags) Float128 {
+	c.Flags |= flags
+	return x
+}
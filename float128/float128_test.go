@@ -0,0 +1,994 @@
+package float128
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// vectors is the directory of Berkeley TestFloat vectors, see testdata/testfloat/gen.sh.
+// The checked in vectors are a sample, the complete suite might be generated
+// and verified with:
+//
+//	go test ./float128 -run TestVectors -vectors /path/to/vectors
+var vectors = flag.String("vectors", filepath.Join("testdata", "testfloat"), "directory of the test vectors")
+
+// modeNames are the Berkeley TestFloat rounding mode names.
+var modeNames = map[RoundingMode]string{
+	ToNearestEven: "rnear_even",
+	ToNearestAway: "rnear_maxMag",
+	ToZero:        "rminMag",
+	ToNegativeInf: "rmin",
+	ToPositiveInf: "rmax",
+}
+
+// allModes lists all the rounding modes.
+var allModes = []RoundingMode{ToNearestEven, ToNearestAway, ToZero, ToNegativeInf, ToPositiveInf}
+
+// kind is the operand or result type of the test vectors.
+type kind int
+
+const (
+	kindF128  kind = iota // binary128 bits, 32 hex digits
+	kindF64               // binary64 bits, 16 hex digits
+	kindInt               // 128-bit integer, 32 hex digits
+	kindInt64             // 64-bit integer, 16 hex digits
+	kindBool              // 0 or 1
+)
+
+// format formats the value as hex digits.
+func (k kind) format(u Uint128) string {
+	switch k {
+	case kindF64, kindInt64:
+		return fmt.Sprintf("%016x", u.Lo)
+	case kindBool:
+		return strconv.FormatUint(u.Lo, 10)
+	}
+	return fmt.Sprintf("%016x%016x", u.Hi, u.Lo)
+}
+
+// parse parses the value formatted by format.
+func (k kind) parse(s string) (Uint128, error) {
+	var u Uint128
+	var err error
+	switch k {
+	case kindF64, kindInt64, kindBool:
+		u.Lo, err = strconv.ParseUint(s, 16, 64)
+	default:
+		if len(s) != 32 {
+			return u, fmt.Errorf("bad length of %q", s)
+		}
+		if u.Hi, err = strconv.ParseUint(s[:16], 16, 64); err == nil {
+			u.Lo, err = strconv.ParseUint(s[16:], 16, 64)
+		}
+	}
+	return u, err
+}
+
+// same reports whether the results are the same, any NaN matches any NaN.
+func (k kind) same(x, y Uint128) bool {
+	switch k {
+	case kindF128:
+		if FromBits(x).IsNaN() && FromBits(y).IsNaN() {
+			return true
+		}
+	case kindF64:
+		if math.IsNaN(math.Float64frombits(x.Lo)) && math.IsNaN(math.Float64frombits(y.Lo)) {
+			return true
+		}
+	}
+	return x.Equals(y)
+}
+
+// vectorOp is the tested operation, named as Berkeley TestFloat function.
+type vectorOp struct {
+	name      string                                                // also vectors file name
+	args      []kind                                                // operand kinds
+	res       kind                                                  // result kind
+	rounded   bool                                                  // depends on the rounding mode
+	testFloat bool                                                  // has TestFloat vectors
+	gen       func(r *rand.Rand) []Uint128                          // operands, optional
+	impl      func(c *Context, a []Uint128) Uint128                 // implementation
+	ref       func(mode RoundingMode, a []Uint128) (Uint128, Flags) // reference
+}
+
+// f128s converts operands.
+func f128s(a []Uint128) (x, y, z Float128) {
+	x = FromBits(a[0])
+	if len(a) > 1 {
+		y = FromBits(a[1])
+	}
+	if len(a) > 2 {
+		z = FromBits(a[2])
+	}
+	return
+}
+
+// bits returns reference result bits.
+func bits(x Float128, flags Flags) (Uint128, Flags) {
+	return x.Bits(), flags
+}
+
+// bool01 converts bool to 0 or 1.
+func bool01(b bool) Uint128 {
+	if b {
+		return uint128.One()
+	}
+	return uint128.Zero()
+}
+
+// vectorOps lists all tested operations.
+var vectorOps = []vectorOp{
+	{
+		name: "f128_add", args: []kind{kindF128, kindF128}, res: kindF128, rounded: true, testFloat: true, gen: genAddends,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, _ := f128s(a); return c.Add(x, y).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { x, y, _ := f128s(a); return bits(refAdd(x, y, m)) },
+	},
+	{
+		name: "f128_sub", args: []kind{kindF128, kindF128}, res: kindF128, rounded: true, testFloat: true, gen: genAddends,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, _ := f128s(a); return c.Sub(x, y).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { x, y, _ := f128s(a); return bits(refSub(x, y, m)) },
+	},
+	{
+		name: "f128_mul", args: []kind{kindF128, kindF128}, res: kindF128, rounded: true, testFloat: true, gen: genFactors,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, _ := f128s(a); return c.Mul(x, y).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { x, y, _ := f128s(a); return bits(refMul(x, y, m)) },
+	},
+	{
+		name: "f128_div", args: []kind{kindF128, kindF128}, res: kindF128, rounded: true, testFloat: true, gen: genFactors,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, _ := f128s(a); return c.Div(x, y).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { x, y, _ := f128s(a); return bits(refDiv(x, y, m)) },
+	},
+	{
+		name: "f128_sqrt", args: []kind{kindF128}, res: kindF128, rounded: true, testFloat: true,
+		impl: func(c *Context, a []Uint128) Uint128 { x, _, _ := f128s(a); return c.Sqrt(x).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { x, _, _ := f128s(a); return bits(refSqrt(x, m)) },
+	},
+	{
+		name: "f128_mulAdd", args: []kind{kindF128, kindF128, kindF128}, res: kindF128, rounded: true, testFloat: true, gen: genMulAdd,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, z := f128s(a); return c.FMA(x, y, z).Bits() },
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			x, y, z := f128s(a)
+			return bits(refFMA(x, y, z, m))
+		},
+	},
+	{
+		name: "f128_eq", args: []kind{kindF128, kindF128}, res: kindBool, testFloat: true, gen: genCompared,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, _ := f128s(a); return bool01(c.Equal(x, y)) },
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			x, y, _ := f128s(a)
+			cmp, flags := refCompare(x, y)
+			return bool01(cmp == 0), flags
+		},
+	},
+	{
+		name: "f128_lt", args: []kind{kindF128, kindF128}, res: kindBool, testFloat: true, gen: genCompared,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, _ := f128s(a); return bool01(c.Less(x, y)) },
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			x, y, _ := f128s(a)
+			if cmp, _ := refCompare(x, y); cmp != 2 {
+				return bool01(cmp < 0), 0
+			}
+			return bool01(false), Invalid
+		},
+	},
+	{
+		name: "f128_le", args: []kind{kindF128, kindF128}, res: kindBool, testFloat: true, gen: genCompared,
+		impl: func(c *Context, a []Uint128) Uint128 { x, y, _ := f128s(a); return bool01(c.LessEqual(x, y)) },
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			x, y, _ := f128s(a)
+			if cmp, _ := refCompare(x, y); cmp != 2 {
+				return bool01(cmp <= 0), 0
+			}
+			return bool01(false), Invalid
+		},
+	},
+	{
+		name: "f128_to_f64", args: []kind{kindF128}, res: kindF64, rounded: true, testFloat: true, gen: genNearFloat64,
+		impl: func(c *Context, a []Uint128) Uint128 {
+			x, _, _ := f128s(a)
+			return uint128.From64(math.Float64bits(c.Float64(x)))
+		},
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			x, _, _ := f128s(a)
+			b, flags := refToFloat64(x, m)
+			return uint128.From64(b), flags
+		},
+	},
+	{
+		name: "f64_to_f128", args: []kind{kindF64}, res: kindF128, testFloat: true,
+		impl: func(c *Context, a []Uint128) Uint128 { return c.FromFloat64(math.Float64frombits(a[0].Lo)).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { return refFromFloat64(a[0].Lo) },
+	},
+	{
+		name: "ui64_to_f128", args: []kind{kindInt64}, res: kindF128, testFloat: true,
+		impl: func(c *Context, a []Uint128) Uint128 { return c.FromUint128(a[0]).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { return bits(refFromInt(a[0].Big(), m)) },
+	},
+	{
+		name: "i64_to_f128", args: []kind{kindInt64}, res: kindF128, testFloat: true,
+		impl: func(c *Context, a []Uint128) Uint128 { return c.FromInt128(signExtend64(a[0])).Bits() },
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			return bits(refFromInt(big.NewInt(int64(a[0].Lo)), m))
+		},
+	},
+	{
+		name: "f128_to_ui64", args: []kind{kindF128}, res: kindInt64, rounded: true, testFloat: true, gen: genNearInteger,
+		impl: func(c *Context, a []Uint128) Uint128 {
+			x, _, _ := f128s(a)
+			v := c.Uint128(x)
+			v, c.Flags = narrowUint64(v, c.Flags)
+			return v
+		},
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			x, _, _ := f128s(a)
+			return narrowUint64(refToUint128(x, m))
+		},
+	},
+	{
+		name: "f128_to_i64", args: []kind{kindF128}, res: kindInt64, rounded: true, testFloat: true, gen: genNearInteger,
+		impl: func(c *Context, a []Uint128) Uint128 {
+			x, _, _ := f128s(a)
+			v := c.Int128(x)
+			v, c.Flags = narrowInt64(v, c.Flags)
+			return v
+		},
+		ref: func(m RoundingMode, a []Uint128) (Uint128, Flags) {
+			x, _, _ := f128s(a)
+			return narrowInt64(refToInt128(x, m))
+		},
+	},
+	{
+		name: "ui128_to_f128", args: []kind{kindInt}, res: kindF128, rounded: true,
+		impl: func(c *Context, a []Uint128) Uint128 { return c.FromUint128(a[0]).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { return bits(refFromInt(a[0].Big(), m)) },
+	},
+	{
+		name: "i128_to_f128", args: []kind{kindInt}, res: kindF128, rounded: true,
+		impl: func(c *Context, a []Uint128) Uint128 { return c.FromInt128(a[0]).Bits() },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { return bits(refFromInt(refSigned(a[0]), m)) },
+	},
+	{
+		name: "f128_to_ui128", args: []kind{kindF128}, res: kindInt, rounded: true, gen: genNearInteger,
+		impl: func(c *Context, a []Uint128) Uint128 { x, _, _ := f128s(a); return c.Uint128(x) },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { x, _, _ := f128s(a); return refToUint128(x, m) },
+	},
+	{
+		name: "f128_to_i128", args: []kind{kindF128}, res: kindInt, rounded: true, gen: genNearInteger,
+		impl: func(c *Context, a []Uint128) Uint128 { x, _, _ := f128s(a); return c.Int128(x) },
+		ref:  func(m RoundingMode, a []Uint128) (Uint128, Flags) { x, _, _ := f128s(a); return refToInt128(x, m) },
+	},
+}
+
+// signExtend64 returns 64-bit two's complement value as 128-bit one.
+func signExtend64(u Uint128) Uint128 {
+	return Uint128{Lo: u.Lo, Hi: uint64(int64(u.Lo) >> 63)}
+}
+
+// narrowUint64 narrows the 128-bit conversion result to uint64 as
+// TestFloat does: the out of range values raise the invalid exception only.
+func narrowUint64(v Uint128, flags Flags) (Uint128, Flags) {
+	if flags&Invalid == 0 && v.Hi != 0 {
+		flags = Invalid
+	}
+	return uint128.From64(v.Lo), flags
+}
+
+// narrowInt64 narrows the 128-bit conversion result to int64 as
+// TestFloat does: the out of range values raise the invalid exception only.
+func narrowInt64(v Uint128, flags Flags) (Uint128, Flags) {
+	if flags&Invalid == 0 && v != signExtend64(v) {
+		flags = Invalid
+	}
+	return uint128.From64(v.Lo), flags
+}
+
+// refSigned returns two's complement value as signed integer.
+func refSigned(u Uint128) *big.Int {
+	n := u.Big()
+	if n.Bit(127) == 1 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	return n
+}
+
+// refFromFloat64 is the reference conversion from float64 bits.
+func refFromFloat64(b uint64) (Uint128, Flags) {
+	f := math.Float64frombits(b)
+	switch {
+	case math.IsNaN(f):
+		var flags Flags
+		if b&(1<<51) == 0 {
+			flags = Invalid
+		}
+		return uint128.FromBig(refBinary128.special(false, true)), flags
+	case math.IsInf(f, 0):
+		return uint128.FromBig(refBinary128.special(f < 0, false)), 0
+	}
+	q := new(big.Rat).SetFloat64(math.Abs(f))
+	n, flags := refBinary128.round(math.Signbit(f), q, ToNearestEven)
+	return uint128.FromBig(n), flags
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// operand generators ////////////////////////////////////////////////////////
+
+// specials are the interesting binary128 values.
+var specials = []Uint128{
+	{Hi: 0, Lo: 0},                                   // +0
+	{Hi: 0x3FFF000000000000, Lo: 0},                  // 1
+	{Hi: 0x4000000000000000, Lo: 0},                  // 2
+	{Hi: 0x3FFE000000000000, Lo: 0},                  // 0.5
+	{Hi: 0x3FFF800000000000, Lo: 0},                  // 1.5
+	{Hi: 0x3FFFFFFFFFFFFFFF, Lo: 0xFFFFFFFFFFFFFFFF}, // 2-ulp
+	{Hi: 0, Lo: 1},                                   // min subnormal
+	{Hi: 0x0000FFFFFFFFFFFF, Lo: 0xFFFFFFFFFFFFFFFF}, // max subnormal
+	{Hi: 0x0001000000000000, Lo: 0},                  // min normal
+	{Hi: 0x7FFEFFFFFFFFFFFF, Lo: 0xFFFFFFFFFFFFFFFF}, // max finite
+	{Hi: 0x7FFF000000000000, Lo: 0},                  // +Inf
+	{Hi: 0x7FFF800000000000, Lo: 0},                  // quiet NaN
+	{Hi: 0x7FFF000000000000, Lo: 1},                  // signaling NaN
+	{Hi: 0x7FFFC00000000000, Lo: 0x123},              // quiet NaN with payload
+}
+
+// randFraction returns 112-bit fraction with TestFloat-like patterns.
+func randFraction(r *rand.Rand) Uint128 {
+	var f Uint128
+	switch r.Intn(6) {
+	case 0: // random
+		f = Uint128{Lo: r.Uint64(), Hi: r.Uint64()}
+	case 1: // few bits set
+		for n := r.Intn(3) + 1; n > 0; n-- {
+			f = f.SetBit(uint(r.Intn(112)), 1)
+		}
+	case 2: // run of ones from the top
+		f = uint128.Mask(uint(r.Intn(113))).Lsh(uint(112 - r.Intn(113)))
+	case 3: // run of ones from the bottom
+		f = uint128.Mask(uint(r.Intn(113)))
+	case 4: // all ones but few bits
+		f = uint128.Max()
+		for n := r.Intn(3) + 1; n > 0; n-- {
+			f = f.ClearBit(uint(r.Intn(112)))
+		}
+	default: // zero
+	}
+	return f.And(uint128.Mask(112))
+}
+
+// randExponent returns biased exponent with boundary cases more likely.
+func randExponent(r *rand.Rand) int {
+	switch r.Intn(8) {
+	case 0:
+		return 0 // subnormal
+	case 1:
+		return 1 + r.Intn(4) // tiny
+	case 2:
+		return 0x7FFE - r.Intn(4) // huge
+	case 3, 4:
+		return 0x3FFF - 8 + r.Intn(17) // near one
+	default:
+		return 1 + r.Intn(0x7FFE)
+	}
+}
+
+// randFloat128 returns random binary128 bits.
+func randFloat128(r *rand.Rand) Uint128 {
+	var u Uint128
+	if r.Intn(16) == 0 {
+		u = specials[r.Intn(len(specials))]
+	} else {
+		u = randFraction(r).Or(uint128.From64(uint64(randExponent(r))).Lsh(112))
+	}
+	if r.Intn(2) == 0 {
+		u.Hi |= 1 << 63
+	}
+	return u
+}
+
+// withExponent returns u with the biased exponent changed by delta, clamped.
+func withExponent(u Uint128, delta int) Uint128 {
+	e := int(u.Hi>>48&0x7FFF) + delta
+	if e < 0 {
+		e = 0
+	}
+	if e > 0x7FFE {
+		e = 0x7FFE
+	}
+	u.Hi = u.Hi&^(0x7FFF<<48) | uint64(e)<<48
+	return u
+}
+
+// genAddends generates addends with close exponents quite often.
+func genAddends(r *rand.Rand) []Uint128 {
+	x, y := randFloat128(r), randFloat128(r)
+	if r.Intn(2) == 0 && FromBits(x).IsFinite() {
+		y = withExponent(x, r.Intn(7)-3)
+		y = y.Xor(uint128.Mask(uint(r.Intn(8))))
+		if r.Intn(2) == 0 {
+			y.Hi ^= 1 << 63
+		}
+	}
+	return []Uint128{x, y}
+}
+
+// genFactors generates factors, the product of random ones often overflows,
+// so the exponents are moved to the middle sometimes.
+func genFactors(r *rand.Rand) []Uint128 {
+	x, y := randFloat128(r), randFloat128(r)
+	if r.Intn(2) == 0 && FromBits(x).IsFinite() && FromBits(y).IsFinite() {
+		ex := int(x.Hi >> 48 & 0x7FFF)
+		y = withExponent(y, 0x3FFF-ex-int(y.Hi>>48&0x7FFF)+0x3FFF+r.Intn(9)-4) // near 1/x
+		if r.Intn(4) == 0 {
+			y = withExponent(y, -0x3FFF+r.Intn(200)-100) // near the limits
+		}
+	}
+	return []Uint128{x, y}
+}
+
+// genMulAdd generates operands with the addend close to the negated product quite often.
+func genMulAdd(r *rand.Rand) []Uint128 {
+	a := genFactors(r)
+	z := randFloat128(r)
+	if r.Intn(2) == 0 {
+		if p, _ := refMul(FromBits(a[0]), FromBits(a[1]), ToNearestEven); p.IsFinite() {
+			z = p.Neg().Bits().Xor(uint128.Mask(uint(r.Intn(4))))
+			z = withExponent(z, r.Intn(3)-1)
+		}
+	}
+	return append(a, z)
+}
+
+// genCompared generates the same or close values quite often.
+func genCompared(r *rand.Rand) []Uint128 {
+	x, y := randFloat128(r), randFloat128(r)
+	switch r.Intn(4) {
+	case 0:
+		y = x
+	case 1:
+		y = x.Xor(uint128.One())
+	case 2:
+		y = x.Xor(Uint128{Hi: 1 << 63})
+	}
+	return []Uint128{x, y}
+}
+
+// genNearFloat64 generates values near binary64 range boundaries.
+func genNearFloat64(r *rand.Rand) []Uint128 {
+	x := randFloat128(r)
+	if r.Intn(2) == 0 && FromBits(x).IsFinite() {
+		edges := []int{1023, -1022, -1074, 0}
+		e := edges[r.Intn(len(edges))] + r.Intn(9) - 4
+		x = withExponent(x, e+0x3FFF-int(x.Hi>>48&0x7FFF))
+	}
+	return []Uint128{x}
+}
+
+// genNearInteger generates values in or near 128-bit integer range.
+func genNearInteger(r *rand.Rand) []Uint128 {
+	x := randFloat128(r)
+	if r.Intn(4) != 0 && FromBits(x).IsFinite() {
+		e := r.Intn(132) - 3
+		if r.Intn(2) == 0 {
+			e = []int{-1, 0, 112, 126, 127, 128}[r.Intn(6)]
+		}
+		x = withExponent(x, e+0x3FFF-int(x.Hi>>48&0x7FFF))
+	}
+	return []Uint128{x}
+}
+
+// randOperand returns random operand of the kind.
+func randOperand(r *rand.Rand, k kind) Uint128 {
+	switch k {
+	case kindF128:
+		return randFloat128(r)
+	case kindF64:
+		switch r.Intn(8) {
+		case 0:
+			return uint128.From64([]uint64{0x7FF0000000000001, 0x7FF8000000000000, 0x7FF0000000000000, 0, 1}[r.Intn(5)])
+		case 1:
+			return uint128.From64(r.Uint64() & (1<<52 - 1)) // subnormal
+		}
+		return uint128.From64(r.Uint64())
+	case kindInt64:
+		return uint128.From64(randOperand(r, kindInt).Lo)
+	}
+
+	// integer of random bit length, the rounding is tested with long ones
+	u := Uint128{Lo: r.Uint64(), Hi: r.Uint64()}.Rsh(uint(r.Intn(128)))
+	if r.Intn(4) == 0 {
+		u = u.Or(uint128.Mask(uint(r.Intn(128)))) // run of ones
+	}
+	if r.Intn(4) == 0 {
+		u = uint128.Zero().Sub(u) // negative
+	}
+	return u
+}
+
+// operands generates random operands for the operation.
+func (op vectorOp) operands(r *rand.Rand) []Uint128 {
+	if op.gen != nil {
+		return op.gen(r)
+	}
+	a := make([]Uint128, len(op.args))
+	for i, k := range op.args {
+		a[i] = randOperand(r, k)
+	}
+	return a
+}
+
+// modes returns the rounding modes to test.
+func (op vectorOp) modes() []RoundingMode {
+	if op.rounded {
+		return allModes
+	}
+	return allModes[:1]
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// test vectors //////////////////////////////////////////////////////////////
+
+// TestVectors verifies the operations against Berkeley TestFloat vectors.
+func TestVectors(t *testing.T) {
+	modes := make(map[string]RoundingMode)
+	for m, name := range modeNames {
+		modes[name] = m
+	}
+
+	for _, op := range vectorOps {
+		if !op.testFloat {
+			continue
+		}
+		op := op
+		t.Run(op.name, func(t *testing.T) {
+			path := filepath.Join(*vectors, op.name+".txt")
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatalf("failed to open vectors: %s", err)
+			}
+			defer f.Close()
+
+			count, errors := 0, 0
+			s := bufio.NewScanner(f)
+			for line := 1; s.Scan(); line++ {
+				text := s.Text()
+				if text == "" || text[0] == '#' {
+					continue
+				}
+
+				fields := strings.Fields(text)
+				if len(fields) != len(op.args)+3 {
+					t.Fatalf("%s:%d: bad number of fields", path, line)
+				}
+				mode, ok := modes[fields[0]]
+				if !ok {
+					t.Fatalf("%s:%d: bad rounding mode %q", path, line, fields[0])
+				}
+				a := make([]Uint128, len(op.args))
+				for i, k := range op.args {
+					if a[i], err = k.parse(fields[1+i]); err != nil {
+						t.Fatalf("%s:%d: bad operand: %s", path, line, err)
+					}
+				}
+				expected, err := op.res.parse(fields[len(fields)-2])
+				if err != nil {
+					t.Fatalf("%s:%d: bad result: %s", path, line, err)
+				}
+				eflags, err := strconv.ParseUint(fields[len(fields)-1], 16, 8)
+				if err != nil {
+					t.Fatalf("%s:%d: bad flags: %s", path, line, err)
+				}
+
+				// the result of invalid integer conversion is not specified
+				c := Context{Mode: mode}
+				got := op.impl(&c, a)
+				if Flags(eflags)&Invalid != 0 && op.res == kindInt64 {
+					got = expected
+				}
+				if !op.res.same(got, expected) || c.Flags != Flags(eflags) {
+					t.Errorf("%s:%d: %s(%s) should be %s (%s), got %s (%s)", path, line, op.name,
+						strings.Join(fields[1:1+len(op.args)], ", "), op.res.format(expected),
+						Flags(eflags), op.res.format(got), c.Flags)
+					if errors++; errors >= 20 {
+						t.Fatalf("%s: too many errors", path)
+					}
+				}
+				count++
+			}
+			if err := s.Err(); err != nil {
+				t.Fatalf("failed to read vectors: %s", err)
+			}
+			if count == 0 {
+				t.Fatalf("%s: no vectors", path)
+			}
+		})
+	}
+}
+
+// TestReference verifies the operations against the reference on random operands.
+func TestReference(t *testing.T) {
+	n := 400
+	if testing.Short() {
+		n = 40
+	}
+
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for _, op := range vectorOps {
+		for _, mode := range op.modes() {
+			for i := 0; i < n; i++ {
+				a := op.operands(r)
+				expected, eflags := op.ref(mode, a)
+				c := Context{Mode: mode}
+				if got := op.impl(&c, a); !op.res.same(got, expected) || c.Flags != eflags {
+					args := make([]string, len(a))
+					for j, k := range op.args {
+						args[j] = k.format(a[j])
+					}
+					t.Fatalf("%s(%s) with %s should be %s (%s), got %s (%s)", op.name, strings.Join(args, ", "),
+						mode, op.res.format(expected), eflags, op.res.format(got), c.Flags)
+				}
+			}
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// API and decimal strings ///////////////////////////////////////////////////
+
+// mustParse parses the string or panics.
+func mustParse(s string) Float128 {
+	x, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+// hex128 returns the binary128 value from 32 hex digits.
+func hex128(s string) Float128 {
+	u, err := kindF128.parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return FromBits(u)
+}
+
+// TestKnownValues unit tests for well known binary128 values.
+func TestKnownValues(t *testing.T) {
+	three := FromInt64(3)
+	for _, tc := range []struct {
+		x        Float128
+		expected string
+	}{
+		{FromInt64(1), "3fff0000000000000000000000000000"},
+		{FromInt64(-2), "c0000000000000000000000000000000"},
+		{FromUint64(1).Div(three), "3ffd5555555555555555555555555555"},
+		{FromInt64(2).Sqrt(), "3fff6a09e667f3bcc908b2fb1366ea95"},
+		{mustParse("0.1"), "3ffb999999999999999999999999999a"},
+		{mustParse("3.14159265358979323846264338327950288419716939937510"), "4000921fb54442d18469898cc51701b8"},
+		{mustParse("1.189731495357231765085759326628007e4932"), "7ffeffffffffffffffffffffffffffff"},
+		{mustParse("6.475175119438025110924438958227646552e-4966"), "00000000000000000000000000000001"},
+		{mustParse("-0"), "80000000000000000000000000000000"},
+		{mustParse("-inf"), "ffff0000000000000000000000000000"},
+		{FromFloat64(math.Inf(+1)), "7fff0000000000000000000000000000"},
+		{FromFloat64(0.1), "3ffb999999999999a000000000000000"},
+		{FromInt128(Uint128{Hi: 1 << 63}), "c07e0000000000000000000000000000"}, // -2^127
+		{FromUint128(uint128.Max()), "407f0000000000000000000000000000"},       // rounded to 2^128
+	} {
+		if got := kindF128.format(tc.x.Bits()); got != tc.expected {
+			t.Errorf("%v should be %s, got %s", tc.x, tc.expected, got)
+		}
+	}
+}
+
+// TestClassify unit tests for classification and sign functions.
+func TestClassify(t *testing.T) {
+	one, zero := FromInt64(1), Float128{}
+	nan, inf := NaN(), Inf(+1)
+	sub := hex128("00000000000000000000000000000001")
+
+	if !nan.IsNaN() || one.IsNaN() || inf.IsNaN() || hex128("7fff0000000000000000000000000001").IsNaN() == false {
+		t.Errorf("IsNaN failed")
+	}
+	if !inf.IsInf(0) || !inf.IsInf(+1) || inf.IsInf(-1) || !Inf(-1).IsInf(-1) || nan.IsInf(0) || one.IsInf(0) {
+		t.Errorf("IsInf failed")
+	}
+	if !zero.IsZero() || !zero.Neg().IsZero() || sub.IsZero() || !sub.IsSubnormal() || one.IsSubnormal() || zero.IsSubnormal() {
+		t.Errorf("IsZero/IsSubnormal failed")
+	}
+	if !one.IsFinite() || !sub.IsFinite() || inf.IsFinite() || nan.IsFinite() {
+		t.Errorf("IsFinite failed")
+	}
+	if one.Sign() != 1 || one.Neg().Sign() != -1 || zero.Neg().Sign() != 0 || nan.Sign() != 0 || Inf(-1).Sign() != -1 {
+		t.Errorf("Sign failed")
+	}
+	if !zero.Neg().Signbit() || zero.Signbit() || one.Neg().Abs().Signbit() {
+		t.Errorf("Signbit/Neg/Abs failed")
+	}
+
+	// comparisons
+	if !zero.Equal(zero.Neg()) || nan.Equal(nan) || nan.Less(one) || one.Less(nan) || nan.LessEqual(nan) {
+		t.Errorf("comparison with NaN or zeros failed")
+	}
+	if !one.Neg().Less(sub) || !Inf(-1).Less(one) || !one.LessEqual(one) || one.Less(one) || inf.Less(one) {
+		t.Errorf("comparison failed")
+	}
+
+	if got := Flags(0).String(); got != "0" {
+		t.Errorf("Flags(0) should be 0, got %s", got)
+	}
+	if got := (Inexact | Invalid | 0x40).String(); got != "Inexact|Invalid|0x40" {
+		t.Errorf("Flags should be Inexact|Invalid|0x40, got %s", got)
+	}
+	if got := RoundingMode(7).String(); got != "RoundingMode(7)" {
+		t.Errorf("RoundingMode(7) should be RoundingMode(7), got %s", got)
+	}
+}
+
+// TestContext unit tests for the sticky flags.
+func TestContext(t *testing.T) {
+	var c Context
+	one := FromInt64(1)
+	c.Div(one, FromInt64(3))
+	c.Div(one, Float128{})
+	if c.Flags != Inexact|DivByZero {
+		t.Errorf("flags should be Inexact|DivByZero, got %s", c.Flags)
+	}
+	c.Less(one, NaN())
+	if c.Flags != Inexact|DivByZero|Invalid {
+		t.Errorf("flags should be Inexact|DivByZero|Invalid, got %s", c.Flags)
+	}
+
+	// the same operation in different modes
+	third := map[RoundingMode]string{
+		ToNearestEven: "3ffd5555555555555555555555555555",
+		ToNearestAway: "3ffd5555555555555555555555555555",
+		ToZero:        "3ffd5555555555555555555555555555",
+		ToNegativeInf: "3ffd5555555555555555555555555555",
+		ToPositiveInf: "3ffd5555555555555555555555555556",
+	}
+	for mode, expected := range third {
+		c := Context{Mode: mode}
+		if got := kindF128.format(c.Div(one, FromInt64(3)).Bits()); got != expected {
+			t.Errorf("1/3 with %s should be %s, got %s", mode, expected, got)
+		}
+	}
+}
+
+// TestIntegers unit tests for integer conversions.
+func TestIntegers(t *testing.T) {
+	two127 := Uint128{Hi: 1 << 63}
+	for _, tc := range []struct {
+		x   Float128
+		u   Uint128
+		uok bool
+		i   Uint128
+		iok bool
+	}{
+		{FromFloat64(2.75), uint128.From64(2), true, uint128.From64(2), true},
+		{FromFloat64(-0.5), uint128.Zero(), true, uint128.Zero(), true},
+		{FromFloat64(-2.75), uint128.Zero(), false, uint128.Zero().Sub64(2), true},
+		{NaN(), uint128.Zero(), false, uint128.Zero(), false},
+		{Inf(+1), uint128.Max(), false, two127.Sub64(1), false},
+		{Inf(-1), uint128.Zero(), false, two127, false},
+		{mustParse("1e40"), uint128.Max(), false, two127.Sub64(1), false},
+		{FromInt128(two127), uint128.Zero(), false, two127, true},
+		{FromInt128(two127).Neg(), two127, true, two127.Sub64(1), false},
+		{FromInt64(-12345), uint128.Zero(), false, uint128.Zero().Sub64(12345), true},
+	} {
+		if u, ok := tc.x.Uint128(); !u.Equals(tc.u) || ok != tc.uok {
+			t.Errorf("%v.Uint128() should be (%v, %t), got (%v, %t)", tc.x, tc.u, tc.uok, u, ok)
+		}
+		if i, ok := tc.x.Int128(); !i.Equals(tc.i) || ok != tc.iok {
+			t.Errorf("%v.Int128() should be (%#x, %t), got (%#x, %t)", tc.x, tc.i, tc.iok, i, ok)
+		}
+	}
+
+	for _, v := range []int64{0, 1, -1, 1 << 62, math.MinInt64, math.MaxInt64} {
+		if i, ok := FromInt64(v).Int128(); !ok || !i.Equals(refTwos(big.NewInt(v))) {
+			t.Errorf("FromInt64(%d).Int128() should be %d, got %#x", v, v, i)
+		}
+	}
+}
+
+// TestFloat64 unit tests for float64 round trip.
+func TestFloat64(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		f := math.Float64frombits(rand.Uint64())
+		if got := FromFloat64(f).Float64(); math.Float64bits(got) != math.Float64bits(f) && !math.IsNaN(f) {
+			t.Fatalf("FromFloat64(%v).Float64() should be %v, got %v", f, f, got)
+		}
+	}
+	if got := FromFloat64(math.NaN()).Float64(); !math.IsNaN(got) {
+		t.Errorf("NaN should be converted to NaN, got %v", got)
+	}
+	if got := mustParse("1e400").Float64(); !math.IsInf(got, +1) {
+		t.Errorf("1e400 should be converted to +Inf, got %v", got)
+	}
+	if got := mustParse("-1e-400").Float64(); got != 0 || !math.Signbit(got) {
+		t.Errorf("-1e-400 should be converted to -0, got %v", got)
+	}
+}
+
+// TestBigFloat unit tests for big.Float conversions.
+func TestBigFloat(t *testing.T) {
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 2000; i++ {
+		x := FromBits(randFloat128(r))
+		f := x.BigFloat()
+		switch {
+		case x.IsNaN():
+			if f != nil {
+				t.Fatalf("%v.BigFloat() should be nil", x)
+			}
+			continue
+		case x.IsInf(0):
+			if !f.IsInf() || f.Signbit() != x.Signbit() {
+				t.Fatalf("%v.BigFloat() should be infinity, got %v", x, f)
+			}
+		default:
+			if q, _ := f.Rat(nil); q.Cmp(refDecode(x.Bits()).signed()) != 0 {
+				t.Fatalf("%v.BigFloat() should be exact, got %v", x, f)
+			}
+		}
+		if got := FromBigFloat(f); !got.Bits().Equals(x.Bits()) {
+			t.Fatalf("FromBigFloat(%v) should be %v, got %v", f, x, got)
+		}
+	}
+
+	// rounding of high precision value
+	third := new(big.Float).SetPrec(1000).Quo(big.NewFloat(1), big.NewFloat(3))
+	if got, expected := FromBigFloat(third), FromInt64(1).Div(FromInt64(3)); !got.Equal(expected) {
+		t.Errorf("FromBigFloat(1/3) should be %v, got %v", expected, got)
+	}
+	var c Context
+	c.FromBigFloat(new(big.Float).SetMantExp(big.NewFloat(1), 20000))
+	if c.Flags != Overflow|Inexact {
+		t.Errorf("FromBigFloat(2^20000) should overflow, got %s", c.Flags)
+	}
+}
+
+// randDecimal returns random decimal string.
+func randDecimal(r *rand.Rand) string {
+	var sb strings.Builder
+	switch r.Intn(3) {
+	case 0:
+		sb.WriteByte('-')
+	case 1:
+		sb.WriteByte('+')
+	}
+	for n := r.Intn(40) + 1; n > 0; n-- {
+		sb.WriteByte(byte('0' + r.Intn(10)))
+	}
+	if r.Intn(2) == 0 {
+		sb.WriteByte('.')
+		for n := r.Intn(10); n > 0; n-- {
+			sb.WriteByte(byte('0' + r.Intn(10)))
+		}
+	}
+	e := r.Intn(10000) - 5000
+	if r.Intn(4) == 0 {
+		e = r.Intn(80) - 40 + []int{4932, -4932, -4966, -4940}[r.Intn(4)]
+	}
+	sb.WriteString("e" + strconv.Itoa(e))
+	return sb.String()
+}
+
+// TestParse unit tests for decimal string conversion.
+func TestParse(t *testing.T) {
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 2000; i++ {
+		s := randDecimal(r)
+		for _, mode := range allModes {
+			c := Context{Mode: mode}
+			got, err := c.Parse(s)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %s", s, err)
+			}
+			expected, eflags := refParse(s, mode)
+			if !got.Bits().Equals(expected.Bits()) || c.Flags != eflags {
+				t.Fatalf("Parse(%q) with %s should be %s (%s), got %s (%s)", s, mode,
+					kindF128.format(expected.Bits()), eflags, kindF128.format(got.Bits()), c.Flags)
+			}
+		}
+	}
+
+	for _, tc := range []struct {
+		s        string
+		expected string
+	}{
+		{"1", "1"},
+		{"+1.5", "1.5"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"000123.4500e-2", "1.2345"},
+		{"-0.0e10", "-0"},
+		{"1e999999999999999999", "+Inf"},
+		{"-1e5000", "-Inf"},
+		{"1e-999999999999999999", "0"},
+		{"Infinity", "+Inf"},
+		{"-INF", "-Inf"},
+		{"nan", "NaN"},
+	} {
+		x, err := Parse(tc.s)
+		if err != nil && !x.IsInf(0) {
+			t.Errorf("Parse(%q) failed: %s", tc.s, err)
+		}
+		if got := x.String(); got != tc.expected {
+			t.Errorf("Parse(%q) should be %s, got %s", tc.s, tc.expected, got)
+		}
+	}
+
+	for _, s := range []string{"", "+", "-", ".", "e1", "1e", "1e+", "1.2.3", "0x10", "1_000", " 1", "1 ", "infinite", "--1"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) should fail", s)
+		} else if ne, ok := err.(*strconv.NumError); !ok || ne.Err != strconv.ErrSyntax || ne.Num != s {
+			t.Errorf("Parse(%q) should fail with ErrSyntax, got %#v", s, err)
+		}
+	}
+
+	if _, err := Parse("-1e5000"); err == nil || err.(*strconv.NumError).Err != strconv.ErrRange {
+		t.Errorf("Parse(-1e5000) should fail with ErrRange, got %v", err)
+	}
+}
+
+// TestString unit tests for decimal string round trip and formatting.
+func TestString(t *testing.T) {
+	r := rand.New(rand.NewSource(rand.Int63()))
+	for i := 0; i < 300; i++ { // formatting of tiny values via big.Float is slow
+		x := FromBits(randFloat128(r))
+		s := x.String()
+		got, err := Parse(s)
+		if x.IsNaN() {
+			if s != "NaN" || !got.IsNaN() {
+				t.Fatalf("NaN should be formatted as NaN, got %q", s)
+			}
+			continue
+		}
+		if err != nil || !got.Bits().Equals(x.Bits()) {
+			t.Fatalf("Parse(%v.String()) should be %s, got %s (%v)", s,
+				kindF128.format(x.Bits()), kindF128.format(got.Bits()), err)
+		}
+	}
+
+	third := FromInt64(1).Div(FromInt64(3))
+	for _, tc := range []struct {
+		s        string
+		expected string
+	}{
+		{third.String(), "0.3333333333333333333333333333333333"},
+		{third.Text('e', 5), "3.33333e-01"},
+		{FromInt64(-1).Text('f', 2), "-1.00"},
+		{fmt.Sprintf("%.3f", third), "0.333"},
+		{fmt.Sprintf("%v", mustParse("1e100")), "1e+100"},
+		{fmt.Sprintf("%v", FromFloat64(1e100)), "1.000000000000000015902891109759918e+100"},
+		{fmt.Sprintf("%+.2e", FromInt64(1<<20)), "+1.05e+06"},
+		{fmt.Sprintf("%v|%g|%f", FromInt64(1<<20), FromInt64(1<<20), FromInt64(1<<20)), "1.048576e+06|1.048576e+06|1048576"},
+		{fmt.Sprintf("%6v", NaN()), "   NaN"},
+		{fmt.Sprint(Inf(-1)), "-Inf"},
+	} {
+		if tc.s != tc.expected {
+			t.Errorf("should be %q, got %q", tc.expected, tc.s)
+		}
+	}
+
+	var x Float128
+	if err := x.UnmarshalText([]byte("2.5")); err != nil || !x.Equal(FromFloat64(2.5)) {
+		t.Errorf("UnmarshalText(2.5) failed: %v, %v", x, err)
+	}
+	if text, err := x.MarshalText(); err != nil || string(text) != "2.5" {
+		t.Errorf("MarshalText(2.5) failed: %q, %v", text, err)
+	}
+	if err := x.UnmarshalText([]byte("1e5000")); err == nil {
+		t.Errorf("UnmarshalText(1e5000) should fail")
+	}
+}
@@ -0,0 +1,93 @@
+package float128
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// DummyOutput is exported to avoid unwanted optimizations.
+var DummyOutput int
+
+// rand128slice generates slice of random finite nonzero binary128 values.
+func rand128slice(count int) []Float128 {
+	r := rand.New(rand.NewSource(1))
+	out := make([]Float128, 0, count)
+	for len(out) < count {
+		if x := FromBits(randFloat128(r)); x.IsFinite() && !x.IsZero() {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// BenchmarkArith performance tests for arithmetic operations.
+func BenchmarkArith(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	yy := rand128slice(K + 1)[1:]
+	ff := make([]*big.Float, K)
+	for i, x := range xx {
+		ff[i] = x.BigFloat()
+	}
+
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Add(yy[i%K]).bits.Lo & 1)
+		}
+	})
+
+	b.Run("Big.Add", func(b *testing.B) {
+		z := new(big.Float).SetPrec(113)
+		for i := 0; i < b.N; i++ {
+			z.Add(ff[i%K], ff[(i+1)%K])
+			DummyOutput += z.Sign()
+		}
+	})
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Mul(yy[i%K]).bits.Lo & 1)
+		}
+	})
+
+	b.Run("Big.Mul", func(b *testing.B) {
+		z := new(big.Float).SetPrec(113)
+		for i := 0; i < b.N; i++ {
+			z.Mul(ff[i%K], ff[(i+1)%K])
+			DummyOutput += z.Sign()
+		}
+	})
+
+	b.Run("Div", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Div(yy[i%K]).bits.Lo & 1)
+		}
+	})
+
+	b.Run("Big.Div", func(b *testing.B) {
+		z := new(big.Float).SetPrec(113)
+		for i := 0; i < b.N; i++ {
+			z.Quo(ff[i%K], ff[(i+1)%K])
+			DummyOutput += z.Sign()
+		}
+	})
+
+	b.Run("Sqrt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Abs().Sqrt().bits.Lo & 1)
+		}
+	})
+
+	b.Run("FMA", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].FMA(yy[i%K], xx[(i+1)%K]).bits.Lo & 1)
+		}
+	})
+
+	b.Run("Float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Float64()) & 1
+		}
+	})
+}
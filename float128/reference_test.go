@@ -0,0 +1,481 @@
+package float128
+
+import (
+	"math/big"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// The reference implementation via math/big, used to verify the operations
+// on random operands, see TestReference. It does not share any code with the package: the operands are
+// decoded to exact rationals and the results are rounded with big.Int arithmetic.
+
+// refFormat describes the reference destination format.
+type refFormat struct {
+	prec    int // significand bits including the implicit one
+	expBits int // exponent bits
+}
+
+var (
+	refBinary128 = refFormat{prec: 113, expBits: 15}
+	refBinary64  = refFormat{prec: 53, expBits: 11}
+)
+
+// refValue is a decoded floating-point value.
+type refValue struct {
+	nan, snan bool
+	inf       bool
+	neg       bool
+	q         *big.Rat // exact absolute value of finite number
+}
+
+// isZero reports whether v is zero.
+func (v refValue) isZero() bool {
+	return !v.nan && !v.inf && v.q.Sign() == 0
+}
+
+// signed returns exact signed value of finite v.
+func (v refValue) signed() *big.Rat {
+	q := new(big.Rat).Set(v.q)
+	if v.neg {
+		q.Neg(q)
+	}
+	return q
+}
+
+// refDecode decodes binary128 bits.
+func refDecode(b Uint128) refValue {
+	n := b.Big()
+	v := refValue{neg: n.Bit(127) == 1}
+	exp := int(new(big.Int).Rsh(n, 112).Int64() & 0x7FFF)
+	frac := new(big.Int).And(n, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 112), big.NewInt(1)))
+
+	switch {
+	case exp == 0x7FFF && frac.Sign() == 0:
+		v.inf = true
+	case exp == 0x7FFF:
+		v.nan = true
+		v.snan = frac.Bit(111) == 0
+	default:
+		if exp == 0 {
+			exp = 1
+		} else {
+			frac.SetBit(frac, 112, 1)
+		}
+		v.q = new(big.Rat).SetInt(frac)
+		v.q.Mul(v.q, refPow2(exp-16383-112))
+	}
+	return v
+}
+
+// refPow2 returns 2^e as a rational.
+func refPow2(e int) *big.Rat {
+	if e >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), uint(e)))
+	}
+	return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), uint(-e)))
+}
+
+// refBits packs the format fields.
+func (f refFormat) bits(neg bool, exp int, frac *big.Int) *big.Int {
+	n := new(big.Int).Lsh(big.NewInt(int64(exp)), uint(f.prec-1))
+	n.Or(n, frac)
+	if neg {
+		n.SetBit(n, f.prec-1+f.expBits, 1)
+	}
+	return n
+}
+
+// refSpecial returns bits of infinity or NaN.
+func (f refFormat) special(neg, nan bool) *big.Int {
+	frac := new(big.Int)
+	if nan {
+		frac.SetBit(frac, f.prec-2, 1)
+	}
+	return f.bits(neg, 1<<f.expBits-1, frac)
+}
+
+// refRound rounds the exact value (-1)^neg * q to the format.
+// The zero q gives zero of the given sign.
+func (f refFormat) round(neg bool, q *big.Rat, mode RoundingMode) (*big.Int, Flags) {
+	if q.Sign() == 0 {
+		return f.bits(neg, 0, new(big.Int)), 0
+	}
+
+	bias := 1<<(f.expBits-1) - 1
+	emin, emax := 1-bias, bias
+	num, den := q.Num(), q.Denom()
+
+	// e = floor(log2(q))
+	e := num.BitLen() - den.BitLen()
+	if q.Cmp(refPow2(e)) < 0 {
+		e--
+	}
+
+	// roundAt returns q / 2^scale rounded to an integer
+	roundAt := func(scale int) (*big.Int, bool) {
+		n, d := new(big.Int).Set(num), new(big.Int).Set(den)
+		if scale > 0 {
+			d.Lsh(d, uint(scale))
+		} else {
+			n.Lsh(n, uint(-scale))
+		}
+		m, r := new(big.Int).QuoRem(n, d, new(big.Int))
+		if r.Sign() == 0 {
+			return m, false
+		}
+		c := r.Lsh(r, 1).Cmp(d)
+		var up bool
+		switch mode {
+		case ToNearestEven:
+			up = c > 0 || (c == 0 && m.Bit(0) == 1)
+		case ToNearestAway:
+			up = c >= 0
+		case ToPositiveInf:
+			up = !neg
+		case ToNegativeInf:
+			up = neg
+		}
+		if up {
+			m.Add(m, big.NewInt(1))
+		}
+		return m, true
+	}
+
+	// tininess after rounding with unbounded exponent
+	mu, _ := roundAt(e - (f.prec - 1))
+	tiny := e < emin && !(e == emin-1 && mu.BitLen() > f.prec)
+
+	scale := e - (f.prec - 1)
+	if e < emin {
+		scale = emin - (f.prec - 1)
+	}
+	m, inexact := roundAt(scale)
+	if m.BitLen() > f.prec {
+		m.Rsh(m, 1)
+		scale++
+	}
+
+	var flags Flags
+	if inexact {
+		flags |= Inexact
+		if tiny {
+			flags |= Underflow
+		}
+	}
+
+	if m.BitLen() < f.prec { // subnormal or zero
+		return f.bits(neg, 0, m), flags
+	}
+	if exp := scale + f.prec - 1; exp <= emax {
+		m.SetBit(m, f.prec-1, 0)
+		return f.bits(neg, exp+bias, m), flags
+	}
+
+	// overflow
+	res := f.special(neg, false)
+	if mode == ToZero || (mode == ToPositiveInf && neg) || (mode == ToNegativeInf && !neg) {
+		res.Sub(res, big.NewInt(1))
+	}
+	return res, Overflow | Inexact
+}
+
+// refResult converts reference bits to Float128.
+func refResult(n *big.Int, flags Flags) (Float128, Flags) {
+	return FromBits(uint128.FromBig(n)), flags
+}
+
+// refNaN returns NaN result raising invalid if any operand is signaling.
+func refNaN(vs ...refValue) (Float128, Flags) {
+	var flags Flags
+	for _, v := range vs {
+		if v.snan {
+			flags = Invalid
+		}
+	}
+	return refResult(refBinary128.special(false, true), flags)
+}
+
+// refAnyNaN reports whether any value is NaN.
+func refAnyNaN(vs ...refValue) bool {
+	for _, v := range vs {
+		if v.nan {
+			return true
+		}
+	}
+	return false
+}
+
+// refInvalid returns the invalid operation result.
+func refInvalid() (Float128, Flags) {
+	return refResult(refBinary128.special(false, true), Invalid)
+}
+
+// refInf returns infinity result.
+func refInf(neg bool, flags Flags) (Float128, Flags) {
+	return refResult(refBinary128.special(neg, false), flags)
+}
+
+// refSum rounds the exact sum a+b of the values with signs,
+// taking care of the zero sign.
+func refSum(aneg bool, a *big.Rat, bneg bool, b *big.Rat, mode RoundingMode) (Float128, Flags) {
+	sa, sb := new(big.Rat).Set(a), new(big.Rat).Set(b)
+	if aneg {
+		sa.Neg(sa)
+	}
+	if bneg {
+		sb.Neg(sb)
+	}
+	s := sa.Add(sa, sb)
+	if s.Sign() == 0 {
+		neg := mode == ToNegativeInf
+		if a.Sign() == 0 && b.Sign() == 0 && aneg == bneg {
+			neg = aneg
+		}
+		return refResult(refBinary128.round(neg, s, mode))
+	}
+	return refResult(refBinary128.round(s.Sign() < 0, s.Abs(s), mode))
+}
+
+// refAdd is the reference addition.
+func refAdd(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	a, b := refDecode(x.Bits()), refDecode(y.Bits())
+	switch {
+	case refAnyNaN(a, b):
+		return refNaN(a, b)
+	case a.inf && b.inf && a.neg != b.neg:
+		return refInvalid()
+	case a.inf:
+		return refInf(a.neg, 0)
+	case b.inf:
+		return refInf(b.neg, 0)
+	}
+	return refSum(a.neg, a.q, b.neg, b.q, mode)
+}
+
+// refSub is the reference subtraction.
+func refSub(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	if b := refDecode(y.Bits()); b.nan {
+		return refNaN(refDecode(x.Bits()), b)
+	}
+	return refAdd(x, y.Neg(), mode)
+}
+
+// refMul is the reference multiplication.
+func refMul(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	a, b := refDecode(x.Bits()), refDecode(y.Bits())
+	neg := a.neg != b.neg
+	switch {
+	case refAnyNaN(a, b):
+		return refNaN(a, b)
+	case (a.inf && b.isZero()) || (b.inf && a.isZero()):
+		return refInvalid()
+	case a.inf, b.inf:
+		return refInf(neg, 0)
+	}
+	return refResult(refBinary128.round(neg, new(big.Rat).Mul(a.q, b.q), mode))
+}
+
+// refDiv is the reference division.
+func refDiv(x, y Float128, mode RoundingMode) (Float128, Flags) {
+	a, b := refDecode(x.Bits()), refDecode(y.Bits())
+	neg := a.neg != b.neg
+	switch {
+	case refAnyNaN(a, b):
+		return refNaN(a, b)
+	case (a.inf && b.inf) || (a.isZero() && b.isZero()):
+		return refInvalid()
+	case a.inf:
+		return refInf(neg, 0)
+	case b.inf:
+		return refResult(refBinary128.round(neg, new(big.Rat), mode))
+	case b.isZero():
+		return refInf(neg, DivByZero)
+	}
+	return refResult(refBinary128.round(neg, new(big.Rat).Quo(a.q, b.q), mode))
+}
+
+// refSqrt is the reference square root.
+func refSqrt(x Float128, mode RoundingMode) (Float128, Flags) {
+	a := refDecode(x.Bits())
+	switch {
+	case a.nan:
+		return refNaN(a)
+	case a.isZero():
+		return refResult(refBinary128.round(a.neg, a.q, mode))
+	case a.neg:
+		return refInvalid()
+	case a.inf:
+		return refInf(false, 0)
+	}
+
+	// r = isqrt(q * 2^(2k)) is exact integer part of sqrt(q) * 2^k,
+	// the fractional part is replaced by 1/2 which rounds the same way
+	const k = 8600
+	n := new(big.Rat).Mul(a.q, refPow2(2*k))
+	if !n.IsInt() {
+		panic("refSqrt: precision is not enough")
+	}
+	r := new(big.Int).Sqrt(n.Num())
+	root := new(big.Rat).Mul(new(big.Rat).SetInt(r), refPow2(-k))
+	if new(big.Int).Mul(r, r).Cmp(n.Num()) != 0 {
+		root.Add(root, refPow2(-k-1))
+	}
+	return refResult(refBinary128.round(false, root, mode))
+}
+
+// refFMA is the reference fused multiply-add.
+func refFMA(x, y, z Float128, mode RoundingMode) (Float128, Flags) {
+	a, b, c := refDecode(x.Bits()), refDecode(y.Bits()), refDecode(z.Bits())
+	neg := a.neg != b.neg
+	switch {
+	case (a.inf && b.isZero()) || (b.inf && a.isZero()):
+		return refInvalid() // even if c is quiet NaN
+	case refAnyNaN(a, b, c):
+		return refNaN(a, b, c)
+	case a.inf || b.inf:
+		if c.inf && c.neg != neg {
+			return refInvalid()
+		}
+		return refInf(neg, 0)
+	case c.inf:
+		return refInf(c.neg, 0)
+	}
+	return refSum(neg, new(big.Rat).Mul(a.q, b.q), c.neg, c.q, mode)
+}
+
+// refToFloat64 is the reference conversion to float64 bits.
+func refToFloat64(x Float128, mode RoundingMode) (uint64, Flags) {
+	a := refDecode(x.Bits())
+	switch {
+	case a.nan:
+		var flags Flags
+		if a.snan {
+			flags = Invalid
+		}
+		return refBinary64.special(false, true).Uint64(), flags
+	case a.inf:
+		return refBinary64.special(a.neg, false).Uint64(), 0
+	}
+	n, flags := refBinary64.round(a.neg, a.q, mode)
+	return n.Uint64(), flags
+}
+
+// refFromInt rounds the integer.
+func refFromInt(v *big.Int, mode RoundingMode) (Float128, Flags) {
+	return refResult(refBinary128.round(v.Sign() < 0, new(big.Rat).SetInt(new(big.Int).Abs(v)), mode))
+}
+
+// refToInt rounds to an integer, the result is nil for NaN or infinity.
+func refToInt(x Float128, mode RoundingMode) (*big.Int, bool) {
+	a := refDecode(x.Bits())
+	if a.nan || a.inf {
+		return nil, false
+	}
+
+	q := a.signed()
+	n, r := new(big.Int).QuoRem(q.Num(), q.Denom(), new(big.Int)) // truncated
+	if r.Sign() == 0 {
+		return n, false
+	}
+
+	// r has the sign of q, compare |2r| with the denominator
+	c := new(big.Int).Abs(r)
+	c = c.Lsh(c, 1)
+	cmp := c.Cmp(q.Denom())
+	var away bool
+	switch mode {
+	case ToNearestEven:
+		away = cmp > 0 || (cmp == 0 && n.Bit(0) == 1)
+	case ToNearestAway:
+		away = cmp >= 0
+	case ToPositiveInf:
+		away = q.Sign() > 0
+	case ToNegativeInf:
+		away = q.Sign() < 0
+	}
+	if away {
+		n.Add(n, big.NewInt(int64(q.Sign())))
+	}
+	return n, true
+}
+
+// refToUint128 is the reference conversion to an unsigned integer.
+func refToUint128(x Float128, mode RoundingMode) (Uint128, Flags) {
+	if refDecode(x.Bits()).nan {
+		return uint128.Zero(), Invalid
+	}
+	n, inexact := refToInt(x, mode)
+	switch {
+	case n == nil && !x.Signbit(), n != nil && n.Sign() > 0 && n.BitLen() > 128:
+		return uint128.Max(), Invalid
+	case n == nil, n.Sign() < 0:
+		return uint128.Zero(), Invalid
+	}
+	if inexact {
+		return uint128.FromBig(n), Inexact
+	}
+	return uint128.FromBig(n), 0
+}
+
+// refToInt128 is the reference conversion to a signed integer.
+func refToInt128(x Float128, mode RoundingMode) (Uint128, Flags) {
+	if refDecode(x.Bits()).nan {
+		return uint128.Zero(), Invalid
+	}
+	minInt := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	maxInt := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	n, inexact := refToInt(x, mode)
+	switch {
+	case n == nil && x.Signbit(), n != nil && n.Cmp(minInt) < 0:
+		return refTwos(minInt), Invalid
+	case n == nil, n.Cmp(maxInt) > 0:
+		return refTwos(maxInt), Invalid
+	}
+	if inexact {
+		return refTwos(n), Inexact
+	}
+	return refTwos(n), 0
+}
+
+// refTwos returns two's complement form of 128-bit integer.
+func refTwos(n *big.Int) Uint128 {
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	return uint128.FromBig(n)
+}
+
+// refCompare is the reference comparison, returns -1, 0, +1 or 2 if unordered.
+func refCompare(x, y Float128) (int, Flags) {
+	a, b := refDecode(x.Bits()), refDecode(y.Bits())
+	if a.nan || b.nan {
+		var flags Flags
+		if a.snan || b.snan {
+			flags = Invalid
+		}
+		return 2, flags
+	}
+
+	val := func(v refValue) *big.Rat {
+		if v.inf {
+			inf := refPow2(20000)
+			if v.neg {
+				inf.Neg(inf)
+			}
+			return inf
+		}
+		return v.signed()
+	}
+	return val(a).Cmp(val(b)), 0
+}
+
+// refParse is the reference decimal string conversion.
+func refParse(s string, mode RoundingMode) (Float128, Flags) {
+	q, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic("refParse: bad number " + s)
+	}
+	neg := len(s) != 0 && s[0] == '-'
+	return refResult(refBinary128.round(neg, q.Abs(q), mode))
+}
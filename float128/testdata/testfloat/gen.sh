@@ -0,0 +1,51 @@
+#!/bin/sh
+# Generates the float128 test vectors with Berkeley TestFloat, see README.md.
+#
+#	TESTFLOAT_GEN=/path/to/testfloat_gen ./gen.sh [dir]
+#
+# writes the vector files into dir, the current directory by default.
+# Only each n-th case of the level 1 suite is kept to limit the size,
+# FULL=1 keeps all of them, e.g. to run the complete suite once:
+#
+#	FULL=1 ./gen.sh /tmp/testfloat
+#	go test ./float128 -run TestVectors -vectors /tmp/testfloat
+set -e
+
+gen=${TESTFLOAT_GEN:-testfloat_gen}
+dir=${1:-.}
+rounded="rnear_even rminMag rmin rmax rnear_maxMag"
+
+# vectors <function> <n> <modes...> writes each n-th case
+# of the function for the rounding modes into <function>.txt
+vectors() {
+	fn=$1
+	n=$2
+	shift 2
+	if [ -n "$FULL" ]; then
+		n=1
+	fi
+	{
+		echo "# $fn test vectors generated by Berkeley TestFloat: testfloat_gen -level 1 -seed 1 -exact, each ${n}-th case"
+		echo "# <rounding mode> <operands...> <result> <flags: 01 inexact, 02 underflow, 04 overflow, 08 infinite, 10 invalid>"
+		for mode in "$@"; do
+			"$gen" -level 1 -seed 1 -exact "-$mode" "$fn" |
+				awk -v mode="$mode" -v n="$n" '(NR - 1) % n == 0 { print mode, $0 }'
+		done
+	} >"$dir/$fn.txt"
+}
+
+vectors f128_add 97 $rounded
+vectors f128_sub 97 $rounded
+vectors f128_mul 97 $rounded
+vectors f128_div 97 $rounded
+vectors f128_sqrt 3 $rounded
+vectors f128_mulAdd 12263 $rounded
+vectors f128_eq 23 rnear_even
+vectors f128_lt 23 rnear_even
+vectors f128_le 23 rnear_even
+vectors f128_to_f64 1 $rounded
+vectors f64_to_f128 1 rnear_even
+vectors f128_to_i64 1 $rounded
+vectors f128_to_ui64 1 $rounded
+vectors i64_to_f128 1 rnear_even
+vectors ui64_to_f128 1 rnear_even
@@ -0,0 +1,10 @@
+// Package cpu detects the CPU features used by the assembly implementations
+// of uint128 and uint256 packages.
+package cpu
+
+// CPU features, always false on other than amd64 architectures
+// or with the purego build tag.
+var (
+	HasBMI2 bool // MULX, PDEP, PEXT
+	HasADX  bool // ADCX, ADOX
+)
@@ -0,0 +1,15 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package cpu
+
+func init() {
+	if maxID, _, _, _ := cpuid(0, 0); maxID >= 7 {
+		_, ebx, _, _ := cpuid(7, 0)
+		HasBMI2 = ebx&(1<<8) != 0
+		HasADX = ebx&(1<<19) != 0
+	}
+}
+
+// cpuid executes the CPUID instruction.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
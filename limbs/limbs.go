@@ -0,0 +1,160 @@
+// Package limbs provides multi-precision arithmetic primitives over
+// vectors of 64-bit limbs, similar to math/big internals.
+//
+// The vectors are little-endian: x[0] is the least significant limb.
+// All functions process len(z) limbs and panic if any source vector
+// is shorter than z. The z may be the same vector as any of the sources.
+package limbs
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// errShortSource is panic value for a source vector shorter than z.
+var errShortSource = errors.New("limbs: source vector is shorter than z")
+
+// AddVV sets z = x + y + c and returns the carry out.
+// The carry input must be 0 or 1; the carry output is 0 or 1.
+func AddVV(z, x, y []uint64, c uint64) uint64 {
+	if len(x) < len(z) || len(y) < len(z) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(z)], y[:len(z)] // bounds check elimination
+	for i := range z {
+		z[i], c = bits.Add64(x[i], y[i], c)
+	}
+	return c
+}
+
+// SubVV sets z = x - y - b and returns the borrow out.
+// The borrow input must be 0 or 1; the borrow output is 0 or 1.
+func SubVV(z, x, y []uint64, b uint64) uint64 {
+	if len(x) < len(z) || len(y) < len(z) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(z)], y[:len(z)] // bounds check elimination
+	for i := range z {
+		z[i], b = bits.Sub64(x[i], y[i], b)
+	}
+	return b
+}
+
+// AddVW sets z = x + y and returns the carry out.
+// The y itself is returned if z is empty.
+func AddVW(z, x []uint64, y uint64) uint64 {
+	if len(x) < len(z) {
+		panic(errShortSource)
+	}
+	x = x[:len(z)] // bounds check elimination
+	c := y
+	for i := range z {
+		z[i], c = bits.Add64(x[i], c, 0)
+	}
+	return c
+}
+
+// SubVW sets z = x - y and returns the borrow out.
+// The y itself is returned if z is empty.
+func SubVW(z, x []uint64, y uint64) uint64 {
+	if len(x) < len(z) {
+		panic(errShortSource)
+	}
+	x = x[:len(z)] // bounds check elimination
+	b := y
+	for i := range z {
+		z[i], b = bits.Sub64(x[i], b, 0)
+	}
+	return b
+}
+
+// MulAddVWW sets z = x*y + r and returns the carry limb.
+func MulAddVWW(z, x []uint64, y, r uint64) uint64 {
+	if len(x) < len(z) {
+		panic(errShortSource)
+	}
+	x = x[:len(z)] // bounds check elimination
+	c := r
+	for i := range z {
+		p := uint128.From64(x[i]).Mul64(y).Add64(c)
+		z[i], c = p.Lo, p.Hi
+	}
+	return c
+}
+
+// AddMulVVW sets z = z + x*y and returns the carry limb.
+func AddMulVVW(z, x []uint64, y uint64) uint64 {
+	if len(x) < len(z) {
+		panic(errShortSource)
+	}
+	x = x[:len(z)] // bounds check elimination
+	var c uint64
+	for i := range z {
+		// x*y + z + c never overflows 128 bits
+		p := uint128.From64(x[i]).Mul64(y).Add64(z[i]).Add64(c)
+		z[i], c = p.Lo, p.Hi
+	}
+	return c
+}
+
+// ShlVU sets z = x << s and returns the bits shifted out
+// in the lower bits of the result. The s must be less than 64.
+func ShlVU(z, x []uint64, s uint) uint64 {
+	if len(x) < len(z) {
+		panic(errShortSource)
+	}
+	if len(z) == 0 {
+		return 0
+	}
+	x = x[:len(z)] // bounds check elimination
+	s &= 63        // no shift overflow checks
+	t := 64 - s    // zero shift if s == 0
+	c := x[len(z)-1] >> t
+	for i := len(z) - 1; i > 0; i-- {
+		z[i] = x[i]<<s | x[i-1]>>t
+	}
+	z[0] = x[0] << s
+	return c
+}
+
+// ShrVU sets z = x >> s and returns the bits shifted out
+// in the upper bits of the result. The s must be less than 64.
+func ShrVU(z, x []uint64, s uint) uint64 {
+	if len(x) < len(z) {
+		panic(errShortSource)
+	}
+	if len(z) == 0 {
+		return 0
+	}
+	x = x[:len(z)] // bounds check elimination
+	s &= 63        // no shift overflow checks
+	t := 64 - s    // zero shift if s == 0
+	c := x[0] << t
+	for i := 0; i < len(z)-1; i++ {
+		z[i] = x[i]>>s | x[i+1]<<t
+	}
+	z[len(z)-1] = x[len(z)-1] >> s
+	return c
+}
+
+// DivVW sets z = (r, x) / y and returns the remainder, where r is the
+// initial remainder (most significant limb of the dividend).
+// It panics if y is zero or if r is not less than y.
+func DivVW(z, x []uint64, r, y uint64) uint64 {
+	if len(x) < len(z) {
+		panic(errShortSource)
+	}
+	if y == 0 {
+		panic(errors.New("integer divide by zero"))
+	}
+	if r >= y {
+		panic(errors.New("integer overflow"))
+	}
+	x = x[:len(z)] // bounds check elimination
+	for i := len(z) - 1; i >= 0; i-- {
+		z[i], r = bits.Div64(r, x[i], y)
+	}
+	return r
+}
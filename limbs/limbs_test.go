@@ -0,0 +1,109 @@
+package limbs
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randVector generates vector of random limbs including edge values.
+func randVector(n int) []uint64 {
+	x := make([]uint64, n)
+	for i := range x {
+		switch rand.Intn(4) {
+		case 0:
+			x[i] = 0
+		case 1:
+			x[i] = math.MaxUint64
+		default:
+			x[i] = rand.Uint64()
+		}
+	}
+	return x
+}
+
+// TestLimbs unit tests for limb primitives against the reference implementation.
+func TestLimbs(t *testing.T) {
+	type vvFunc func(z, x, y []uint64, c uint64) uint64
+	type vwFunc func(z, x []uint64, y uint64) uint64
+
+	checkVV := func(t *testing.T, name string, f, ref vvFunc, x, y []uint64, c uint64) {
+		t.Helper()
+		z, ez := make([]uint64, len(x)), make([]uint64, len(x))
+		ec := ref(ez, x, y, c)
+		if got := f(z, x, y, c); got != ec || fmt.Sprint(z) != fmt.Sprint(ez) {
+			t.Fatalf("%s(%x, %x, %d) should be (%x, %d), got (%x, %d)", name, x, y, c, ez, ec, z, got)
+		}
+
+		// in-place
+		z = append([]uint64(nil), x...)
+		if got := f(z, z, y, c); got != ec || fmt.Sprint(z) != fmt.Sprint(ez) {
+			t.Fatalf("%s(%x, %x, %d) in-place should be (%x, %d), got (%x, %d)", name, x, y, c, ez, ec, z, got)
+		}
+	}
+
+	checkVW := func(t *testing.T, name string, f, ref vwFunc, x []uint64, y uint64, z0 []uint64) {
+		t.Helper()
+		z := append([]uint64(nil), z0...)
+		ez := append([]uint64(nil), z0...)
+		ec := ref(ez, x, y)
+		if got := f(z, x, y); got != ec || fmt.Sprint(z) != fmt.Sprint(ez) {
+			t.Fatalf("%s(%x, %x, %x) should be (%x, %x), got (%x, %x)", name, z0, x, y, ez, ec, z, got)
+		}
+	}
+
+	for n := 0; n <= 8; n++ {
+		for k := 0; k < 200; k++ {
+			x, y, z := randVector(n), randVector(n), randVector(n)
+			w := randVector(1)[0]
+			c := uint64(k & 1)
+
+			checkVV(t, "AddVV", AddVV, refAddVV, x, y, c)
+			checkVV(t, "SubVV", SubVV, refSubVV, x, y, c)
+			checkVW(t, "AddVW", AddVW, refAddVW, x, w, z)
+			checkVW(t, "SubVW", SubVW, refSubVW, x, w, z)
+			checkVW(t, "AddMulVVW", AddMulVVW, refAddMulVVW, x, w, z)
+
+			r := rand.Uint64()
+			mulAdd := func(z, x []uint64, y uint64) uint64 { return MulAddVWW(z, x, y, r) }
+			refMulAdd := func(z, x []uint64, y uint64) uint64 { return refMulAddVWW(z, x, y, r) }
+			checkVW(t, "MulAddVWW", mulAdd, refMulAdd, x, w, z)
+
+			s := uint(k % 64)
+			shl := func(z, x []uint64, _ uint64) uint64 { return ShlVU(z, x, s) }
+			refShl := func(z, x []uint64, _ uint64) uint64 { return refShlVU(z, x, s) }
+			checkVW(t, "ShlVU", shl, refShl, x, 0, z)
+			shr := func(z, x []uint64, _ uint64) uint64 { return ShrVU(z, x, s) }
+			refShr := func(z, x []uint64, _ uint64) uint64 { return refShrVU(z, x, s) }
+			checkVW(t, "ShrVU", shr, refShr, x, 0, z)
+
+			if w != 0 {
+				r := rand.Uint64() % w
+				div := func(z, x []uint64, y uint64) uint64 { return DivVW(z, x, r, y) }
+				refDiv := func(z, x []uint64, y uint64) uint64 { return refDivVW(z, x, r, y) }
+				checkVW(t, "DivVW", div, refDiv, x, w, z)
+			}
+		}
+	}
+
+	t.Run("panics", func(t *testing.T) {
+		checkPanic := func(t *testing.T, expected string, f func()) {
+			t.Helper()
+			defer func() {
+				if r := recover(); r == nil {
+					t.Fatalf("expected panic, got nothing")
+				} else if fmt.Sprint(r) != expected {
+					t.Fatalf("unexpected panic: %v", r)
+				}
+			}()
+			f()
+		}
+
+		z, x := make([]uint64, 4, 8), make([]uint64, 3, 8)
+		checkPanic(t, errShortSource.Error(), func() { AddVV(z, x, z, 0) })
+		checkPanic(t, errShortSource.Error(), func() { ShlVU(z, x, 1) })
+		checkPanic(t, "integer divide by zero", func() { DivVW(z, z, 0, 0) })
+		checkPanic(t, "integer overflow", func() { DivVW(z, z, 5, 5) })
+	})
+}
@@ -0,0 +1,54 @@
+package limbs
+
+import (
+	"math/big"
+	"testing"
+)
+
+// DummyOutput is exported to avoid unwanted optimizations
+var DummyOutput int
+
+// BenchmarkLimbs performance tests for limb primitives.
+func BenchmarkLimbs(b *testing.B) {
+	const K = 64
+	x, y, z := randVector(K), randVector(K), randVector(K)
+	w := y[0] | 1 // avoid zero
+
+	b.Run("AddVV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(AddVV(z, x, y, 0))
+		}
+	})
+
+	b.Run("big.Int.Add", func(b *testing.B) {
+		xb, yb, zb := toBig(x), toBig(y), new(big.Int)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			zb.Add(xb, yb)
+		}
+	})
+
+	b.Run("MulAddVWW", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(MulAddVWW(z, x, w, 0))
+		}
+	})
+
+	b.Run("AddMulVVW", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(AddMulVVW(z, x, w))
+		}
+	})
+
+	b.Run("ShlVU", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(ShlVU(z, x, 13))
+		}
+	})
+
+	b.Run("DivVW", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(DivVW(z, x, 0, w))
+		}
+	})
+}
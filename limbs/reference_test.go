@@ -0,0 +1,101 @@
+package limbs
+
+import (
+	"math/big"
+)
+
+// The reference implementation via math/big, used to verify limb primitives.
+
+// toBig returns vector as a *big.Int.
+func toBig(x []uint64) *big.Int {
+	v := new(big.Int)
+	for i := len(x) - 1; i >= 0; i-- {
+		v.Lsh(v, 64)
+		v.Or(v, new(big.Int).SetUint64(x[i]))
+	}
+	return v
+}
+
+// fromBig stores the lower len(z) limbs of v to z and returns the rest.
+func fromBig(z []uint64, v *big.Int) *big.Int {
+	v = new(big.Int).Set(v)
+	mask := new(big.Int).SetUint64(^uint64(0))
+	for i := range z {
+		z[i] = new(big.Int).And(v, mask).Uint64()
+		v.Rsh(v, 64)
+	}
+	return v
+}
+
+// refAddVV is reference implementation of AddVV.
+func refAddVV(z, x, y []uint64, c uint64) uint64 {
+	n := len(z)
+	v := new(big.Int).Add(toBig(x[:n]), toBig(y[:n]))
+	v.Add(v, new(big.Int).SetUint64(c))
+	return fromBig(z, v).Uint64()
+}
+
+// refSubVV is reference implementation of SubVV.
+func refSubVV(z, x, y []uint64, b uint64) uint64 {
+	n := len(z)
+	v := new(big.Int).Sub(toBig(x[:n]), toBig(y[:n]))
+	v.Sub(v, new(big.Int).SetUint64(b))
+	if v.Sign() < 0 {
+		v.Add(v, new(big.Int).Lsh(big.NewInt(1), uint(64*n)))
+		fromBig(z, v)
+		return 1
+	}
+	fromBig(z, v)
+	return 0
+}
+
+// refAddVW is reference implementation of AddVW.
+func refAddVW(z, x []uint64, y uint64) uint64 {
+	v := new(big.Int).Add(toBig(x[:len(z)]), new(big.Int).SetUint64(y))
+	return fromBig(z, v).Uint64()
+}
+
+// refSubVW is reference implementation of SubVW.
+func refSubVW(z, x []uint64, y uint64) uint64 {
+	if len(z) == 0 {
+		return y
+	}
+	return refSubVV(z, x, append([]uint64{y}, make([]uint64, len(z))...), 0)
+}
+
+// refMulAddVWW is reference implementation of MulAddVWW.
+func refMulAddVWW(z, x []uint64, y, r uint64) uint64 {
+	v := new(big.Int).Mul(toBig(x[:len(z)]), new(big.Int).SetUint64(y))
+	v.Add(v, new(big.Int).SetUint64(r))
+	return fromBig(z, v).Uint64()
+}
+
+// refAddMulVVW is reference implementation of AddMulVVW.
+func refAddMulVVW(z, x []uint64, y uint64) uint64 {
+	v := new(big.Int).Mul(toBig(x[:len(z)]), new(big.Int).SetUint64(y))
+	v.Add(v, toBig(z))
+	return fromBig(z, v).Uint64()
+}
+
+// refShlVU is reference implementation of ShlVU.
+func refShlVU(z, x []uint64, s uint) uint64 {
+	v := new(big.Int).Lsh(toBig(x[:len(z)]), s)
+	return fromBig(z, v).Uint64()
+}
+
+// refShrVU is reference implementation of ShrVU.
+func refShrVU(z, x []uint64, s uint) uint64 {
+	v := new(big.Int).Lsh(toBig(x[:len(z)]), 64-s)
+	c := v.Uint64()
+	fromBig(z, v.Rsh(v, 64))
+	return c
+}
+
+// refDivVW is reference implementation of DivVW.
+func refDivVW(z, x []uint64, r, y uint64) uint64 {
+	v := new(big.Int).Lsh(new(big.Int).SetUint64(r), uint(64*len(z)))
+	v.Or(v, toBig(x[:len(z)]))
+	q, m := new(big.Int).QuoRem(v, new(big.Int).SetUint64(y), new(big.Int))
+	fromBig(z, q)
+	return m.Uint64()
+}
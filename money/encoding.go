@@ -0,0 +1,117 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// Errors returned by Parse function are of *strconv.NumError type,
+// its Err field is one of the following errors.
+var (
+	// ErrSyntax indicates that a value does not have the right syntax.
+	ErrSyntax = uint128.ErrSyntax
+
+	// ErrRange indicates that a value is out of range.
+	ErrRange = uint128.ErrRange
+
+	// ErrPrecision indicates that a value has more than MaxScale decimal places.
+	ErrPrecision = uint128.ErrPrecision
+)
+
+// String returns the decimal representation of x with all
+// the scale digits, e.g. "12.50".
+func (x Money) String() string {
+	return uint128.FormatDecimal(x.coef, int(x.scale), uint128.DecimalOptions{})
+}
+
+// Parse parses the decimal string, e.g. "12.50", keeping its scale,
+// i.e. the number of fractional digits, which is 2 in the example.
+// The syntax is the decimal digits with optional decimal point.
+func Parse(s string) (Money, error) {
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = len(s) - i - 1
+	}
+	if scale > MaxScale {
+		return Money{}, &strconv.NumError{Func: "Parse", Num: s, Err: ErrPrecision}
+	}
+
+	coef, err := uint128.ParseDecimal(s, scale, RoundUnnecessary)
+	if err != nil {
+		err.(*strconv.NumError).Func = "Parse"
+		return Money{}, err
+	}
+	return Money{coef: coef, scale: uint8(scale)}, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (x Money) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (x *Money) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*x = v
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The amount is encoded as a string, e.g. "12.50",
+// so that no precision is lost by the float64 decoders.
+func (x Money) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, x.String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both the strings and the numbers are accepted, null is no-op.
+func (x *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) != 0 && data[0] == '"' {
+		var s string // unescaped
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return x.UnmarshalText(data)
+}
+
+// Value implements the driver.Valuer interface.
+// The amount is stored as a string, e.g. "12.50",
+// suitable for the NUMERIC and DECIMAL columns.
+func (x Money) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// The string, []byte and int64 sources are supported.
+// The float64 source is rejected since it might be inexact,
+// the NUMERIC columns should be selected as text instead.
+func (x *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return x.UnmarshalText([]byte(v))
+	case []byte:
+		return x.UnmarshalText(v)
+	case int64:
+		if v < 0 {
+			return &strconv.NumError{Func: "Scan", Num: strconv.FormatInt(v, 10), Err: ErrRange}
+		}
+		*x = Money{coef: uint128.From64(uint64(v))}
+		return nil
+	case float64:
+		return fmt.Errorf("money: cannot scan float64 %v, floats are not supported", v)
+	}
+	return fmt.Errorf("money: cannot scan %T", src)
+}
@@ -0,0 +1,29 @@
+package money_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Pilatuz/bigz/money"
+)
+
+// ExampleMoney is an example for ledger amounts.
+func ExampleMoney() {
+	price, _ := money.Parse("19.99")
+	rate, _ := money.Parse("0.0825") // sales tax
+	tax, _ := price.Mul(rate, money.RoundHalfEven)
+	total, _ := price.Add(tax)
+	fmt.Println(tax, total)
+
+	fmt.Println(total.Split(3))
+	parts, _ := total.Allocate(50, 30, 20)
+	fmt.Println(parts)
+
+	data, _ := json.Marshal(map[string]money.Money{"total": total})
+	fmt.Println(string(data))
+	// Output:
+	// 1.65 21.64
+	// [7.22 7.21 7.21]
+	// [10.83 6.49 4.32]
+	// {"total":"21.64"}
+}
@@ -0,0 +1,273 @@
+// Package money implements exact unsigned decimal amounts on top of
+// uint128.Uint128, suitable for ledgers and accounting.
+//
+// A Money value is a Uint128 coefficient with a fixed decimal scale of up
+// to 18 places, i.e. the amount is coef/10^scale, so "12.50" is 1250 with
+// scale 2. The sums are exact, the products and quotients are computed
+// with the full 256-bit intermediates and rounded once according to the
+// requested rounding mode. Overflows are reported with the ok flag.
+package money
+
+import (
+	"errors"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// Uint128 is an alias for uint128.Uint128 type, the coefficient.
+type Uint128 = uint128.Uint128
+
+// RoundingMode is an alias for uint128.RoundingMode type,
+// it determines how Mul, Div and Rescale round the result.
+type RoundingMode = uint128.RoundingMode
+
+// Rounding modes of Mul, Div and Rescale, the same as in uint128 package.
+// RoundUnnecessary reports any inexact result as a failure.
+const (
+	RoundHalfUp      = uint128.RoundHalfUp      // to nearest, halves up
+	RoundHalfEven    = uint128.RoundHalfEven    // to nearest, halves to even (banker's)
+	RoundHalfDown    = uint128.RoundHalfDown    // to nearest, halves down
+	RoundTowardZero  = uint128.RoundTowardZero  // down, i.e. truncated
+	RoundUp          = uint128.RoundUp          // up, i.e. ceiling
+	RoundUnnecessary = uint128.RoundUnnecessary // exact, no rounding
+)
+
+// MaxScale is the largest number of decimal places.
+const MaxScale = 18
+
+// Money is an unsigned decimal amount coef/10^scale.
+// The zero value is zero with scale 0.
+type Money struct {
+	coef  Uint128
+	scale uint8
+}
+
+// pow10 contains powers of ten that fit into Uint128.
+var pow10 = func() (t [39]Uint128) {
+	t[0] = uint128.One()
+	for i := 1; i < len(t); i++ {
+		t[i] = t[i-1].Mul64(10)
+	}
+	return
+}()
+
+// New returns the amount coef/10^scale.
+// It panics if scale is out of [0, MaxScale] range.
+func New(coef Uint128, scale int) Money {
+	checkScale(scale)
+	return Money{coef: coef, scale: uint8(scale)}
+}
+
+// checkScale panics if scale is out of range.
+func checkScale(scale int) {
+	if scale < 0 || scale > MaxScale {
+		panic(errors.New("money: illegal scale"))
+	}
+}
+
+// Coefficient returns the coefficient of x, i.e. x·10^scale.
+func (x Money) Coefficient() Uint128 {
+	return x.coef
+}
+
+// Scale returns the number of decimal places of x.
+func (x Money) Scale() int {
+	return int(x.scale)
+}
+
+// IsZero returns true if x is zero of any scale.
+func (x Money) IsZero() bool {
+	return x.coef.IsZero()
+}
+
+// Cmp compares x and y and returns:
+//
+//	-1 if x <  y
+//	 0 if x == y
+//	+1 if x >  y
+//
+// The scales do not matter, so 1.5 and 1.50 are equal.
+func (x Money) Cmp(y Money) int {
+	s := maxScale(x, y)
+	xhi, xlo := uint128.Mul(x.coef, pow10[s-int(x.scale)])
+	yhi, ylo := uint128.Mul(y.coef, pow10[s-int(y.scale)])
+	if c := xhi.Cmp(yhi); c != 0 {
+		return c
+	}
+	return xlo.Cmp(ylo)
+}
+
+// Add returns the sum x+y with the larger scale of x and y.
+// Provides ok successful flag as a second return value.
+// If the result overflows then Zero and ok=false returned.
+func (x Money) Add(y Money) (Money, bool) {
+	s := maxScale(x, y)
+	cx, okx := x.upscale(s)
+	cy, oky := y.upscale(s)
+	sum, carry := uint128.Add(cx, cy, 0)
+	if !okx || !oky || carry != 0 {
+		return Money{}, false
+	}
+	return Money{coef: sum, scale: uint8(s)}, true
+}
+
+// Sub returns the difference x-y with the larger scale of x and y.
+// Provides ok successful flag as a second return value.
+// If the result overflows or y is greater than x then Zero and ok=false returned.
+func (x Money) Sub(y Money) (Money, bool) {
+	// the upscaled x may not fit 128 bits while the difference does
+	s := maxScale(x, y)
+	xhi, xlo := uint128.Mul(x.coef, pow10[s-int(x.scale)])
+	yhi, ylo := uint128.Mul(y.coef, pow10[s-int(y.scale)])
+	lo, borrow := uint128.Sub(xlo, ylo, 0)
+	hi, borrow := uint128.Sub(xhi, yhi, borrow)
+	if borrow != 0 || !hi.IsZero() {
+		return Money{}, false
+	}
+	return Money{coef: lo, scale: uint8(s)}, true
+}
+
+// Mul returns the product x*y with the scale of x,
+// rounded according to the mode, e.g. an amount multiplied by a rate.
+// Provides ok successful flag as a second return value.
+// If the result overflows or is inexact with RoundUnnecessary
+// then Zero and ok=false returned.
+func (x Money) Mul(y Money, mode RoundingMode) (Money, bool) {
+	c, ok := mulDiv(x.coef, y.coef, pow10[y.scale], mode)
+	if !ok {
+		return Money{}, false
+	}
+	return Money{coef: c, scale: x.scale}, true
+}
+
+// Div returns the quotient x/y with the scale of x,
+// rounded according to the mode.
+// Provides ok successful flag as a second return value.
+// If y is zero, the result overflows or is inexact with RoundUnnecessary
+// then Zero and ok=false returned.
+func (x Money) Div(y Money, mode RoundingMode) (Money, bool) {
+	c, ok := mulDiv(x.coef, pow10[y.scale], y.coef, mode)
+	if !ok {
+		return Money{}, false
+	}
+	return Money{coef: c, scale: x.scale}, true
+}
+
+// Rescale returns x with the given number of decimal places,
+// rounded according to the mode if scale is reduced.
+// Provides ok successful flag as a second return value.
+// If the result overflows or is inexact with RoundUnnecessary
+// then Zero and ok=false returned.
+// It panics if scale is out of [0, MaxScale] range.
+func (x Money) Rescale(scale int, mode RoundingMode) (Money, bool) {
+	checkScale(scale)
+	var c Uint128
+	var ok bool
+	if scale >= int(x.scale) {
+		c, ok = x.upscale(scale)
+	} else {
+		c, ok = mulDiv(x.coef, uint128.One(), pow10[int(x.scale)-scale], mode)
+	}
+	if !ok {
+		return Money{}, false
+	}
+	return Money{coef: c, scale: uint8(scale)}, true
+}
+
+// Split splits x into n parts of the same scale that sum up to x exactly.
+// The parts differ by at most one unit of the last place,
+// the larger parts come first, e.g. 10.00 splits into 3.34, 3.33 and 3.33.
+// It panics if n is not positive.
+func (x Money) Split(n int) []Money {
+	if n <= 0 {
+		panic(errors.New("money: illegal number of parts"))
+	}
+
+	q, r := x.coef.QuoRem64(uint64(n))
+	parts := make([]Money, n)
+	for i := range parts {
+		parts[i] = Money{coef: q, scale: x.scale}
+		if uint64(i) < r {
+			parts[i].coef = q.Add64(1)
+		}
+	}
+	return parts
+}
+
+// Allocate splits x into parts of the same scale proportionally
+// to the ratios, so that the parts sum up to x exactly. Each part is
+// rounded down first, then the remaining units of the last place are
+// given one by one to the parts with non-zero ratio, in order.
+// For example, 0.05 allocated by 3:7 is 0.02 and 0.03.
+// Provides ok successful flag as a second return value.
+// If there are no ratios, all of them are zero or their sum overflows
+// uint64 then nil and ok=false returned.
+func (x Money) Allocate(ratios ...uint64) ([]Money, bool) {
+	var total uint64
+	for _, r := range ratios {
+		if total+r < total {
+			return nil, false
+		}
+		total += r
+	}
+	if total == 0 {
+		return nil, false
+	}
+
+	parts := make([]Money, len(ratios))
+	left := x.coef
+	for i, r := range ratios {
+		// coef·r/total <= coef, no overflow
+		q, _ := mulDiv(x.coef, uint128.From64(r), uint128.From64(total), RoundTowardZero)
+		parts[i] = Money{coef: q, scale: x.scale}
+		left = left.Sub(q)
+	}
+	for i := 0; !left.IsZero(); i++ {
+		// at most one unit per non-zero ratio is left
+		if ratios[i] != 0 {
+			parts[i].coef = parts[i].coef.Add64(1)
+			left = left.Sub64(1)
+		}
+	}
+	return parts, true
+}
+
+// maxScale returns the larger scale of x and y.
+func maxScale(x, y Money) int {
+	if x.scale > y.scale {
+		return int(x.scale)
+	}
+	return int(y.scale)
+}
+
+// upscale returns the coefficient of x with the larger scale.
+// Provides ok successful flag as a second return value.
+func (x Money) upscale(scale int) (Uint128, bool) {
+	hi, lo := uint128.Mul(x.coef, pow10[scale-int(x.scale)])
+	return lo, hi.IsZero()
+}
+
+// mulDiv returns a·b/d rounded according to the mode,
+// with the 256-bit intermediate product.
+// Provides ok successful flag as a second return value.
+// If d is zero, the result overflows or is inexact with
+// RoundUnnecessary then ok=false returned.
+func mulDiv(a, b, d Uint128, mode RoundingMode) (Uint128, bool) {
+	hi, lo := uint128.Mul(a, b)
+	if hi.Cmp(d) >= 0 {
+		return Uint128{}, false // also division by zero
+	}
+	q, r := uint128.Div(hi, lo, d)
+
+	// the remainder rounded is the carry into the quotient, r < d,
+	// halves go to even quotient, i.e. up if the quotient is odd
+	if mode == RoundHalfEven && q.Lo&1 != 0 {
+		mode = RoundHalfUp
+	}
+	c, ok := r.DivRound(d, mode)
+	q, carry := uint128.Add(q, c, 0)
+	if !ok || carry != 0 {
+		return Uint128{}, false
+	}
+	return q, true
+}
@@ -0,0 +1,328 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// rand128 generates random 128-bit value with random bit length.
+func rand128(r *rand.Rand) Uint128 {
+	u := Uint128{Lo: r.Uint64(), Hi: r.Uint64()}
+	return u.Rsh(uint(r.Intn(129)))
+}
+
+// randMoney generates random amount with random scale.
+func randMoney(r *rand.Rand) Money {
+	return New(rand128(r), r.Intn(MaxScale+1))
+}
+
+// rat returns the exact value of x.
+func rat(x Money) *big.Rat {
+	return new(big.Rat).SetFrac(x.coef.Big(), pow10[x.scale].Big())
+}
+
+// refRound returns the value v·10^scale rounded according to the mode,
+// and whether it is exact.
+func refRound(v *big.Rat, scale int, mode RoundingMode) (*big.Int, bool) {
+	v = new(big.Rat).Mul(v, new(big.Rat).SetInt(pow10[scale].Big()))
+	q, r := new(big.Int).QuoRem(v.Num(), v.Denom(), new(big.Int))
+	if r.Sign() == 0 {
+		return q, true
+	}
+	c := r.Lsh(r, 1).Cmp(v.Denom())
+	switch {
+	case mode == RoundHalfUp && c >= 0,
+		mode == RoundHalfEven && (c > 0 || (c == 0 && q.Bit(0) != 0)),
+		mode == RoundHalfDown && c > 0,
+		mode == RoundUp:
+		q.Add(q, big.NewInt(1))
+	}
+	return q, false
+}
+
+// check compares the result with the reference value rounded to the scale.
+func check(t *testing.T, name string, x, y Money, got Money, ok bool, v *big.Rat, scale int, mode RoundingMode) {
+	t.Helper()
+	var expected *big.Int
+	eok := v != nil
+	if eok {
+		var exact bool
+		expected, exact = refRound(v, scale, mode)
+		eok = expected.BitLen() <= 128 && (exact || mode != RoundUnnecessary)
+	}
+	if ok != eok || ok && (got.coef.Big().Cmp(expected) != 0 || got.Scale() != scale) || !ok && got != (Money{}) {
+		t.Fatalf("%s.%s(%s, %v) should be %v/10^%d (%t), got %s (%t)", x, name, y, mode, expected, scale, eok, got, ok)
+	}
+}
+
+// TestArith checks the arithmetic against math/big.
+func TestArith(t *testing.T) {
+	modes := []RoundingMode{RoundHalfUp, RoundHalfEven, RoundHalfDown, RoundTowardZero, RoundUp, RoundUnnecessary}
+	r := rand.New(rand.NewSource(49))
+	for i := 0; i < 20000; i++ {
+		x, y := randMoney(r), randMoney(r)
+		if r.Intn(4) == 0 {
+			y.coef = y.coef.Rsh(uint(y.coef.BitLen() / 2)) // small values
+		}
+		vx, vy := rat(x), rat(y)
+		mode := modes[r.Intn(len(modes))]
+		s := maxScale(x, y)
+
+		if got, expected := x.Cmp(y), vx.Cmp(vy); got != expected {
+			t.Fatalf("%s.Cmp(%s) should be %d, got %d", x, y, expected, got)
+		}
+
+		z, ok := x.Add(y)
+		check(t, "Add", x, y, z, ok, new(big.Rat).Add(vx, vy), s, RoundTowardZero)
+
+		z, ok = x.Sub(y)
+		if d := new(big.Rat).Sub(vx, vy); d.Sign() >= 0 {
+			check(t, "Sub", x, y, z, ok, d, s, RoundTowardZero)
+		} else {
+			check(t, "Sub", x, y, z, ok, nil, s, RoundTowardZero)
+		}
+
+		z, ok = x.Mul(y, mode)
+		check(t, "Mul", x, y, z, ok, new(big.Rat).Mul(vx, vy), x.Scale(), mode)
+
+		z, ok = x.Div(y, mode)
+		if y.IsZero() {
+			check(t, "Div", x, y, z, ok, nil, x.Scale(), mode)
+		} else {
+			check(t, "Div", x, y, z, ok, new(big.Rat).Quo(vx, vy), x.Scale(), mode)
+		}
+
+		scale := r.Intn(MaxScale + 1)
+		z, ok = x.Rescale(scale, mode)
+		check(t, "Rescale", x, New(uint128.Zero(), scale), z, ok, vx, scale, mode)
+	}
+}
+
+// TestManual checks the arithmetic on the known values.
+func TestManual(t *testing.T) {
+	m := func(s string) Money {
+		t.Helper()
+		x, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return x
+	}
+
+	for _, c := range []struct {
+		x, y     string
+		mode     RoundingMode
+		mul, div string
+	}{
+		{"10.00", "0.075", RoundHalfEven, "0.75", "133.33"},
+		{"2.50", "0.01", RoundHalfEven, "0.02", "250.00"},
+		{"2.50", "0.01", RoundHalfUp, "0.03", "250.00"},
+		{"2.70", "0.01", RoundHalfEven, "0.03", "270.00"},
+		{"1.00", "3", RoundTowardZero, "3.00", "0.33"},
+		{"1.00", "3", RoundUp, "3.00", "0.34"},
+		{"2.00", "3", RoundHalfUp, "6.00", "0.67"},
+		{"2.00", "3", RoundHalfDown, "6.00", "0.67"},
+		{"0.25", "0.5", RoundHalfDown, "0.12", "0.50"},
+	} {
+		x, y := m(c.x), m(c.y)
+		if got, ok := x.Mul(y, c.mode); !ok || got.String() != c.mul {
+			t.Fatalf("%s.Mul(%s, %v) should be %s, got %s (%t)", x, y, c.mode, c.mul, got, ok)
+		}
+		if got, ok := x.Div(y, c.mode); !ok || got.String() != c.div {
+			t.Fatalf("%s.Div(%s, %v) should be %s, got %s (%t)", x, y, c.mode, c.div, got, ok)
+		}
+	}
+
+	if got := RoundUp.String(); got != "Up" {
+		t.Fatalf("RoundUp.String() should be Up, got %q", got)
+	}
+	if got := RoundingMode(10).String(); got != "RoundingMode(10)" {
+		t.Fatalf("RoundingMode(10).String() should be RoundingMode(10), got %q", got)
+	}
+	if got, ok := m("1.5").Add(m("0.25")); !ok || got.String() != "1.75" {
+		t.Fatalf("1.5+0.25 should be 1.75, got %s (%t)", got, ok)
+	}
+	if got, ok := m("1.5").Sub(m("1.50")); !ok || got.String() != "0.00" {
+		t.Fatalf("1.5-1.50 should be 0.00, got %s (%t)", got, ok)
+	}
+	if got, ok := m("1.5").Sub(m("1.51")); ok || got != (Money{}) {
+		t.Fatalf("1.5-1.51 should fail, got %s (%t)", got, ok)
+	}
+	if got, ok := m("1.005").Rescale(2, RoundHalfEven); !ok || got.String() != "1.00" {
+		t.Fatalf("1.005.Rescale(2) should be 1.00, got %s (%t)", got, ok)
+	}
+	if got, ok := m("1.5").Rescale(4, RoundUnnecessary); !ok || got.String() != "1.5000" {
+		t.Fatalf("1.5.Rescale(4) should be 1.5000, got %s (%t)", got, ok)
+	}
+	if got, ok := m("1.55").Rescale(1, RoundUnnecessary); ok {
+		t.Fatalf("1.55.Rescale(1, RoundUnnecessary) should fail, got %s", got)
+	}
+	if got, ok := New(uint128.Max(), 0).Rescale(1, RoundTowardZero); ok {
+		t.Fatalf("Max.Rescale(1) should overflow, got %s", got)
+	}
+	if m("1.5").Cmp(m("1.50")) != 0 || m("1.5").Cmp(m("1.49")) <= 0 {
+		t.Fatalf("1.5 should be equal to 1.50 and greater than 1.49")
+	}
+
+	for _, scale := range []int{-1, MaxScale + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("New(1, %d) should panic", scale)
+				}
+			}()
+			New(uint128.One(), scale)
+		}()
+	}
+}
+
+// TestAllocate checks Split and Allocate.
+func TestAllocate(t *testing.T) {
+	str := func(parts []Money) string {
+		s := ""
+		for i, p := range parts {
+			if i != 0 {
+				s += " "
+			}
+			s += p.String()
+		}
+		return s
+	}
+
+	x, _ := Parse("10.00")
+	if expected, got := "3.34 3.33 3.33", str(x.Split(3)); got != expected {
+		t.Fatalf("10.00.Split(3) should be %q, got %q", expected, got)
+	}
+	y, _ := Parse("0.05")
+	if parts, ok := y.Allocate(3, 7); !ok || str(parts) != "0.02 0.03" {
+		t.Fatalf("0.05.Allocate(3, 7) should be \"0.02 0.03\", got %q (%t)", str(parts), ok)
+	}
+	if parts, ok := y.Allocate(0, 1, 0, 1); !ok || str(parts) != "0.00 0.03 0.00 0.02" {
+		t.Fatalf("0.05.Allocate(0, 1, 0, 1) should be \"0.00 0.03 0.00 0.02\", got %q (%t)", str(parts), ok)
+	}
+	if _, ok := y.Allocate(); ok {
+		t.Fatalf("Allocate() should fail")
+	}
+	if _, ok := y.Allocate(0, 0); ok {
+		t.Fatalf("Allocate(0, 0) should fail")
+	}
+	if _, ok := y.Allocate(1<<63, 1<<63); ok {
+		t.Fatalf("Allocate(2^63, 2^63) should fail")
+	}
+
+	// the parts always sum up to the whole
+	r := rand.New(rand.NewSource(50))
+	for i := 0; i < 2000; i++ {
+		x := randMoney(r)
+		ratios := make([]uint64, 1+r.Intn(10))
+		for k := range ratios {
+			ratios[k] = r.Uint64() >> uint(r.Intn(64))
+		}
+		parts, ok := x.Allocate(ratios...)
+		if !ok {
+			continue // sum overflow
+		}
+		splits := x.Split(len(ratios))
+		for _, all := range [][]Money{parts, splits} {
+			sum := New(uint128.Zero(), x.Scale())
+			for _, p := range all {
+				if p.Scale() != x.Scale() {
+					t.Fatalf("%s parts should have scale %d, got %s", x, x.Scale(), p)
+				}
+				sum, _ = sum.Add(p)
+			}
+			if sum != x {
+				t.Fatalf("%s parts %q should sum up to the whole, got %s", x, str(all), sum)
+			}
+		}
+	}
+}
+
+// TestEncoding checks the string, JSON and SQL encoding.
+func TestEncoding(t *testing.T) {
+	for _, s := range []string{"0", "12.50", "0.000000000000000001", "340282366920938463463374607431768211455", "7."} {
+		x, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		expected := s
+		if expected == "7." {
+			expected = "7"
+		}
+		if got := x.String(); got != expected {
+			t.Fatalf("Parse(%q).String() should be %q, got %q", s, expected, got)
+		}
+	}
+
+	for _, c := range []struct {
+		s        string
+		expected error
+	}{
+		{"", ErrSyntax},
+		{"-1", ErrSyntax},
+		{"1e2", ErrSyntax},
+		{"0.0000000000000000001", ErrPrecision},
+		{"340282366920938463463374607431768211456", ErrRange},
+	} {
+		_, err := Parse(c.s)
+		var ne *strconv.NumError
+		if !errors.As(err, &ne) || ne.Func != "Parse" || !errors.Is(err, c.expected) {
+			t.Fatalf("Parse(%q) should fail with %v, got %v", c.s, c.expected, err)
+		}
+	}
+
+	type Row struct {
+		Price  Money  `json:"price"`
+		Amount *Money `json:"amount,omitempty"`
+	}
+	price, _ := Parse("12.50")
+	data, err := json.Marshal(Row{Price: price})
+	if err != nil || string(data) != `{"price":"12.50"}` {
+		t.Fatalf("json.Marshal should be %q, got %q (%v)", `{"price":"12.50"}`, data, err)
+	}
+	var row Row
+	if err := json.Unmarshal([]byte(`{"price":12.5,"amount":"0.10"}`), &row); err != nil ||
+		row.Price.String() != "12.5" || row.Amount == nil || row.Amount.String() != "0.10" {
+		t.Fatalf("json.Unmarshal failed: %+v (%v)", row, err)
+	}
+	if err := json.Unmarshal([]byte(`{"price":null}`), &row); err != nil || row.Price.String() != "12.5" {
+		t.Fatalf("json.Unmarshal(null) should be no-op, got %+v (%v)", row, err)
+	}
+	if err := json.Unmarshal([]byte(`{"price":"12\u002e50"}`), &row); err != nil || row.Price != price {
+		t.Fatalf("json.Unmarshal(escaped) should be 12.50, got %+v (%v)", row, err)
+	}
+	if err := json.Unmarshal([]byte(`{"price":"-1"}`), &row); !errors.Is(err, ErrSyntax) {
+		t.Fatalf("json.Unmarshal(-1) should fail with %v, got %v", ErrSyntax, err)
+	}
+
+	if v, err := price.Value(); err != nil || v != "12.50" {
+		t.Fatalf("Value() should be \"12.50\", got %v (%v)", v, err)
+	}
+	var x Money
+	for _, src := range []interface{}{"12.50", []byte("12.50")} {
+		if err := x.Scan(src); err != nil || x != price {
+			t.Fatalf("Scan(%v) should be 12.50, got %s (%v)", src, x, err)
+		}
+	}
+	if err := x.Scan(int64(42)); err != nil || x.String() != "42" {
+		t.Fatalf("Scan(42) should be 42, got %s (%v)", x, err)
+	}
+	if err := x.Scan(int64(-42)); !errors.Is(err, ErrRange) {
+		t.Fatalf("Scan(-42) should fail with %v, got %v", ErrRange, err)
+	}
+	for _, src := range []interface{}{nil, 1.5} {
+		if err := x.Scan(src); err == nil {
+			t.Fatalf("Scan(%v) should fail", src)
+		}
+	}
+	if err := x.Scan(1.5); err == nil || !strings.Contains(err.Error(), "floats are not supported") {
+		t.Fatalf("Scan(1.5) should reject floats, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package uint128
+
+import (
+	"github.com/Pilatuz/bigz/internal/cpu"
+)
+
+// The assembly implementations, see arith_amd64.s.
+func pdep64Asm(x, m uint64) uint64
+func pext64Asm(x, m uint64) uint64
+
+// pdep64 deposits the lower bits of x to the positions of set bits of m.
+func pdep64(x, m uint64) uint64 {
+	if cpu.HasBMI2 {
+		return pdep64Asm(x, m)
+	}
+	return pdep64Generic(x, m)
+}
+
+// pext64 extracts the bits of x at the positions of set bits of m.
+func pext64(x, m uint64) uint64 {
+	if cpu.HasBMI2 {
+		return pext64Asm(x, m)
+	}
+	return pext64Generic(x, m)
+}
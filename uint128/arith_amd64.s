@@ -0,0 +1,22 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+#include "textflag.h"
+
+// func pdep64Asm(x, m uint64) uint64
+// requires BMI2
+TEXT ·pdep64Asm(SB), NOSPLIT, $0-24
+	MOVQ x+0(FP), AX
+	MOVQ m+8(FP), BX
+	PDEPQ BX, AX, CX
+	MOVQ CX, ret+16(FP)
+	RET
+
+// func pext64Asm(x, m uint64) uint64
+// requires BMI2
+TEXT ·pext64Asm(SB), NOSPLIT, $0-24
+	MOVQ x+0(FP), AX
+	MOVQ m+8(FP), BX
+	PEXTQ BX, AX, CX
+	MOVQ CX, ret+16(FP)
+	RET
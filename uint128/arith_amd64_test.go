@@ -0,0 +1,28 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package uint128
+
+import (
+	"testing"
+
+	"github.com/Pilatuz/bigz/internal/cpu"
+)
+
+// TestAsm unit tests for assembly implementations.
+func TestAsm(t *testing.T) {
+	if !cpu.HasBMI2 {
+		t.Skip("BMI2 is not supported")
+	}
+
+	values := make(chan Uint128)
+	go generate128s(10000, values)
+	for x := range values {
+		if got, expected := pdep64Asm(x.Lo, x.Hi), pdep64Generic(x.Lo, x.Hi); got != expected {
+			t.Fatalf("pdep64Asm(%#x, %#x) should be %#x, got %#x", x.Lo, x.Hi, expected, got)
+		}
+		if got, expected := pext64Asm(x.Lo, x.Hi), pext64Generic(x.Lo, x.Hi); got != expected {
+			t.Fatalf("pext64Asm(%#x, %#x) should be %#x, got %#x", x.Lo, x.Hi, expected, got)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !amd64 || purego
+// +build !amd64 purego
+
+package uint128
+
+// pdep64 deposits the lower bits of x to the positions of set bits of m.
+func pdep64(x, m uint64) uint64 {
+	return pdep64Generic(x, m)
+}
+
+// pext64 extracts the bits of x at the positions of set bits of m.
+func pext64(x, m uint64) uint64 {
+	return pext64Generic(x, m)
+}
@@ -2,6 +2,7 @@ package uint128_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
@@ -36,9 +37,16 @@ func ExampleFromString() {
 	fmt.Println(u)
 	_, err := uint128.FromString("-1")
 	fmt.Println(err)
+	fmt.Println(errors.Is(err, uint128.ErrNegative), errors.Is(err, uint128.ErrRange), errors.Is(err, uint128.ErrSyntax))
+	_, err = uint128.FromString("abc")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, uint128.ErrNegative), errors.Is(err, uint128.ErrRange), errors.Is(err, uint128.ErrSyntax))
 	// Output:
 	// 1
-	// out of 128-bit range
+	// strconv.FromString: parsing "-1": value out of range: negative
+	// true true false
+	// strconv.FromString: parsing "abc": invalid syntax
+	// false false true
 }
 
 // ExampleUint128_String is an example for Uint128.String.
@@ -1,7 +1,11 @@
 package uint128
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
 	"math/big"
+	"strconv"
 	"testing"
 )
 
@@ -318,3 +322,432 @@ func BenchmarkDiv(b *testing.B) {
 		DummyOutput += int(q.Uint64() & 1)
 	})
 }
+
+// BenchmarkFromString performance tests for FromString.
+func BenchmarkFromString(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	ss := make([]string, K)
+	for i := 0; i < K; i++ {
+		ss[i] = xx[i].String()
+	}
+
+	// native (just as a reference)
+	b.Run("Native_64", func(b *testing.B) {
+		s64 := make([]string, K)
+		for i := 0; i < K; i++ {
+			s64[i] = strconv.FormatUint(xx[i].Lo, 10)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			res, _ := strconv.ParseUint(s64[i%K], 10, 64)
+			DummyOutput += int(res & 1)
+		}
+	})
+
+	// Uint128: native decimal parser
+	b.Run("Uint128.FromString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			res, _ := FromString(ss[i%K])
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	// Uint128: via fmt.Sscan and big.Int (the previous implementation)
+	b.Run("fmt.Sscan+big.Int", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			q := new(big.Int)
+			_, _ = fmt.Sscan(ss[i%K], q)
+			res, _ := FromBigEx(q)
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	// big.Int: SetString
+	b.Run("big.Int.SetString", func(b *testing.B) {
+		b.ReportAllocs()
+		q := new(big.Int)
+		for i := 0; i < b.N; i++ {
+			q, _ = q.SetString(ss[i%K], 10)
+		}
+		DummyOutput += int(q.Uint64() & 1)
+	})
+}
+
+// BenchmarkDivisor performance tests for precomputed Divisor.
+func BenchmarkDivisor(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	yy := rand128slice(K)[0]
+	yh := yy.Lo | 1 // 64-bit half, avoid zero
+	d128 := NewDivisor(yy)
+	d64 := NewDivisor64(yh)
+
+	// Uint128: 128 / 64
+	b.Run("Uint128.QuoRem64_128_64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := xx[i%K].QuoRem64(yh)
+			DummyOutput += int(q.Lo&1) + int(r&1)
+		}
+	})
+
+	// Divisor: 128 / 64
+	b.Run("Divisor.QuoRem_128_64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := d64.QuoRem(xx[i%K])
+			DummyOutput += int(q.Lo&1) + int(r.Lo&1)
+		}
+	})
+
+	// Uint128: 128 / 128
+	b.Run("Uint128.QuoRem_128_128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := xx[i%K].QuoRem(yy)
+			DummyOutput += int(q.Lo&1) + int(r.Lo&1)
+		}
+	})
+
+	// Divisor: 128 / 128
+	b.Run("Divisor.QuoRem_128_128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := d128.QuoRem(xx[i%K])
+			DummyOutput += int(q.Lo&1) + int(r.Lo&1)
+		}
+	})
+}
+
+// BenchmarkSet performance tests for in-place Set* methods.
+func BenchmarkSet(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	yy := rand128slice(K)
+
+	// value: acc += x
+	b.Run("Add", func(b *testing.B) {
+		var acc Uint128
+		for i := 0; i < b.N; i++ {
+			acc = acc.Add(xx[i%K])
+		}
+		DummyOutput += int(acc.Lo & 1)
+	})
+
+	// in-place: acc += x
+	b.Run("SetAdd", func(b *testing.B) {
+		var acc Uint128
+		for i := 0; i < b.N; i++ {
+			acc.SetAdd(&acc, &xx[i%K])
+		}
+		DummyOutput += int(acc.Lo & 1)
+	})
+
+	// value: acc[i] += x[i]
+	b.Run("AddSlice", func(b *testing.B) {
+		acc := make([]Uint128, K)
+		for i := 0; i < b.N; i++ {
+			acc[i%K] = acc[i%K].Add(xx[i%K])
+		}
+		DummyOutput += int(acc[0].Lo & 1)
+	})
+
+	// in-place: acc[i] += x[i]
+	b.Run("SetAddSlice", func(b *testing.B) {
+		acc := make([]Uint128, K)
+		for i := 0; i < b.N; i++ {
+			acc[i%K].SetAdd(&acc[i%K], &xx[i%K])
+		}
+		DummyOutput += int(acc[0].Lo & 1)
+	})
+
+	// value: acc = acc*x + y
+	b.Run("MulAdd", func(b *testing.B) {
+		var acc Uint128
+		for i := 0; i < b.N; i++ {
+			acc = acc.Mul(xx[i%K]).Add(yy[i%K])
+		}
+		DummyOutput += int(acc.Lo & 1)
+	})
+
+	// in-place: acc = acc*x + y
+	b.Run("SetMulAdd", func(b *testing.B) {
+		var acc Uint128
+		for i := 0; i < b.N; i++ {
+			acc.SetMul(&acc, &xx[i%K]).SetAdd(&acc, &yy[i%K])
+		}
+		DummyOutput += int(acc.Lo & 1)
+	})
+
+	// value: acc = (acc << n) ^ x
+	b.Run("LshXor", func(b *testing.B) {
+		var acc Uint128
+		for i := 0; i < b.N; i++ {
+			acc = acc.Lsh(uint(i & 63)).Xor(xx[i%K])
+		}
+		DummyOutput += int(acc.Lo & 1)
+	})
+
+	// in-place: acc = (acc << n) ^ x
+	b.Run("SetLshXor", func(b *testing.B) {
+		var acc Uint128
+		for i := 0; i < b.N; i++ {
+			acc.SetLsh(&acc, uint(i&63)).SetXor(&acc, &xx[i%K])
+		}
+		DummyOutput += int(acc.Lo & 1)
+	})
+}
+
+// BenchmarkLoadSlice performance tests for bulk load and store functions.
+func BenchmarkLoadSlice(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	buf := make([]byte, 16*K)
+	StoreSliceLittleEndian(buf, xx)
+
+	// per element: x[i] = LoadLittleEndian()
+	b.Run("LoadLittleEndian", func(b *testing.B) {
+		b.SetBytes(16 * K)
+		for i := 0; i < b.N; i++ {
+			for j := range xx {
+				xx[j] = LoadLittleEndian(buf[j*16:])
+			}
+		}
+		DummyOutput += int(xx[0].Lo & 1)
+	})
+
+	// bulk: LoadSliceLittleEndian()
+	b.Run("LoadSliceLittleEndian", func(b *testing.B) {
+		b.SetBytes(16 * K)
+		for i := 0; i < b.N; i++ {
+			DummyOutput += LoadSliceLittleEndian(xx, buf)
+		}
+	})
+
+	// bulk: LoadSliceBigEndian()
+	b.Run("LoadSliceBigEndian", func(b *testing.B) {
+		b.SetBytes(16 * K)
+		for i := 0; i < b.N; i++ {
+			DummyOutput += LoadSliceBigEndian(xx, buf)
+		}
+	})
+
+	// per element: StoreLittleEndian(x[i])
+	b.Run("StoreLittleEndian", func(b *testing.B) {
+		b.SetBytes(16 * K)
+		for i := 0; i < b.N; i++ {
+			for j := range xx {
+				StoreLittleEndian(buf[j*16:], xx[j])
+			}
+		}
+		DummyOutput += int(buf[0] & 1)
+	})
+
+	// bulk: StoreSliceLittleEndian()
+	b.Run("StoreSliceLittleEndian", func(b *testing.B) {
+		b.SetBytes(16 * K)
+		for i := 0; i < b.N; i++ {
+			DummyOutput += StoreSliceLittleEndian(buf, xx)
+		}
+	})
+}
+
+// BenchmarkReadWrite performance tests for io.Reader/io.Writer functions.
+func BenchmarkReadWrite(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	buf := make([]byte, 16*K)
+	StoreSliceLittleEndian(buf, xx)
+
+	// reflection: binary.Read
+	b.Run("binary.Read", func(b *testing.B) {
+		r := bytes.NewReader(buf)
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				r.Reset(buf)
+			}
+			var res Uint128
+			_ = binary.Read(r, binary.LittleEndian, &res)
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	// direct: ReadLittleEndian
+	b.Run("ReadLittleEndian", func(b *testing.B) {
+		r := bytes.NewReader(buf)
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				r.Reset(buf)
+			}
+			res, _ := ReadLittleEndian(r)
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	// reflection: binary.Write
+	b.Run("binary.Write", func(b *testing.B) {
+		var w bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				w.Reset()
+			}
+			_ = binary.Write(&w, binary.LittleEndian, xx[i%K])
+		}
+		DummyOutput += w.Len() & 1
+	})
+
+	// direct: WriteLittleEndian
+	b.Run("WriteLittleEndian", func(b *testing.B) {
+		var w bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				w.Reset()
+			}
+			_ = WriteLittleEndian(&w, xx[i%K])
+		}
+		DummyOutput += w.Len() & 1
+	})
+}
+
+// BenchmarkSetBits performance tests for set bit iteration.
+func BenchmarkSetBits(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+
+	// naive: loop over all bits
+	b.Run("Bit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			for j := uint(0); j < 128; j++ {
+				if x.Bit(j) != 0 {
+					DummyOutput += int(j)
+				}
+			}
+		}
+	})
+
+	// callback: ForEachSetBit
+	b.Run("ForEachSetBit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			xx[i%K].ForEachSetBit(func(j int) bool {
+				DummyOutput += j
+				return true
+			})
+		}
+	})
+
+	// select: NthSetBit
+	b.Run("NthSetBit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += xx[i%K].NthSetBit(i & 63)
+		}
+	})
+}
+
+// BenchmarkPermutation performance tests for bit deposit/extract and permutations.
+func BenchmarkPermutation(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	mm := rand128slice(K)
+
+	b.Run("DepositBits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].DepositBits(mm[i%K])
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	b.Run("ExtractBits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].ExtractBits(mm[i%K])
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	src := make([]int, 128)
+	for i := range src {
+		src[i] = 127 - i
+	}
+	p, _ := NewPermutation128(src)
+
+	b.Run("Permutation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := p.Apply(xx[i%K])
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	b.Run("Reverse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].Reverse()
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+}
+
+// BenchmarkCurve performance tests for space-filling curves.
+func BenchmarkCurve(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+
+	b.Run("Interleave2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			res := Interleave2(x.Lo, x.Hi)
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	b.Run("Deinterleave2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x, _ := Deinterleave2(xx[i%K])
+			DummyOutput += int(x & 1)
+		}
+	})
+
+	b.Run("HilbertEncode2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			res := HilbertEncode([]uint64{x.Lo, x.Hi}, 64)
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+
+	b.Run("HilbertDecode2", func(b *testing.B) {
+		var coords [2]uint64
+		for i := 0; i < b.N; i++ {
+			HilbertDecode(xx[i%K], coords[:], 64)
+			DummyOutput += int(coords[0] & 1)
+		}
+	})
+}
+
+// BenchmarkFloat performance tests for float conversions.
+func BenchmarkFloat(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand128slice(K)
+	ff := make([]float64, K)
+	for i, x := range xx {
+		ff[i] = x.Float64()
+	}
+
+	b.Run("Float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Float64()) & 1
+		}
+	})
+
+	b.Run("Big.Float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f, _ := new(big.Float).SetInt(xx[i%K].Big()).Float64()
+			DummyOutput += int(f) & 1
+		}
+	})
+
+	b.Run("FromFloat64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res, _ := FromFloat64(ff[i%K])
+			DummyOutput += int(res.Lo & 1)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package uint128
+
+// The bit accessors below never panic: bits at index 128 and above
+// are read as zero and are silently dropped on write.
+
+// Mask returns the value with the lower n bits set.
+// The result is Max() if n >= 128.
+func Mask(n uint) Uint128 {
+	if n >= 128 {
+		return Max()
+	}
+	return Max().Rsh(128 - n)
+}
+
+// bitAt returns the value with only bit i set.
+// The result is zero if i >= 128.
+func bitAt(i uint) Uint128 {
+	return Uint128{
+		Lo: 1 << i,        // zero if i >= 64
+		Hi: 1 << (i - 64), // zero if i < 64 or i >= 128
+	}
+}
+
+// Bit returns the value of the i-th bit, 0 or 1.
+// The result is 0 if i >= 128.
+func (u Uint128) Bit(i uint) uint {
+	if i < 64 {
+		return uint(u.Lo>>i) & 1
+	}
+	return uint(u.Hi>>(i-64)) & 1 // zero if i >= 128
+}
+
+// SetBit returns the value with the i-th bit set to b, which must be 0 or 1.
+// The value is unchanged if i >= 128.
+func (u Uint128) SetBit(i uint, b uint) Uint128 {
+	if b&1 != 0 {
+		return u.Or(bitAt(i))
+	}
+	return u.AndNot(bitAt(i))
+}
+
+// ClearBit returns the value with the i-th bit cleared.
+// The value is unchanged if i >= 128.
+func (u Uint128) ClearBit(i uint) Uint128 {
+	return u.AndNot(bitAt(i))
+}
+
+// FlipBit returns the value with the i-th bit inverted.
+// The value is unchanged if i >= 128.
+func (u Uint128) FlipBit(i uint) Uint128 {
+	return u.Xor(bitAt(i))
+}
+
+// Extract returns the bit field of given width starting at bit offset,
+// i.e. (u >> offset) & Mask(width). Bits beyond 128 are read as zero.
+func (u Uint128) Extract(offset, width uint) Uint128 {
+	return u.Rsh(offset).And(Mask(width))
+}
+
+// Extract64 returns the bit field of given width starting at bit offset
+// as uint64. The width is limited to 64 bits.
+func (u Uint128) Extract64(offset, width uint) uint64 {
+	if width > 64 {
+		width = 64
+	}
+	return u.Extract(offset, width).Lo
+}
+
+// Insert returns the value with the bit field of given width starting at
+// bit offset replaced by the lower width bits of v.
+// Bits beyond 128 are dropped.
+func (u Uint128) Insert(offset, width uint, v Uint128) Uint128 {
+	m := Mask(width).Lsh(offset)
+	return u.AndNot(m).Or(v.Lsh(offset).And(m))
+}
@@ -0,0 +1,163 @@
+package uint128
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// ForEachSetBit calls fn for each set bit index in ascending order.
+// The iteration stops if fn returns false.
+func (u Uint128) ForEachSetBit(fn func(i int) bool) {
+	for x := u.Lo; x != 0; x &= x - 1 {
+		if !fn(bits.TrailingZeros64(x)) {
+			return
+		}
+	}
+	for x := u.Hi; x != 0; x &= x - 1 {
+		if !fn(64 + bits.TrailingZeros64(x)) {
+			return
+		}
+	}
+}
+
+// NthSetBit returns the index of the n-th set bit, counting from zero
+// (also known as "select"). The result is -1 if there are not enough set bits.
+func (u Uint128) NthSetBit(n int) int {
+	if n < 0 {
+		return -1
+	}
+	if c := bits.OnesCount64(u.Lo); n >= c {
+		if i := select64(u.Hi, n-c); i >= 0 {
+			return 64 + i
+		}
+		return -1
+	}
+	return select64(u.Lo, n)
+}
+
+// Rank returns the number of set bits below the i-th bit, i.e. in [0, i).
+// The result is OnesCount() if i >= 128.
+func (u Uint128) Rank(i uint) int {
+	return u.And(Mask(i)).OnesCount()
+}
+
+// select64 returns the index of the n-th set bit of x or -1 if not found.
+func select64(x uint64, n int) int {
+	if n >= bits.OnesCount64(x) {
+		return -1
+	}
+
+	// skip whole bytes first
+	for s := 0; ; s += 8 {
+		c := bits.OnesCount8(uint8(x >> s))
+		if n < c {
+			x >>= s
+			for ; n > 0; n-- {
+				x &= x - 1 // clear the lowest set bit
+			}
+			return s + bits.TrailingZeros64(x)
+		}
+		n -= c
+	}
+}
+
+// Bitset128 is a set of integers in [0, 128) represented as 128-bit mask.
+// The zero value is an empty set.
+type Bitset128 Uint128
+
+// NewBitset128 returns the set containing the given integers.
+// Integers out of [0, 128) range are ignored.
+func NewBitset128(values ...int) Bitset128 {
+	var s Bitset128
+	for _, i := range values {
+		s = s.Add(i)
+	}
+	return s
+}
+
+// Add returns the set with i added.
+// The set is unchanged if i is out of [0, 128) range.
+func (s Bitset128) Add(i int) Bitset128 {
+	if i < 0 {
+		return s
+	}
+	return Bitset128(Uint128(s).SetBit(uint(i), 1))
+}
+
+// Remove returns the set with i removed.
+func (s Bitset128) Remove(i int) Bitset128 {
+	if i < 0 {
+		return s
+	}
+	return Bitset128(Uint128(s).ClearBit(uint(i)))
+}
+
+// Contains reports whether i is in the set.
+func (s Bitset128) Contains(i int) bool {
+	return i >= 0 && Uint128(s).Bit(uint(i)) != 0
+}
+
+// Len returns the number of integers in the set.
+func (s Bitset128) Len() int {
+	return Uint128(s).OnesCount()
+}
+
+// IsEmpty reports whether the set is empty.
+func (s Bitset128) IsEmpty() bool {
+	return Uint128(s).IsZero()
+}
+
+// Union returns the set of integers in s or t.
+func (s Bitset128) Union(t Bitset128) Bitset128 {
+	return Bitset128(Uint128(s).Or(Uint128(t)))
+}
+
+// Intersect returns the set of integers in both s and t.
+func (s Bitset128) Intersect(t Bitset128) Bitset128 {
+	return Bitset128(Uint128(s).And(Uint128(t)))
+}
+
+// Difference returns the set of integers in s but not in t.
+func (s Bitset128) Difference(t Bitset128) Bitset128 {
+	return Bitset128(Uint128(s).AndNot(Uint128(t)))
+}
+
+// IsSubset reports whether all integers of s are in t.
+func (s Bitset128) IsSubset(t Bitset128) bool {
+	return Uint128(s).AndNot(Uint128(t)).IsZero()
+}
+
+// ForEach calls fn for each integer of the set in ascending order.
+// The iteration stops if fn returns false.
+func (s Bitset128) ForEach(fn func(i int) bool) {
+	Uint128(s).ForEachSetBit(fn)
+}
+
+// Select returns the n-th smallest integer of the set, counting from zero.
+// The result is -1 if the set has not enough integers.
+func (s Bitset128) Select(n int) int {
+	return Uint128(s).NthSetBit(n)
+}
+
+// Rank returns the number of integers in the set less than i.
+func (s Bitset128) Rank(i int) int {
+	if i <= 0 {
+		return 0
+	}
+	return Uint128(s).Rank(uint(i))
+}
+
+// String returns the set literal, e.g. "{1, 5, 127}".
+func (s Bitset128) String() string {
+	buf := make([]byte, 0, 2+4*s.Len())
+	buf = append(buf, '{')
+	s.ForEach(func(i int) bool {
+		if len(buf) > 1 {
+			buf = append(buf, ',', ' ')
+		}
+		buf = strconv.AppendInt(buf, int64(i), 10)
+		return true
+	})
+	buf = append(buf, '}')
+	return string(buf)
+}
@@ -0,0 +1,39 @@
+//go:build go1.23
+// +build go1.23
+
+package uint128
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestSetBitsSeq unit tests for set bit iterators.
+func TestSetBitsSeq(t *testing.T) {
+	values := make(chan Uint128)
+	go generate128s(100, values)
+	for x := range values {
+		expected := setBits(x)
+		if got := slices.Collect(x.Ones()); !slices.Equal(got, expected) {
+			t.Fatalf("%#x.Ones() should be %v, got %v", x, expected, got)
+		}
+		if got := slices.Collect(Bitset128(x).All()); !slices.Equal(got, expected) {
+			t.Fatalf("%#x.All() should be %v, got %v", x, expected, got)
+		}
+
+		// break early
+		var got []int
+		for i := range x.Ones() {
+			if len(got) == 2 {
+				break
+			}
+			got = append(got, i)
+		}
+		if len(expected) > 2 {
+			expected = expected[:2]
+		}
+		if !slices.Equal(got, expected) {
+			t.Fatalf("%#x.Ones() with break should be %v, got %v", x, expected, got)
+		}
+	}
+}
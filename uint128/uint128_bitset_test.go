@@ -0,0 +1,125 @@
+package uint128
+
+import (
+	"testing"
+)
+
+// setBits returns set bit indexes using naive loop over all bits.
+func setBits(u Uint128) []int {
+	var out []int
+	for i := 0; i < 128; i++ {
+		if u.Bit(uint(i)) != 0 {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// TestSetBits unit tests for set bit iteration, NthSetBit and Rank.
+func TestSetBits(t *testing.T) {
+	values := make(chan Uint128)
+	go generate128s(1000, values)
+	for x := range values {
+		expected := setBits(x)
+
+		var got []int
+		x.ForEachSetBit(func(i int) bool {
+			got = append(got, i)
+			return true
+		})
+		if !equalInts(got, expected) {
+			t.Fatalf("%#x.ForEachSetBit() should be %v, got %v", x, expected, got)
+		}
+
+		// stop early
+		got = got[:0]
+		x.ForEachSetBit(func(i int) bool {
+			got = append(got, i)
+			return len(got) < 3
+		})
+		if len(expected) > 3 {
+			expected = expected[:3]
+		}
+		if !equalInts(got, expected) {
+			t.Fatalf("%#x.ForEachSetBit() stopped should be %v, got %v", x, expected, got)
+		}
+
+		expected = setBits(x)
+		for n := -1; n <= len(expected); n++ {
+			e := -1
+			if n >= 0 && n < len(expected) {
+				e = expected[n]
+			}
+			if got := x.NthSetBit(n); got != e {
+				t.Fatalf("%#x.NthSetBit(%d) should be %d, got %d", x, n, e, got)
+			}
+		}
+
+		rank := 0
+		for i := uint(0); i <= 140; i++ {
+			if got := x.Rank(i); got != rank {
+				t.Fatalf("%#x.Rank(%d) should be %d, got %d", x, i, rank, got)
+			}
+			rank += int(x.Bit(i))
+		}
+	}
+}
+
+// TestBitset unit tests for Bitset128 type.
+func TestBitset(t *testing.T) {
+	s := NewBitset128(1, 5, 127, -1, 128, 5)
+	if got, expected := s.String(), "{1, 5, 127}"; got != expected {
+		t.Fatalf("String() should be %q, got %q", expected, got)
+	}
+	if got, expected := (Bitset128{}).String(), "{}"; got != expected {
+		t.Fatalf("String() should be %q, got %q", expected, got)
+	}
+	if s.Len() != 3 || s.IsEmpty() || !(Bitset128{}).IsEmpty() {
+		t.Fatalf("%v.Len() should be 3, got %d", s, s.Len())
+	}
+	for i := -2; i < 130; i++ {
+		expected := i == 1 || i == 5 || i == 127
+		if got := s.Contains(i); got != expected {
+			t.Fatalf("%v.Contains(%d) should be %t, got %t", s, i, expected, got)
+		}
+	}
+	if got := s.Select(2); got != 127 {
+		t.Fatalf("%v.Select(2) should be 127, got %d", s, got)
+	}
+	if got := s.Rank(-1); got != 0 {
+		t.Fatalf("%v.Rank(-1) should be 0, got %d", s, got)
+	}
+	if got := s.Rank(6); got != 2 {
+		t.Fatalf("%v.Rank(6) should be 2, got %d", s, got)
+	}
+
+	tt := NewBitset128(5, 64, 100)
+	check := func(name string, got Bitset128, expected string) {
+		t.Helper()
+		if got.String() != expected {
+			t.Fatalf("%s should be %s, got %v", name, expected, got)
+		}
+	}
+	check("Union", s.Union(tt), "{1, 5, 64, 100, 127}")
+	check("Intersect", s.Intersect(tt), "{5}")
+	check("Difference", s.Difference(tt), "{1, 127}")
+	check("Add", s.Add(64).Add(-1).Add(200), "{1, 5, 64, 127}")
+	check("Remove", s.Remove(5).Remove(-1).Remove(200), "{1, 127}")
+
+	if !s.IsSubset(s) || !s.Intersect(tt).IsSubset(tt) || s.IsSubset(tt) || !(Bitset128{}).IsSubset(tt) {
+		t.Fatalf("IsSubset failed")
+	}
+}
+
+// equalInts reports whether two slices are equal.
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,352 @@
+package uint128
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strconv"
+)
+
+// Base58 uses the Bitcoin alphabet, i.e. all alphanumeric characters
+// except "0", "O", "I" and "l" which look similar.
+const base58Digits = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Crockford Base32 alphabet, the same one is used by ULID.
+// Letters "I", "L", "O" and "U" are excluded.
+const crockfordDigits = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Crockford Base32 check symbols, the value is modulo 37.
+const crockfordCheckDigits = crockfordDigits + "*~$=U"
+
+const (
+	// Base58Len is the number of Base58 digits enough to represent any 128-bit value.
+	Base58Len = 22
+
+	// CrockfordLen is the number of Crockford Base32 digits enough to represent any 128-bit value.
+	CrockfordLen = 26
+)
+
+// ErrChecksum indicates that checksum of the parsed value does not match.
+var ErrChecksum = errors.New("checksum mismatch")
+
+// Special values of digit lookup tables.
+const (
+	invalidDigit = 0xFF // character is not a digit
+	ignoredDigit = 0xFE // character is ignored, e.g. hyphen
+)
+
+// base58Values is the reverse lookup table for Base58 digits.
+var base58Values = func() (out [256]byte) {
+	for i := range out {
+		out[i] = invalidDigit
+	}
+	for i := 0; i < len(base58Digits); i++ {
+		out[base58Digits[i]] = byte(i)
+	}
+	return
+}()
+
+// crockfordValues is the reverse lookup table for Crockford Base32 digits.
+// Decoding is case-insensitive, "I" and "L" are treated as "1",
+// "O" is treated as "0" and hyphens are ignored.
+var crockfordValues = func() (out [256]byte) {
+	for i := range out {
+		out[i] = invalidDigit
+	}
+	for i := 0; i < len(crockfordDigits); i++ {
+		c := crockfordDigits[i]
+		out[c] = byte(i)
+		out[lower(c)] = byte(i)
+	}
+	for _, c := range []byte("IiLl") {
+		out[c] = 1
+	}
+	for _, c := range []byte("Oo") {
+		out[c] = 0
+	}
+	out['-'] = ignoredDigit
+	return
+}()
+
+// crockfordCheckValues is the reverse lookup table for Crockford Base32 check symbols.
+var crockfordCheckValues = func() (out [256]byte) {
+	out = crockfordValues
+	out['-'] = invalidDigit
+	for i := len(crockfordDigits); i < len(crockfordCheckDigits); i++ {
+		c := crockfordCheckDigits[i]
+		out[c] = byte(i)
+		out[lower(c)] = byte(i)
+	}
+	return
+}()
+
+// ChecksumFunc calculates checksum of the payload.
+// The returned bytes are appended to the payload before encoding.
+type ChecksumFunc func(payload []byte) []byte
+
+// DoubleSHA256 is the Base58Check checksum used by Bitcoin:
+// the first 4 bytes of SHA256(SHA256(payload)).
+func DoubleSHA256(payload []byte) []byte {
+	h1 := sha256.Sum256(payload)
+	h2 := sha256.Sum256(h1[:])
+	return h2[:4]
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Base58 ////////////////////////////////////////////////////////////////////
+
+// FormatBase58 returns the Base58 representation of 128-bit value
+// using the Bitcoin alphabet. Zero is represented as "1".
+func FormatBase58(u Uint128) string {
+	var buf [Base58Len]byte
+	return string(AppendBase58(buf[:0], u))
+}
+
+// AppendBase58 appends the Base58 form of 128-bit value,
+// as generated by FormatBase58, to dst and returns the extended buffer.
+func AppendBase58(dst []byte, u Uint128) []byte {
+	return appendDigits(dst, u, base58Digits, 0)
+}
+
+// FormatBase58Fixed returns the Base58 representation of 128-bit value
+// zero-padded (with "1") to exactly Base58Len characters.
+func FormatBase58Fixed(u Uint128) string {
+	var buf [Base58Len]byte
+	return string(AppendBase58Fixed(buf[:0], u))
+}
+
+// AppendBase58Fixed appends the fixed-width Base58 form of 128-bit value,
+// as generated by FormatBase58Fixed, to dst and returns the extended buffer.
+func AppendBase58Fixed(dst []byte, u Uint128) []byte {
+	return appendDigits(dst, u, base58Digits, Base58Len)
+}
+
+// ParseBase58 parses Base58 string as a 128-bit value.
+// Both fixed-width and minimal forms are accepted.
+// The errors are of *strconv.NumError type, see ParseBase for details.
+func ParseBase58(s string) (Uint128, error) {
+	return parseDigits("ParseBase58", s, base58Digits, &base58Values)
+}
+
+// FormatBase58Check returns the Base58Check representation of 128-bit value.
+// The 16-byte big-endian payload is extended with checksum bytes
+// and the whole byte sequence is encoded in Base58, each leading zero byte
+// is encoded as "1". Use DoubleSHA256 for Bitcoin compatible checksum.
+func FormatBase58Check(u Uint128, sum ChecksumFunc) string {
+	return string(AppendBase58Check(nil, u, sum))
+}
+
+// AppendBase58Check appends the Base58Check form of 128-bit value,
+// as generated by FormatBase58Check, to dst and returns the extended buffer.
+func AppendBase58Check(dst []byte, u Uint128, sum ChecksumFunc) []byte {
+	payload := make([]byte, 16, 16+8)
+	StoreBigEndian(payload, u)
+	payload = append(payload, sum(payload)...)
+	return appendBase58Bytes(dst, payload)
+}
+
+// ParseBase58Check parses Base58Check string as a 128-bit value.
+// The checksum is verified using the same sum function as used for encoding.
+// On checksum mismatch the error wraps ErrChecksum.
+func ParseBase58Check(s string, sum ChecksumFunc) (Uint128, error) {
+	const fnParseBase58Check = "ParseBase58Check"
+
+	data, ok := decodeBase58Bytes(s)
+	if !ok || len(data) < 16 {
+		return Zero(), syntaxError(fnParseBase58Check, s)
+	}
+
+	payload, check := data[:16], data[16:]
+	if expected := sum(payload); string(expected) != string(check) {
+		return Zero(), checksumError(fnParseBase58Check, s)
+	}
+
+	return LoadBigEndian(payload), nil
+}
+
+// appendBase58Bytes appends Base58 encoded byte sequence to dst.
+func appendBase58Bytes(dst []byte, data []byte) []byte {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	// log(256)/log(58) ~ 1.37
+	buf := make([]byte, 0, (len(data)-zeros)*138/100+1)
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		for j := range buf {
+			carry += int(buf[j]) << 8
+			buf[j] = byte(carry % 58)
+			carry /= 58
+		}
+		for ; carry != 0; carry /= 58 {
+			buf = append(buf, byte(carry%58))
+		}
+	}
+
+	for i := 0; i < zeros; i++ {
+		dst = append(dst, base58Digits[0])
+	}
+	for i := len(buf) - 1; i >= 0; i-- {
+		dst = append(dst, base58Digits[buf[i]])
+	}
+	return dst
+}
+
+// decodeBase58Bytes decodes Base58 encoded byte sequence.
+func decodeBase58Bytes(s string) ([]byte, bool) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Digits[0] {
+		zeros++
+	}
+
+	// log(58)/log(256) ~ 0.74
+	buf := make([]byte, 0, (len(s)-zeros)*74/100+1)
+	for i := zeros; i < len(s); i++ {
+		d := base58Values[s[i]]
+		if d == invalidDigit {
+			return nil, false
+		}
+		carry := int(d)
+		for j := range buf {
+			carry += int(buf[j]) * 58
+			buf[j] = byte(carry)
+			carry >>= 8
+		}
+		for ; carry != 0; carry >>= 8 {
+			buf = append(buf, byte(carry))
+		}
+	}
+
+	out := make([]byte, zeros, zeros+len(buf))
+	for i := len(buf) - 1; i >= 0; i-- {
+		out = append(out, buf[i])
+	}
+	return out, true
+}
+
+///////////////////////////////////////////////////////////////////////////////
+/// Crockford Base32 //////////////////////////////////////////////////////////
+
+// FormatCrockford returns the Crockford Base32 representation of 128-bit value.
+// Upper-case letters are used.
+func FormatCrockford(u Uint128) string {
+	var buf [CrockfordLen]byte
+	return string(AppendCrockford(buf[:0], u))
+}
+
+// AppendCrockford appends the Crockford Base32 form of 128-bit value,
+// as generated by FormatCrockford, to dst and returns the extended buffer.
+func AppendCrockford(dst []byte, u Uint128) []byte {
+	return appendDigits(dst, u, crockfordDigits, 0)
+}
+
+// FormatCrockfordFixed returns the Crockford Base32 representation of 128-bit value
+// zero-padded to exactly CrockfordLen characters, the same form as used by ULID.
+func FormatCrockfordFixed(u Uint128) string {
+	var buf [CrockfordLen]byte
+	return string(AppendCrockfordFixed(buf[:0], u))
+}
+
+// AppendCrockfordFixed appends the fixed-width Crockford Base32 form of 128-bit value,
+// as generated by FormatCrockfordFixed, to dst and returns the extended buffer.
+func AppendCrockfordFixed(dst []byte, u Uint128) []byte {
+	return appendDigits(dst, u, crockfordDigits, CrockfordLen)
+}
+
+// ParseCrockford parses Crockford Base32 string as a 128-bit value.
+// Decoding is case-insensitive and tolerant to ambiguous characters:
+// "I" and "L" are decoded as "1", "O" is decoded as "0". Hyphens are ignored.
+// The errors are of *strconv.NumError type, see ParseBase for details.
+func ParseCrockford(s string) (Uint128, error) {
+	return parseDigits("ParseCrockford", s, crockfordDigits, &crockfordValues)
+}
+
+// FormatCrockfordCheck returns the Crockford Base32 representation of 128-bit value
+// followed by the check symbol (value modulo 37).
+func FormatCrockfordCheck(u Uint128) string {
+	var buf [CrockfordLen + 1]byte
+	return string(AppendCrockfordCheck(buf[:0], u))
+}
+
+// AppendCrockfordCheck appends the Crockford Base32 form of 128-bit value with the
+// check symbol, as generated by FormatCrockfordCheck, to dst and returns the extended buffer.
+func AppendCrockfordCheck(dst []byte, u Uint128) []byte {
+	dst = AppendCrockford(dst, u)
+	return append(dst, crockfordCheckDigits[u.Mod64(37)])
+}
+
+// ParseCrockfordCheck parses Crockford Base32 string with the trailing check symbol.
+// Invalid check symbol is reported as ErrSyntax,
+// on check symbol mismatch the error wraps ErrChecksum.
+func ParseCrockfordCheck(s string) (Uint128, error) {
+	const fnParseCrockfordCheck = "ParseCrockfordCheck"
+
+	if len(s) < 2 {
+		return Zero(), syntaxError(fnParseCrockfordCheck, s)
+	}
+
+	u, err := parseDigits(fnParseCrockfordCheck, s[:len(s)-1], crockfordDigits, &crockfordValues)
+	if err != nil {
+		return u, err
+	}
+
+	check := crockfordCheckValues[s[len(s)-1]]
+	if check == invalidDigit {
+		return Zero(), syntaxError(fnParseCrockfordCheck, s)
+	}
+	if uint64(check) != u.Mod64(37) {
+		return Zero(), checksumError(fnParseCrockfordCheck, s)
+	}
+
+	return u, nil
+}
+
+// parseDigits parses string of digits using the alphabet and its reverse lookup table.
+func parseDigits(fn string, s string, alphabet string, values *[256]byte) (Uint128, error) {
+	base := uint64(len(alphabet))
+	bb := bigBases[base]
+	var u Uint128
+	var acc uint64 // accumulated chunk
+	var n int      // number of digits in chunk
+	var count int  // total number of digits
+	for i := 0; i < len(s); i++ {
+		d := values[s[i]]
+		switch d {
+		case ignoredDigit:
+			continue
+		case invalidDigit:
+			return Zero(), syntaxError(fn, s)
+		}
+
+		count++
+		acc = acc*base + uint64(d)
+		if n++; n == bb.ndigits {
+			var carry uint64
+			if u, carry = mulAdd64(u, bb.b, acc); carry != 0 {
+				return Max(), rangeError(fn, s)
+			}
+			acc, n = 0, 0
+		}
+	}
+	if count == 0 {
+		return Zero(), syntaxError(fn, s)
+	}
+	if n != 0 {
+		m := uint64(1)
+		for ; n != 0; n-- {
+			m *= base
+		}
+		var carry uint64
+		if u, carry = mulAdd64(u, m, acc); carry != 0 {
+			return Max(), rangeError(fn, s)
+		}
+	}
+
+	return u, nil
+}
+
+// checksumError creates *strconv.NumError for checksum mismatch.
+func checksumError(fn, str string) *strconv.NumError {
+	return &strconv.NumError{Func: fn, Num: str, Err: ErrChecksum}
+}
@@ -0,0 +1,155 @@
+package uint128
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// TestBase58 unit tests for Base58 and Base58Check codecs.
+func TestBase58(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		if expected, got := "1", FormatBase58(Zero()); got != expected {
+			t.Fatalf("FormatBase58(0) should be %q, got %q", expected, got)
+		}
+		if expected, got := "YcVfxkQb6JRzqk5kF2tNLv", FormatBase58(Max()); got != expected {
+			t.Fatalf("FormatBase58(Max) should be %q, got %q", expected, got)
+		}
+		if expected, got := "11111111111111111114fr", FormatBase58Fixed(From64(12345)); got != expected {
+			t.Fatalf("FormatBase58Fixed(12345) should be %q, got %q", expected, got)
+		}
+		if expected, got := "11111111111111R1pazgts", FormatBase58Check(From64(12345), DoubleSHA256); got != expected {
+			t.Fatalf("FormatBase58Check(12345) should be %q, got %q", expected, got)
+		}
+		if expected, got := "4ZrjxJnU1LA5xSyrWMNuXTozYEvA", FormatBase58Check(Max(), DoubleSHA256); got != expected {
+			t.Fatalf("FormatBase58Check(Max) should be %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("bad", func(t *testing.T) {
+		if _, err := ParseBase58(""); !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("ParseBase58(%q) unexpected error: %v", "", err)
+		}
+		if _, err := ParseBase58("10"); !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("ParseBase58(%q) unexpected error: %v", "10", err)
+		}
+		if u, err := ParseBase58("YcVfxkQb6JRzqk5kF2tNLw"); !errors.Is(err, strconv.ErrRange) || !u.Equals(Max()) {
+			t.Fatalf("ParseBase58(%q) unexpected result: %v, %v", "YcVfxkQb6JRzqk5kF2tNLw", u, err)
+		}
+		if _, err := ParseBase58Check("11111111111111R1pazgtt", DoubleSHA256); !errors.Is(err, ErrChecksum) {
+			t.Fatalf("ParseBase58Check(%q) unexpected error: %v", "11111111111111R1pazgtt", err)
+		}
+		if _, err := ParseBase58Check("11111111111111R1pazgt0", DoubleSHA256); !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("ParseBase58Check(%q) unexpected error: %v", "11111111111111R1pazgt0", err)
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		// a custom checksum function
+		sum := func(payload []byte) []byte {
+			h := sha256.Sum256(payload)
+			return h[:2]
+		}
+
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			if got, err := ParseBase58(FormatBase58(x)); err != nil || !got.Equals(x) {
+				t.Fatalf("ParseBase58 is not the inverse of FormatBase58 for %#x, got %#x (%v)", x, got, err)
+			}
+
+			s := FormatBase58Fixed(x)
+			if len(s) != Base58Len {
+				t.Fatalf("FormatBase58Fixed(%#x) length mismatch, got %q", x, s)
+			}
+			if got, err := ParseBase58(s); err != nil || !got.Equals(x) {
+				t.Fatalf("ParseBase58 is not the inverse of FormatBase58Fixed for %#x, got %#x (%v)", x, got, err)
+			}
+
+			if got, err := ParseBase58Check(FormatBase58Check(x, DoubleSHA256), DoubleSHA256); err != nil || !got.Equals(x) {
+				t.Fatalf("ParseBase58Check is not the inverse of FormatBase58Check for %#x, got %#x (%v)", x, got, err)
+			}
+			if got, err := ParseBase58Check(FormatBase58Check(x, sum), sum); err != nil || !got.Equals(x) {
+				t.Fatalf("ParseBase58Check is not the inverse of FormatBase58Check for %#x, got %#x (%v)", x, got, err)
+			}
+		}
+	})
+}
+
+// TestCrockford unit tests for Crockford Base32 codec.
+func TestCrockford(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		if expected, got := "0", FormatCrockford(Zero()); got != expected {
+			t.Fatalf("FormatCrockford(0) should be %q, got %q", expected, got)
+		}
+		if expected, got := "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", FormatCrockford(Max()); got != expected {
+			t.Fatalf("FormatCrockford(Max) should be %q, got %q", expected, got)
+		}
+
+		// ULID example
+		ulid := Uint128{Hi: 0x01563e3ab5d3d676, Lo: 0x4c61efb99302bd5b}
+		if expected, got := "01ARZ3NDEKTSV4RRFFQ69G5FAV", FormatCrockfordFixed(ulid); got != expected {
+			t.Fatalf("FormatCrockfordFixed(%#x) should be %q, got %q", ulid, expected, got)
+		}
+		for _, s := range []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "01arz3ndektsv4rrffq69g5fav", "OLARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEK-TSV4RRFFQ6-9G5FAV"} {
+			if got, err := ParseCrockford(s); err != nil || !got.Equals(ulid) {
+				t.Fatalf("ParseCrockford(%q) should be %#x, got %#x (%v)", s, ulid, got, err)
+			}
+		}
+
+		if expected, got := "C1SR", FormatCrockfordCheck(From64(12345)); got != expected {
+			t.Fatalf("FormatCrockfordCheck(12345) should be %q, got %q", expected, got)
+		}
+		if expected, got := "7ZZZZZZZZZZZZZZZZZZZZZZZZZ*", FormatCrockfordCheck(Max()); got != expected {
+			t.Fatalf("FormatCrockfordCheck(Max) should be %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("bad", func(t *testing.T) {
+		for _, s := range []string{"", "-", "U", "01AR#Z"} {
+			if _, err := ParseCrockford(s); !errors.Is(err, strconv.ErrSyntax) {
+				t.Fatalf("ParseCrockford(%q) unexpected error: %v", s, err)
+			}
+		}
+		if u, err := ParseCrockford("80000000000000000000000000"); !errors.Is(err, strconv.ErrRange) || !u.Equals(Max()) {
+			t.Fatalf("ParseCrockford(%q) unexpected result: %v, %v", "80000000000000000000000000", u, err)
+		}
+		if _, err := ParseCrockfordCheck("C1SS"); !errors.Is(err, ErrChecksum) {
+			t.Fatalf("ParseCrockfordCheck(%q) unexpected error: %v", "C1SS", err)
+		}
+		for _, s := range []string{"C1-", "C1!", "C1#", "C1\xff"} {
+			if _, err := ParseCrockfordCheck(s); !errors.Is(err, strconv.ErrSyntax) {
+				t.Fatalf("ParseCrockfordCheck(%q) unexpected error: %v", s, err)
+			}
+		}
+		if _, err := ParseCrockfordCheck("C"); !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("ParseCrockfordCheck(%q) unexpected error: %v", "C", err)
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			if expected, got := x.Big().Text(32), FormatCrockford(x); len(expected) != len(got) {
+				t.Fatalf("FormatCrockford(%#x) length mismatch, got %q", x, got)
+			}
+			if got, err := ParseCrockford(FormatCrockford(x)); err != nil || !got.Equals(x) {
+				t.Fatalf("ParseCrockford is not the inverse of FormatCrockford for %#x, got %#x (%v)", x, got, err)
+			}
+
+			s := FormatCrockfordFixed(x)
+			if len(s) != CrockfordLen {
+				t.Fatalf("FormatCrockfordFixed(%#x) length mismatch, got %q", x, s)
+			}
+			if got, err := ParseCrockford(s); err != nil || !got.Equals(x) {
+				t.Fatalf("ParseCrockford is not the inverse of FormatCrockfordFixed for %#x, got %#x (%v)", x, got, err)
+			}
+
+			if got, err := ParseCrockfordCheck(FormatCrockfordCheck(x)); err != nil || !got.Equals(x) {
+				t.Fatalf("ParseCrockfordCheck is not the inverse of FormatCrockfordCheck for %#x, got %#x (%v)", x, got, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,288 @@
+package uint128
+
+import (
+	"errors"
+	"sort"
+)
+
+// Interleave2 returns the 2D Morton code (Z-order) of (x, y):
+// bit i of x goes to bit 2i, bit i of y goes to bit 2i+1.
+func Interleave2(x, y uint64) Uint128 {
+	return Uint128{
+		Lo: spread32(x) | spread32(y)<<1,
+		Hi: spread32(x>>32) | spread32(y>>32)<<1,
+	}
+}
+
+// Deinterleave2 is the inverse of Interleave2.
+func Deinterleave2(u Uint128) (x, y uint64) {
+	x = compact32(u.Lo) | compact32(u.Hi)<<32
+	y = compact32(u.Lo>>1) | compact32(u.Hi>>1)<<32
+	return
+}
+
+// spread32 spreads the lower 32 bits of x to even bits.
+func spread32(x uint64) uint64 {
+	x &= 0x00000000FFFFFFFF
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// compact32 is the inverse of spread32: it packs even bits of x.
+func compact32(x uint64) uint64 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return x
+}
+
+// KeyRange is an inclusive range of keys.
+type KeyRange struct {
+	Min, Max Uint128
+}
+
+// Contains reports whether the key is in the range.
+func (r KeyRange) Contains(key Uint128) bool {
+	return r.Min.Cmp(key) <= 0 && key.Cmp(r.Max) <= 0
+}
+
+// MortonRanges2 splits the 2D bounding box [min, max] (inclusive) into
+// sorted non-overlapping ranges of Interleave2 keys.
+// The box is subdivided as a quadtree while the number of ranges does not
+// exceed maxRanges, so the ranges may cover some points outside the box.
+// DefaultMaxRanges is used if maxRanges <= 0. The result is nil if min > max.
+func MortonRanges2(min, max [2]uint64, maxRanges int) []KeyRange {
+	var lo, hi [4]uint64
+	copy(lo[:], min[:])
+	copy(hi[:], max[:])
+	return mortonRanges(2, &lo, &hi, maxRanges, func(p *[4]uint64) Uint128 {
+		return Interleave2(p[0], p[1])
+	})
+}
+
+// DefaultMaxRanges is the limit of MortonRanges* result used if maxRanges <= 0.
+// Unbounded subdivision of a box with unaligned edges goes down to single
+// points, so the number of ranges would grow with the box perimeter.
+const DefaultMaxRanges = 1024
+
+// mortonNode is a quadtree node: a cube of 2^shift size at lo corner.
+type mortonNode struct {
+	lo    [4]uint64
+	shift uint
+}
+
+// mortonRanges splits the box [min, max] of given dimensions
+// into key ranges, see MortonRanges2.
+func mortonRanges(dims int, min, max *[4]uint64, maxRanges int, key func(*[4]uint64) Uint128) []KeyRange {
+	for i := 0; i < dims; i++ {
+		if min[i] > max[i] {
+			return nil
+		}
+	}
+	if maxRanges <= 0 {
+		maxRanges = DefaultMaxRanges
+	}
+
+	// nodeRange returns key range of the node
+	nodeRange := func(n *mortonNode) KeyRange {
+		ext := ^uint64(0) >> (64 - n.shift) // zero if shift == 0
+		hi := n.lo
+		for i := 0; i < dims; i++ {
+			hi[i] |= ext
+		}
+		return KeyRange{Min: key(&n.lo), Max: key(&hi)}
+	}
+
+	var out []KeyRange
+	level := []mortonNode{{shift: 64}}
+	for len(level) != 0 {
+		var full []KeyRange
+		var next []mortonNode
+		for k := range level {
+			n := &level[k]
+			s := n.shift - 1
+			for c := 0; c < 1<<uint(dims); c++ {
+				child := mortonNode{lo: n.lo, shift: s}
+				inside, outside := true, false
+				for i := 0; i < dims; i++ {
+					child.lo[i] |= uint64(c>>uint(i)&1) << s
+					l, h := child.lo[i], child.lo[i]|^uint64(0)>>(64-s)
+					outside = outside || h < min[i] || l > max[i]
+					inside = inside && min[i] <= l && h <= max[i]
+				}
+				if outside {
+					continue
+				}
+				if inside {
+					full = append(full, nodeRange(&child))
+				} else {
+					next = append(next, child)
+				}
+			}
+		}
+
+		if len(out)+len(full)+len(next) > maxRanges {
+			// too many ranges, use the current level as is
+			for k := range level {
+				out = append(out, nodeRange(&level[k]))
+			}
+			break
+		}
+		out = append(out, full...)
+		level = next
+	}
+
+	return mergeRanges(out)
+}
+
+// mergeRanges sorts ranges and merges adjacent and overlapping ones.
+func mergeRanges(r []KeyRange) []KeyRange {
+	if len(r) == 0 {
+		return r
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].Min.Cmp(r[j].Min) < 0
+	})
+
+	out := r[:1]
+	for _, c := range r[1:] {
+		last := &out[len(out)-1]
+		if !last.Max.Equals(Max()) && c.Min.Cmp(last.Max.Add64(1)) > 0 {
+			out = append(out, c)
+		} else if c.Max.Cmp(last.Max) > 0 {
+			last.Max = c.Max
+		}
+	}
+	return out
+}
+
+// errHilbertOrder is panic value for too many bits of Hilbert index.
+var errHilbertOrder = errors.New("hilbert: index does not fit 128 bits")
+
+// HilbertEncode returns the Hilbert curve index of the point with
+// len(coords) coordinates of order bits each. Coordinates are truncated
+// to order bits. It panics if len(coords)*order exceeds 128 or order exceeds 64.
+// This is J. Skilling's algorithm, "Programming the Hilbert curve", 2004.
+func HilbertEncode(coords []uint64, order uint) Uint128 {
+	n := uint(len(coords))
+	if order > 64 || n*order > 128 {
+		panic(errHilbertOrder)
+	}
+	if n == 0 || order == 0 {
+		return Zero()
+	}
+
+	var buf [16]uint64
+	x := buf[:0]
+	if n > uint(len(buf)) {
+		x = make([]uint64, 0, n)
+	}
+	ext := ^uint64(0) >> (64 - order)
+	for _, c := range coords {
+		x = append(x, c&ext)
+	}
+
+	// inverse undo
+	for k := order - 1; k > 0; k-- {
+		p := uint64(1)<<k - 1
+		x0 := x[0] ^ p&-(x[0]>>k&1) // i == 0
+		for i := 1; i < len(x); i++ {
+			x0, x[i] = hilbertStep(x0, x[i], k, p)
+		}
+		x[0] = x0
+	}
+
+	// gray encode
+	for i := 1; i < len(x); i++ {
+		x[i] ^= x[i-1]
+	}
+	var t uint64
+	for k := order - 1; k > 0; k-- {
+		t ^= (uint64(1)<<k - 1) & -(x[n-1] >> k & 1)
+	}
+
+	// transposed to index: the most significant bit of x[0] goes first
+	var u Uint128
+	m := hilbertMask(n, order)
+	for i := range x {
+		u = u.Or(From64(x[i] ^ t).DepositBits(m.Lsh(n - 1 - uint(i))))
+	}
+	return u
+}
+
+// HilbertDecode is the inverse of HilbertEncode: it stores len(coords)
+// coordinates of order bits each of the point with given Hilbert index.
+// It panics if len(coords)*order exceeds 128 or order exceeds 64.
+func HilbertDecode(index Uint128, coords []uint64, order uint) {
+	n := uint(len(coords))
+	if order > 64 || n*order > 128 {
+		panic(errHilbertOrder)
+	}
+	if n == 0 || order == 0 {
+		for i := range coords {
+			coords[i] = 0
+		}
+		return
+	}
+
+	// index to transposed
+	x := coords
+	m := hilbertMask(n, order)
+	for i := range x {
+		x[i] = index.ExtractBits(m.Lsh(n - 1 - uint(i))).Lo
+	}
+
+	// gray decode
+	t := x[n-1] >> 1
+	for i := n - 1; i > 0; i-- {
+		x[i] ^= x[i-1]
+	}
+	x[0] ^= t
+
+	// undo excess work
+	for k := uint(1); k < order; k++ {
+		p := uint64(1)<<k - 1
+		x0 := x[0]
+		for i := n - 1; i > 0; i-- {
+			x0, x[i] = hilbertStep(x0, x[i], k, p)
+		}
+		x[0] = x0 ^ p&-(x0>>k&1) // i == 0
+	}
+}
+
+// hilbertStep inverts the lower bits p of x0 if the bit k of xi is set,
+// otherwise exchanges the lower bits p of x0 and xi. It is branch-free.
+func hilbertStep(x0, xi uint64, k uint, p uint64) (uint64, uint64) {
+	b := -(xi >> k & 1)     // all ones if the bit is set
+	t := (x0 ^ xi) & p &^ b // exchange
+	return x0 ^ p&b ^ t, xi ^ t
+}
+
+// hilbertMask returns the mask of every n-th bit, order bits total.
+func hilbertMask(n, order uint) Uint128 {
+	m := One()
+	for k := uint(1); k < order; k *= 2 {
+		m = m.Or(m.Lsh(k * n))
+	}
+	return m.And(Mask(n * order))
+}
+
+// ToGray returns the reflected binary Gray code of u, i.e. u ^ (u >> 1).
+func (u Uint128) ToGray() Uint128 {
+	return u.Xor(u.Rsh(1))
+}
+
+// FromGray is the inverse of ToGray.
+func (u Uint128) FromGray() Uint128 {
+	for s := uint(1); s < 128; s <<= 1 {
+		u = u.Xor(u.Rsh(s))
+	}
+	return u
+}
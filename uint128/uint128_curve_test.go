@@ -0,0 +1,169 @@
+package uint128
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestMorton unit tests for Morton codes.
+func TestMorton(t *testing.T) {
+	values := make(chan Uint128)
+	go generate128s(1000, values)
+	for u := range values {
+		x, y := u.Lo, u.Hi
+
+		var expected Uint128 // naive
+		for i := uint(0); i < 64; i++ {
+			expected = expected.SetBit(2*i, uint(x>>i)&1)
+			expected = expected.SetBit(2*i+1, uint(y>>i)&1)
+		}
+		if got := Interleave2(x, y); got != expected {
+			t.Fatalf("Interleave2(%#x, %#x) should be %#x, got %#x", x, y, expected, got)
+		}
+		if gx, gy := Deinterleave2(expected); gx != x || gy != y {
+			t.Fatalf("Deinterleave2(%#x) should be (%#x, %#x), got (%#x, %#x)", expected, x, y, gx, gy)
+		}
+	}
+}
+
+// TestMortonRanges unit tests for MortonRanges2.
+func TestMortonRanges(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 100; k++ {
+		var min, max [2]uint64
+		for i := range min {
+			min[i] = uint64(rnd.Intn(60))
+			max[i] = min[i] + uint64(rnd.Intn(10))
+		}
+		for _, maxRanges := range []int{0, 1, 3, 10} {
+			ranges := MortonRanges2(min, max, maxRanges)
+			if maxRanges > 0 && len(ranges) > maxRanges {
+				t.Fatalf("MortonRanges2(%v, %v, %d) has too many ranges: %d", min, max, maxRanges, len(ranges))
+			}
+			for i := 1; i < len(ranges); i++ {
+				if ranges[i-1].Max.Add64(1).Cmp(ranges[i].Min) >= 0 {
+					t.Fatalf("MortonRanges2(%v, %v, %d) ranges are not sorted or merged: %v", min, max, maxRanges, ranges)
+				}
+			}
+
+			// all points of the box are covered,
+			// and no points outside if there is no limit
+			for x := uint64(0); x < 80; x++ {
+				for y := uint64(0); y < 80; y++ {
+					key := Interleave2(x, y)
+					covered := false
+					for _, r := range ranges {
+						covered = covered || r.Contains(key)
+					}
+					inside := min[0] <= x && x <= max[0] && min[1] <= y && y <= max[1]
+					if inside && !covered {
+						t.Fatalf("MortonRanges2(%v, %v, %d) does not cover (%d, %d)", min, max, maxRanges, x, y)
+					}
+					if !inside && covered && maxRanges == 0 {
+						t.Fatalf("MortonRanges2(%v, %v, %d) covers (%d, %d)", min, max, maxRanges, x, y)
+					}
+				}
+			}
+		}
+	}
+
+	// full and empty
+	if r := MortonRanges2([2]uint64{}, [2]uint64{^uint64(0), ^uint64(0)}, 0); len(r) != 1 || !r[0].Min.IsZero() || r[0].Max != Max() {
+		t.Fatalf("MortonRanges2(full) should be single full range, got %v", r)
+	}
+	if r := MortonRanges2([2]uint64{1, 1}, [2]uint64{0, 5}, 0); r != nil {
+		t.Fatalf("MortonRanges2(empty) should be nil, got %v", r)
+	}
+
+	// unaligned edges are limited by default
+	min, max := [2]uint64{1, 3}, [2]uint64{^uint64(0) - 5, ^uint64(0) - 1}
+	r := MortonRanges2(min, max, 0)
+	if len(r) == 0 || len(r) > DefaultMaxRanges {
+		t.Fatalf("MortonRanges2(unaligned) should have at most %d ranges, got %d", DefaultMaxRanges, len(r))
+	}
+	for _, p := range [][2]uint64{min, max, {min[0], max[1]}, {max[0], min[1]}} {
+		key := Interleave2(p[0], p[1])
+		covered := false
+		for _, kr := range r {
+			covered = covered || kr.Contains(key)
+		}
+		if !covered {
+			t.Fatalf("MortonRanges2(unaligned) does not cover %v", p)
+		}
+	}
+}
+
+// TestHilbert unit tests for Hilbert curve.
+func TestHilbert(t *testing.T) {
+	t.Run("adjacency", func(t *testing.T) {
+		for n := 1; n <= 4; n++ {
+			for order := uint(1); uint(n)*order <= 12; order++ {
+				coords := make([]uint64, n)
+				prev := make([]uint64, n)
+				for i := uint64(0); i < 1<<(uint(n)*order); i++ {
+					HilbertDecode(From64(i), coords, order)
+					if got := HilbertEncode(coords, order); !got.Equals64(i) {
+						t.Fatalf("HilbertEncode(%v, %d) should be %d, got %d", coords, order, i, got)
+					}
+					dist := uint64(0)
+					for k := range coords {
+						if coords[k] >= 1<<order {
+							t.Fatalf("HilbertDecode(%d, %d) is out of range: %v", i, order, coords)
+						}
+						if coords[k] > prev[k] {
+							dist += coords[k] - prev[k]
+						} else {
+							dist += prev[k] - coords[k]
+						}
+					}
+					if i != 0 && dist != 1 {
+						t.Fatalf("HilbertDecode(%d, %d)=%v is not adjacent to %v", i, order, coords, prev)
+					}
+					copy(prev, coords)
+				}
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for u := range values {
+			for _, c := range []struct {
+				n     int
+				order uint
+			}{{1, 64}, {2, 64}, {3, 42}, {4, 32}, {128, 1}} {
+				coords := make([]uint64, c.n)
+				index := u.And(Mask(uint(c.n) * c.order))
+				HilbertDecode(index, coords, c.order)
+				if got := HilbertEncode(coords, c.order); got != index {
+					t.Fatalf("HilbertEncode(HilbertDecode(%#x)) with %d/%d should be the same, got %#x", index, c.n, c.order, got)
+				}
+			}
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != errHilbertOrder {
+				t.Fatalf("HilbertEncode should panic with %v, got %v", errHilbertOrder, r)
+			}
+		}()
+		HilbertEncode(make([]uint64, 3), 43)
+	})
+}
+
+// TestGray unit tests for Gray codes.
+func TestGray(t *testing.T) {
+	values := make(chan Uint128)
+	go generate128s(1000, values)
+	for u := range values {
+		g := u.ToGray()
+		if got := g.FromGray(); got != u {
+			t.Fatalf("%#x.ToGray().FromGray() should be %#x, got %#x", u, u, got)
+		}
+		if d := u.Add64(1).ToGray().Xor(g).OnesCount(); d != 1 {
+			t.Fatalf("%#x.ToGray() and next differ by %d bits", u, d)
+		}
+	}
+}
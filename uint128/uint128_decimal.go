@@ -0,0 +1,249 @@
+package uint128
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrPrecision indicates that a value has more fractional digits
+// than allowed and the RoundUnnecessary mode is requested.
+var ErrPrecision = errors.New("value has excess precision")
+
+// DecimalOptions control how FormatDecimal prints the value.
+// The zero value prints all the decimals digits, e.g. "1.500000".
+type DecimalOptions struct {
+	// TrimZeros trims the trailing zeros of the fractional part,
+	// and the decimal point if no fractional digits are left.
+	TrimZeros bool
+
+	// FixedPrecision requests exactly Precision fractional digits:
+	// the excess digits are rounded according to the Rounding mode,
+	// the missing digits are padded with zeros. RoundUnnecessary
+	// never drops non-zero digits, so Precision becomes the minimum.
+	FixedPrecision bool
+	Precision      int
+	Rounding       RoundingMode
+
+	// Separator, if not empty, groups the integer digits by thousands,
+	// e.g. "1,000,000.5" with "," separator.
+	Separator string
+}
+
+// FormatDecimal returns the decimal representation of u with
+// the given number of implied decimals, i.e. u/10^decimals.
+// For example, FormatDecimal(From64(1500000), 6, DecimalOptions{}) is "1.500000".
+// Negative decimals are the same as zero.
+func FormatDecimal(u Uint128, decimals int, opts DecimalOptions) string {
+	var buf [39]byte // max 128-bit value is 39 decimal digits long
+	return string(formatDecimal(AppendDecimal(buf[:0], u), decimals, opts))
+}
+
+// ParseDecimal parses the decimal string, e.g. "1.5", as an integer
+// with the given number of implied decimals, i.e. the result is s·10^decimals.
+// The excess fractional digits are rounded according to the mode,
+// RoundUnnecessary rejects non-zero excess digits with ErrPrecision.
+// Negative decimals are the same as zero.
+//
+// The syntax is the decimal digits with optional decimal point,
+// e.g. "123.456", ".5" or "7.". Sign and separators are not allowed.
+// The errors are of *strconv.NumError type.
+func ParseDecimal(s string, decimals int, mode RoundingMode) (Uint128, error) {
+	const fn = "ParseDecimal"
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	ip, fp, ok := splitDecimal(s)
+	if !ok {
+		return Zero(), &strconv.NumError{Func: fn, Num: s, Err: ErrSyntax}
+	}
+
+	var rest string
+	if len(fp) > decimals {
+		fp, rest = fp[:decimals], fp[decimals:]
+	}
+
+	// ip·10^decimals + fp·10^(decimals-len(fp))
+	var u Uint128
+	for _, part := range []string{ip, fp} {
+		for i := 0; i < len(part); i++ {
+			if u, ok = mulAdd10(u, uint64(part[i]-'0')); !ok {
+				return Zero(), &strconv.NumError{Func: fn, Num: s, Err: ErrRange}
+			}
+		}
+	}
+	// zero needs no padding, non-zero value overflows
+	// in a few steps, so huge decimals are cheap
+	for k := len(fp); k < decimals && !u.IsZero(); k++ {
+		if u, ok = mulAdd10(u, 0); !ok {
+			return Zero(), &strconv.NumError{Func: fn, Num: s, Err: ErrRange}
+		}
+	}
+
+	up, exact := roundDecimal(mode, u.Lo&1 != 0, rest)
+	if !exact && mode == RoundUnnecessary {
+		return Zero(), &strconv.NumError{Func: fn, Num: s, Err: ErrPrecision}
+	}
+	if up {
+		if u.Equals(Max()) {
+			return Zero(), &strconv.NumError{Func: fn, Num: s, Err: ErrRange}
+		}
+		u = u.Add64(1)
+	}
+
+	return u, nil
+}
+
+// maxDiv10 is the largest value that can be multiplied by 10 without overflow.
+var maxDiv10 = Max().Div64(10)
+
+// mulAdd10 returns u·10+d.
+// Provides ok successful flag as a second return value.
+func mulAdd10(u Uint128, d uint64) (Uint128, bool) {
+	if u.Cmp(maxDiv10) > 0 {
+		return Zero(), false
+	}
+	v, carry := Add(u.Mul64(10), From64(d), 0)
+	return v, carry == 0
+}
+
+// splitDecimal splits the decimal string into the integer and fractional
+// digits. It reports false if the syntax is wrong or there are no digits.
+func splitDecimal(s string) (ip, fp string, ok bool) {
+	ip = s
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			ip, fp = s[:i], s[i+1:]
+			break
+		}
+	}
+	if len(ip)+len(fp) == 0 {
+		return "", "", false
+	}
+	for _, part := range []string{ip, fp} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return "", "", false
+			}
+		}
+	}
+	return ip, fp, true
+}
+
+// roundDecimal decides whether the kept digits should be rounded up,
+// given the mode, the parity of the last kept digit and the dropped digits.
+// It also reports whether the dropped digits are all zero.
+func roundDecimal(mode RoundingMode, odd bool, rest string) (up bool, exact bool) {
+	tail := false // non-zero digits after the first dropped one
+	for i := 1; i < len(rest); i++ {
+		if rest[i] != '0' {
+			tail = true
+			break
+		}
+	}
+	if len(rest) == 0 || (rest[0] == '0' && !tail) {
+		return false, true
+	}
+
+	switch first := rest[0]; mode {
+	case RoundHalfUp:
+		up = first >= '5'
+	case RoundHalfEven:
+		up = first > '5' || (first == '5' && (tail || odd))
+	case RoundHalfDown:
+		up = first > '5' || (first == '5' && tail)
+	case RoundUp:
+		up = true
+	}
+	return up, false
+}
+
+// formatDecimal formats the decimal digits of an integer
+// with the given number of implied decimals.
+func formatDecimal(digits []byte, decimals int, opts DecimalOptions) []byte {
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	if opts.FixedPrecision {
+		prec := opts.Precision
+		if prec < 0 {
+			prec = 0
+		}
+		if prec < decimals {
+			// the leading zeros are implicit, so keep might be non-positive
+			keep := len(digits) - decimals + prec
+			odd, rest := false, string(digits)
+			switch {
+			case keep > 0:
+				odd, rest = (digits[keep-1]-'0')&1 != 0, string(digits[keep:])
+			case keep < 0:
+				rest = "0" + rest // the first dropped digit is zero
+			}
+			up, exact := roundDecimal(opts.Rounding, odd, rest)
+			switch {
+			case !exact && opts.Rounding == RoundUnnecessary:
+				// keep all the non-zero digits
+				for digits[len(digits)-1] == '0' {
+					digits = digits[:len(digits)-1]
+				}
+				decimals = len(digits) - keep + prec
+			case up && keep > 0:
+				digits = incDecimal(digits[:keep])
+				decimals = prec
+			case up:
+				digits = append(digits[:0], '1')
+				decimals = prec
+			case keep > 0:
+				digits = digits[:keep]
+				decimals = prec
+			default:
+				digits = digits[:0]
+				decimals = prec
+			}
+		}
+		for ; decimals < prec; decimals++ {
+			digits = append(digits, '0')
+		}
+	}
+
+	// at least one integer digit
+	if pad := decimals + 1 - len(digits); pad > 0 {
+		digits = append(make([]byte, pad, pad+len(digits)), digits...)
+		for i := 0; i < pad; i++ {
+			digits[i] = '0'
+		}
+	}
+
+	ip, fp := digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+	if opts.TrimZeros {
+		for len(fp) > 0 && fp[len(fp)-1] == '0' {
+			fp = fp[:len(fp)-1]
+		}
+	}
+
+	out := make([]byte, 0, len(ip)+len(ip)/3*len(opts.Separator)+1+len(fp))
+	for i := range ip {
+		if i != 0 && (len(ip)-i)%3 == 0 {
+			out = append(out, opts.Separator...)
+		}
+		out = append(out, ip[i])
+	}
+	if len(fp) > 0 {
+		out = append(out, '.')
+		out = append(out, fp...)
+	}
+	return out
+}
+
+// incDecimal increments the decimal digits by one.
+func incDecimal(digits []byte) []byte {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] != '9' {
+			digits[i]++
+			return digits
+		}
+		digits[i] = '0'
+	}
+	return append([]byte{'1'}, digits...)
+}
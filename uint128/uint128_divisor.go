@@ -0,0 +1,199 @@
+package uint128
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// Divisor is a precomputed divisor for fast repeated division by an invariant.
+// The reciprocal of normalized divisor is computed once, then the division
+// is done using multiplications only, see N. Möller and T. Granlund,
+// "Improved division by invariant integers", IEEE Transactions on Computers, 2011.
+//
+// Divisor is immutable and safe for concurrent use.
+// The zero value is invalid and panics on division, use NewDivisor.
+type Divisor struct {
+	d     Uint128 // original divisor
+	dn    Uint128 // normalized divisor, i.e. d<<shift
+	shift uint    // normalization shift
+	v     uint64  // reciprocal: 2-by-1 if d.Hi == 0, 3-by-2 otherwise
+}
+
+// NewDivisor precomputes 128-bit divisor d.
+// It panics if divisor is zero.
+func NewDivisor(d Uint128) Divisor {
+	if d.Hi == 0 {
+		return NewDivisor64(d.Lo)
+	}
+
+	s := uint(bits.LeadingZeros64(d.Hi))
+	dn := d.Lsh(s)
+	return Divisor{
+		d:     d,
+		dn:    dn,
+		shift: s,
+		v:     reciprocal3by2(dn.Hi, dn.Lo),
+	}
+}
+
+// NewDivisor64 precomputes 64-bit divisor d.
+// It panics if divisor is zero.
+func NewDivisor64(d uint64) Divisor {
+	if d == 0 {
+		panic(errors.New("integer divide by zero"))
+	}
+
+	s := uint(bits.LeadingZeros64(d))
+	dn := d << s
+	return Divisor{
+		d:     From64(d),
+		dn:    From64(dn),
+		shift: s,
+		v:     reciprocal2by1(dn),
+	}
+}
+
+// Value returns the original divisor value.
+func (d Divisor) Value() Uint128 {
+	return d.d
+}
+
+// Div returns division (u/d) of 128-bit value by precomputed divisor.
+func (d Divisor) Div(u Uint128) Uint128 {
+	q, _ := d.QuoRem(u)
+	return q
+}
+
+// Mod returns modulo (u%d) of 128-bit value by precomputed divisor.
+func (d Divisor) Mod(u Uint128) Uint128 {
+	_, r := d.QuoRem(u)
+	return r
+}
+
+// QuoRem returns quotient (u/d) and remainder (u%d) of 128-bit value by precomputed divisor.
+// It panics if d is the zero value Divisor{}, i.e. was not created by NewDivisor.
+func (d Divisor) QuoRem(u Uint128) (Uint128, Uint128) {
+	if d.d.Hi == 0 {
+		if d.d.Lo == 0 {
+			panic(errors.New("integer divide by zero"))
+		}
+		q, r := d.quoRem64(u)
+		return q, From64(r)
+	}
+
+	// normalized dividend is 3 words: (u2, u1, u0)
+	// the quotient fits a single word since divisor >= 2^64
+	s := d.shift
+	var u2 uint64
+	if s != 0 {
+		u2 = u.Hi >> (64 - s)
+	}
+	un := u.Lsh(s)
+	q, r1, r0 := div3by2(u2, un.Hi, un.Lo, d.dn.Hi, d.dn.Lo, d.v)
+	return From64(q), Uint128{Lo: r0, Hi: r1}.Rsh(s)
+}
+
+// quoRem64 returns quotient and remainder of 128-bit value by 64-bit precomputed divisor.
+func (d Divisor) quoRem64(u Uint128) (Uint128, uint64) {
+	s := d.shift
+	dn := d.dn.Lo
+	var u2 uint64
+	if s != 0 {
+		u2 = u.Hi >> (64 - s)
+	}
+	un := u.Lsh(s)
+
+	var q Uint128
+	var r uint64
+	q.Hi, r = div2by1(u2, un.Hi, dn, d.v)
+	q.Lo, r = div2by1(r, un.Lo, dn, d.v)
+	return q, r >> s
+}
+
+// reciprocal2by1 computes the reciprocal of normalized 64-bit divisor:
+// v = floor((2^128-1)/d) - 2^64.
+func reciprocal2by1(d uint64) uint64 {
+	v, _ := bits.Div64(^d, ^uint64(0), d)
+	return v
+}
+
+// reciprocal3by2 computes the reciprocal of normalized 128-bit divisor (d1, d0):
+// v = floor((2^192-1)/(d1, d0)) - 2^64.
+func reciprocal3by2(d1, d0 uint64) uint64 {
+	v := reciprocal2by1(d1)
+	p := d1 * v
+	p += d0
+	if p < d0 {
+		v--
+		if p >= d1 {
+			v--
+			p -= d1
+		}
+		p -= d1
+	}
+
+	t1, t0 := bits.Mul64(v, d0)
+	p += t1
+	if p < t1 {
+		v--
+		if p > d1 || (p == d1 && t0 >= d0) {
+			v--
+		}
+	}
+
+	return v
+}
+
+// div2by1 divides (u1, u0) by normalized divisor d using its reciprocal v.
+// The u1 must be less than d.
+func div2by1(u1, u0, d, v uint64) (q, r uint64) {
+	q1, q0 := bits.Mul64(v, u1)
+	q0, c := bits.Add64(q0, u0, 0)
+	q1, _ = bits.Add64(q1, u1+1, c)
+
+	r = u0 - q1*d
+	var m uint64 // branch-free adjustment, the condition is unpredictable
+	if r > q0 {
+		m = ^uint64(0)
+	}
+	q1 += m
+	r += d & m
+	if r >= d { // unlikely
+		q1++
+		r -= d
+	}
+
+	return q1, r
+}
+
+// div3by2 divides (u2, u1, u0) by normalized divisor (d1, d0) using its reciprocal v.
+// The (u2, u1) must be less than (d1, d0).
+func div3by2(u2, u1, u0, d1, d0, v uint64) (q, r1, r0 uint64) {
+	q1, q0 := bits.Mul64(v, u2)
+	q0, c := bits.Add64(q0, u1, 0)
+	q1, _ = bits.Add64(q1, u2, c)
+
+	r1 = u1 - q1*d1
+	t1, t0 := bits.Mul64(d0, q1)
+	var b uint64
+	r0, b = bits.Sub64(u0, t0, 0)
+	r1, _ = bits.Sub64(r1, t1, b)
+	r0, b = bits.Sub64(r0, d0, 0)
+	r1, _ = bits.Sub64(r1, d1, b)
+	q1++
+
+	var m uint64 // branch-free adjustment, the condition is unpredictable
+	if r1 >= q0 {
+		m = ^uint64(0)
+	}
+	q1 += m
+	r0, c = bits.Add64(r0, d0&m, 0)
+	r1, _ = bits.Add64(r1, d1&m, c)
+	if r1 > d1 || (r1 == d1 && r0 >= d0) { // unlikely
+		q1++
+		r0, b = bits.Sub64(r0, d0, 0)
+		r1, _ = bits.Sub64(r1, d1, b)
+	}
+
+	return q1, r1, r0
+}
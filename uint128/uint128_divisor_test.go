@@ -0,0 +1,97 @@
+package uint128
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestDivisor unit tests for precomputed Divisor.
+func TestDivisor(t *testing.T) {
+	t.Run("div_by_zero", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != nil {
+				expected := "integer divide by zero"
+				if fmt.Sprintf("%v", r) != expected {
+					t.Fatalf("unexpected panic: %v", r)
+				}
+			} else {
+				t.Fatalf("expected panic, got nothing")
+			}
+		}()
+		NewDivisor(Zero())
+	})
+
+	t.Run("zero_value", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != nil {
+				expected := "integer divide by zero"
+				if fmt.Sprintf("%v", r) != expected {
+					t.Fatalf("unexpected panic: %v", r)
+				}
+			} else {
+				t.Fatalf("expected panic, got nothing")
+			}
+		}()
+		var d Divisor
+		d.Div(One())
+	})
+
+	t.Run("manual", func(t *testing.T) {
+		d := NewDivisor64(1e19)
+		if expected, got := From64(1e19), d.Value(); !got.Equals(expected) {
+			t.Fatalf("Value() should be %v, got %v", expected, got)
+		}
+		if expected, got := Max().Div64(1e19), d.Div(Max()); !got.Equals(expected) {
+			t.Fatalf("Max()/1e19 should be %v, got %v", expected, got)
+		}
+		if expected, got := From64(Max().Mod64(1e19)), d.Mod(Max()); !got.Equals(expected) {
+			t.Fatalf("Max()%%1e19 should be %v, got %v", expected, got)
+		}
+	})
+
+	check := func(t *testing.T, x, y Uint128) {
+		t.Helper()
+		d := NewDivisor(y)
+		eq, er := new(big.Int).QuoRem(x.Big(), y.Big(), new(big.Int))
+		if q, r := d.QuoRem(x); eq.Cmp(q.Big()) != 0 || er.Cmp(r.Big()) != 0 {
+			t.Fatalf("mismatch: (%#x QuoRem %#x) should equal (%#x, %#x), got (%#x, %#x)", x, y, eq, er, q, r)
+		}
+	}
+
+	t.Run("edge", func(t *testing.T) {
+		fixed := []uint64{0, 1, 2, math.MaxUint64 / 2, math.MaxUint64/2 + 1, math.MaxUint64 - 1, math.MaxUint64}
+		for _, xh := range fixed {
+			for _, xl := range fixed {
+				for _, yh := range fixed {
+					for _, yl := range fixed {
+						y := Uint128{Lo: yl, Hi: yh}
+						if y.IsZero() {
+							continue
+						}
+						check(t, Uint128{Lo: xl, Hi: xh}, y)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		xvalues := make(chan Uint128)
+		go generate128s(300, xvalues)
+		for x := range xvalues {
+			yvalues := make(chan Uint128)
+			go generate128s(300, yvalues)
+			for y := range yvalues {
+				if y.IsZero() {
+					continue
+				}
+				check(t, x, y)
+				if z := y.Rsh(uint(x.Lo % 128)); !z.IsZero() {
+					check(t, x, z) // various divisor lengths
+				}
+			}
+		}
+	})
+}
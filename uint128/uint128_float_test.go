@@ -0,0 +1,89 @@
+package uint128
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// TestFloat unit tests for float conversions.
+func TestFloat(t *testing.T) {
+	t.Run("to", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(10000, values)
+		for x := range values {
+			// also check the halfway cases
+			for _, u := range []Uint128{x, x.Rsh(uint(x.Lo % 64)).Or(One().Lsh(127 - uint(x.Hi%60)))} {
+				bf := new(big.Float).SetInt(u.Big())
+				if e, _ := bf.Float64(); u.Float64() != e {
+					t.Fatalf("%#x.Float64() should be %v, got %v", u, e, u.Float64())
+				}
+				if e, _ := bf.Float32(); u.Float32() != e {
+					t.Fatalf("%#x.Float32() should be %v, got %v", u, e, u.Float32())
+				}
+				if got := u.BigFloat(); got.Cmp(bf) != 0 {
+					t.Fatalf("%#x.BigFloat() should be %v, got %v", u, bf, got)
+				}
+				if got := u.BigRat(); !got.IsInt() || got.Num().Cmp(u.Big()) != 0 {
+					t.Fatalf("%#x.BigRat() should be %v, got %v", u, u, got)
+				}
+			}
+		}
+	})
+
+	t.Run("from", func(t *testing.T) {
+		// check compares with big.Float reference
+		check := func(f float64) {
+			t.Helper()
+			var expected Uint128
+			eok := !math.IsNaN(f) && f >= 0 && !math.IsInf(f, 1)
+			if eok {
+				i, _ := big.NewFloat(f).Int(nil)
+				expected, eok = FromBigEx(i)
+			} else if math.IsInf(f, 1) {
+				expected = Max()
+			}
+			if got, ok := FromFloat64(f); got != expected || ok != eok {
+				t.Fatalf("FromFloat64(%v) should be (%#x, %t), got (%#x, %t)", f, expected, eok, got, ok)
+			}
+			if f32 := float32(f); float64(f32) == f {
+				if got, ok := FromFloat32(f32); got != expected || ok != eok {
+					t.Fatalf("FromFloat32(%v) should be (%#x, %t), got (%#x, %t)", f32, expected, eok, got, ok)
+				}
+			}
+			if !math.IsNaN(f) {
+				if got, ok := FromBigFloat(big.NewFloat(f)); got != expected || ok != eok {
+					t.Fatalf("FromBigFloat(%v) should be (%#x, %t), got (%#x, %t)", f, expected, eok, got, ok)
+				}
+			}
+			if !math.IsNaN(f) && !math.IsInf(f, 0) {
+				if got, ok := FromBigRat(new(big.Rat).SetFloat64(f)); got != expected || ok != eok {
+					t.Fatalf("FromBigRat(%v) should be (%#x, %t), got (%#x, %t)", f, expected, eok, got, ok)
+				}
+			}
+		}
+
+		for _, f := range []float64{0, math.Copysign(0, -1), 0.5, 1, 1.5, -0.5, -1,
+			0x1p63, 0x1p64, 0x1p64 + 0x1p12, 0x1p127, 0x1p128, math.Nextafter(0x1p128, 0),
+			math.MaxFloat64, math.Inf(1), math.Inf(-1), math.NaN(), 0x1.8p100} {
+			check(f)
+		}
+
+		rnd := rand.New(rand.NewSource(1))
+		for i := 0; i < 10000; i++ {
+			check(math.Ldexp(rnd.Float64(), rnd.Intn(140)))
+			check(float64(float32(math.Ldexp(rnd.Float64(), rnd.Intn(140)))))
+		}
+
+		if got, ok := FromBigFloat(nil); !ok || !got.IsZero() {
+			t.Fatalf("FromBigFloat(nil) should be zero, got (%#x, %t)", got, ok)
+		}
+		if got, ok := FromBigRat(nil); !ok || !got.IsZero() {
+			t.Fatalf("FromBigRat(nil) should be zero, got (%#x, %t)", got, ok)
+		}
+		if got, ok := FromBigRat(big.NewRat(7, 2)); !ok || !got.Equals64(3) {
+			t.Fatalf("FromBigRat(7/2) should be 3, got (%#x, %t)", got, ok)
+		}
+	})
+}
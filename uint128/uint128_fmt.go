@@ -2,57 +2,162 @@ package uint128
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
-	"math/big"
+	"io"
 	"strconv"
 )
 
+// Errors returned by FromString, Scan, UnmarshalText and ParseBase
+// are of *strconv.NumError type, its Err field is one of the following errors.
+// ErrSyntax and ErrRange are the same errors as defined in strconv package.
+var (
+	// ErrSyntax indicates that a value does not have the right syntax.
+	ErrSyntax = strconv.ErrSyntax
+
+	// ErrRange indicates that a value is out of range.
+	ErrRange = strconv.ErrRange
+
+	// ErrNegative indicates that a value is negative.
+	// Note, errors.Is(ErrNegative, ErrRange) is true.
+	ErrNegative error = negativeError{}
+)
+
+// negativeError is the type of ErrNegative.
+type negativeError struct{}
+
+// Error implements the error interface.
+func (negativeError) Error() string {
+	return "value out of range: negative"
+}
+
+// Is makes ErrNegative also an ErrRange.
+func (negativeError) Is(target error) bool {
+	return target == ErrRange
+}
+
 // FromString parses input string as a Uint128 value.
+// The base is implied by the prefix, see ParseBase.
+// The optional sign is accepted, but only zero might be negative.
 func FromString(s string) (Uint128, error) {
-	var u Uint128
-	_, err := fmt.Sscan(s, &u)
-	return u, err
+	return parseText("FromString", s, 0)
 }
 
 // String returns the base-10 representation of 128-bit value.
 func (u Uint128) String() string {
 	if u.Hi == 0 {
-		if u.Lo == 0 {
-			return "0" // zero
-		}
 		return strconv.FormatUint(u.Lo, 10) // lower 64-bit
 	}
 
-	buf := []byte("0000000000000000000000000000000000000000") // log10(2^128) < 40
-	for i := len(buf); ; i -= 19 {
-		q, r := u.QuoRem64(1e19) // largest power of 10 that fits in a uint64
-		var n int
-		for ; r != 0; r /= 10 {
-			n++
-			buf[i-n] += byte(r % 10)
-		}
+	var buf [39]byte // log10(2^128) < 39
+	return string(AppendDecimal(buf[:0], u))
+}
+
+// decimalDivisor is the largest power of 10 that fits in a uint64.
+var decimalDivisor = NewDivisor64(1e19)
+
+// AppendDecimal appends the base-10 representation of 128-bit value,
+// as generated by String, to dst and returns the extended buffer.
+func AppendDecimal(dst []byte, u Uint128) []byte {
+	if u.Hi == 0 {
+		return strconv.AppendUint(dst, u.Lo, 10) // lower 64-bit
+	}
+
+	var buf [39]byte // log10(2^128) < 39
+	i := len(buf)
+	for {
+		q, r := decimalDivisor.quoRem64(u)
 		if q.IsZero() {
-			return string(buf[i-n:])
+			dst = strconv.AppendUint(dst, r, 10) // leading chunk
+			return append(dst, buf[i:]...)
+		}
+		for n := 0; n < 19; n++ {
+			i--
+			buf[i] = byte('0' + r%10)
+			r /= 10
 		}
 		u = q
 	}
 }
 
+// AppendHex appends the base-16 representation of 128-bit value without
+// leading zeros to dst and returns the extended buffer. The upper flag selects
+// upper-case letters, the prefix flag adds "0x" (or "0X") prefix.
+// The result is the same as fmt's "%x", "%X", "%#x" or "%#X" formatting.
+func AppendHex(dst []byte, u Uint128, upper, prefix bool) []byte {
+	n := (u.BitLen() + 3) / 4
+	if n == 0 {
+		n = 1 // zero
+	}
+	return appendHex(dst, u, upper, prefix, n)
+}
+
+// AppendHexFixed appends the base-16 representation of 128-bit value
+// zero-padded to exactly 32 digits to dst and returns the extended buffer.
+// The upper flag selects upper-case letters, the prefix flag adds "0x" (or "0X") prefix.
+func AppendHexFixed(dst []byte, u Uint128, upper, prefix bool) []byte {
+	return appendHex(dst, u, upper, prefix, 32)
+}
+
+// appendHex appends n lower hex digits of 128-bit value.
+func appendHex(dst []byte, u Uint128, upper, prefix bool, n int) []byte {
+	const lowerDigits = "0123456789abcdef"
+	const upperDigits = "0123456789ABCDEF"
+
+	alphabet, x := lowerDigits, byte('x')
+	if upper {
+		alphabet, x = upperDigits, 'X'
+	}
+	if prefix {
+		dst = append(dst, '0', x)
+	}
+
+	var buf [32]byte
+	i := len(buf)
+	for _, w := range [...]uint64{u.Lo, u.Hi} {
+		for k := 0; k < 16; k++ {
+			i--
+			buf[i] = alphabet[w&0xF]
+			w >>= 4
+		}
+	}
+	return append(dst, buf[len(buf)-n:]...)
+}
+
 // Format does custom formatting of 128-bit value.
 func (u Uint128) Format(s fmt.State, ch rune) {
 	u.Big().Format(s, ch) // via big.Int, unefficient! consider to optimize
 }
 
 // Scan implements fmt.Scanner.
+// The verbs 'b', 'o', 'd', 'x' and 'X' select the corresponding base,
+// for 's' and 'v' the base is implied by the prefix, see ParseBase.
 func (u *Uint128) Scan(s fmt.ScanState, ch rune) error {
-	i := new(big.Int) // via big.Int, unefficient! consider to optimize
-	if err := i.Scan(s, ch); err != nil {
+	var base int
+	switch ch {
+	case 'b':
+		base = 2
+	case 'o':
+		base = 8
+	case 'd':
+		base = 10
+	case 'x', 'X':
+		base = 16
+	case 's', 'v':
+		base = 0
+	default:
+		return errors.New("Uint128.Scan: invalid verb")
+	}
+
+	s.SkipSpace()
+	tok, err := s.Token(false, numberToken(base))
+	if err != nil {
 		return err
 	}
 
-	v, ok := FromBigEx(i)
-	if !ok {
-		return fmt.Errorf("out of 128-bit range")
+	v, err := parseText("Scan", string(tok), base)
+	if err != nil {
+		return err
 	}
 
 	*u = v
@@ -61,24 +166,86 @@ func (u *Uint128) Scan(s fmt.ScanState, ch rune) error {
 
 // MarshalText implements the encoding.TextMarshaler interface.
 func (u Uint128) MarshalText() (text []byte, err error) {
-	return u.Big().MarshalText() // via big.Int, unefficient! consider to optimize
+	return AppendDecimal(nil, u), nil
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The base is implied by the prefix, see ParseBase.
 func (u *Uint128) UnmarshalText(text []byte) error {
-	// via big.Int, unefficient! consider to optimize
-	i := new(big.Int)
-	if err := i.UnmarshalText(text); err != nil {
+	v, err := parseText("UnmarshalText", string(text), 0)
+	if err != nil {
 		return err
 	}
-	v, ok := FromBigEx(i)
-	if !ok {
-		return fmt.Errorf("%q overflows 128-bit integer", text)
-	}
+
 	*u = v
 	return nil
 }
 
+// parseText parses optionally signed number in the given base.
+// Negative numbers (except zero) are reported as ErrNegative.
+func parseText(fn string, s string, base int) (Uint128, error) {
+	t, neg := s, false
+	if len(t) != 0 && (t[0] == '+' || t[0] == '-') {
+		t, neg = t[1:], t[0] == '-'
+	}
+
+	v, err := parseBase(fn, t, base)
+	if ne, ok := err.(*strconv.NumError); ok {
+		ne.Num = s // report the whole input
+		if neg && ne.Err == ErrRange {
+			ne.Err = ErrNegative
+			v = Zero()
+		}
+		return v, ne
+	}
+
+	if neg && !v.IsZero() {
+		return Zero(), &strconv.NumError{Func: fn, Num: s, Err: ErrNegative}
+	}
+
+	return v, nil
+}
+
+// numberToken returns a fmt.ScanState.Token filter that accepts
+// an optionally signed number in the given base and stops at the first
+// rune that cannot be a digit. For base 0 the prefix and underscores
+// are also accepted, see ParseBase.
+func numberToken(base int) func(rune) bool {
+	sign, prefix, under := true, base == 0, base == 0
+	if base == 0 {
+		base = 10
+	}
+	lead := false // leading zero, the base prefix might follow
+	return func(r rune) bool {
+		if sign {
+			sign = false
+			if r == '+' || r == '-' {
+				return true
+			}
+		}
+		if lead {
+			lead, base = false, 8
+			switch r {
+			case 'b', 'B':
+				base = 2
+				return true
+			case 'o', 'O':
+				return true
+			case 'x', 'X':
+				base = 16
+				return true
+			}
+		}
+		if prefix {
+			prefix, lead = false, r == '0'
+		}
+		if r == '_' {
+			return under
+		}
+		return r < 0x80 && digitValue(byte(r), base) < uint64(base)
+	}
+}
+
 // StoreLittleEndian stores 128-bit value in byte slice in little-endian byte order.
 // It panics if byte slice length is less than 16.
 func StoreLittleEndian(b []byte, u Uint128) {
@@ -110,3 +277,47 @@ func LoadBigEndian(b []byte) Uint128 {
 		Hi: binary.BigEndian.Uint64(b[:8]),
 	}
 }
+
+// Note, encoding/binary.Read and encoding/binary.Write handle Uint128
+// as a struct of two uint64 fields: Lo goes first, then Hi. So the result
+// is the same as StoreLittleEndian for binary.LittleEndian only.
+// binary.Size returns 16. The functions below are faster and
+// do not use reflection.
+
+// ReadLittleEndian reads 128-bit value from r in little-endian byte order.
+// The error is io.EOF only if no bytes were read. If an EOF happens
+// after reading some but not all the bytes, io.ErrUnexpectedEOF is returned.
+func ReadLittleEndian(r io.Reader) (Uint128, error) {
+	var b [16]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return Zero(), err
+	}
+	return LoadLittleEndian(b[:]), nil
+}
+
+// ReadBigEndian reads 128-bit value from r in big-endian byte order.
+// The error is io.EOF only if no bytes were read. If an EOF happens
+// after reading some but not all the bytes, io.ErrUnexpectedEOF is returned.
+func ReadBigEndian(r io.Reader) (Uint128, error) {
+	var b [16]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return Zero(), err
+	}
+	return LoadBigEndian(b[:]), nil
+}
+
+// WriteLittleEndian writes 128-bit value to w in little-endian byte order.
+func WriteLittleEndian(w io.Writer, u Uint128) error {
+	var b [16]byte
+	StoreLittleEndian(b[:], u)
+	_, err := w.Write(b[:])
+	return err
+}
+
+// WriteBigEndian writes 128-bit value to w in big-endian byte order.
+func WriteBigEndian(w io.Writer, u Uint128) error {
+	var b [16]byte
+	StoreBigEndian(b[:], u)
+	_, err := w.Write(b[:])
+	return err
+}
@@ -1,8 +1,14 @@
 package uint128
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -52,6 +58,53 @@ func TestUint128String(t *testing.T) {
 		}
 	})
 
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			s   string
+			u   Uint128
+			err error
+		}{
+			{"", Zero(), ErrSyntax},
+			{"+", Zero(), ErrSyntax},
+			{"-", Zero(), ErrSyntax},
+			{"+1", One(), nil},
+			{"-0", Zero(), nil},
+			{"0x_1f", From64(31), nil},
+			{"-1", Zero(), ErrNegative},
+			{"-340282366920938463463374607431768211456", Zero(), ErrNegative},
+			{"340282366920938463463374607431768211456", Max(), ErrRange},
+			{"1e3", Zero(), ErrSyntax},
+			{" 1", Zero(), ErrSyntax},
+		}
+
+		for _, tt := range tests {
+			u, err := FromString(tt.s)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("FromString(%q) unexpected error: %v", tt.s, err)
+			}
+			if err != nil {
+				var ne *strconv.NumError
+				if !errors.As(err, &ne) || ne.Func != "FromString" || ne.Num != tt.s {
+					t.Fatalf("FromString(%q) unexpected error: %#v", tt.s, err)
+				}
+			}
+			if !u.Equals(tt.u) {
+				t.Fatalf("FromString(%q) should be %#x, got %#x", tt.s, tt.u, u)
+			}
+		}
+
+		// no allocations on success
+		x := Max().String()
+		if allocs := testing.AllocsPerRun(100, func() { _, _ = FromString(x) }); allocs != 0 {
+			t.Fatalf("FromString(%q) should not allocate, got %v allocs", x, allocs)
+		}
+
+		// ErrNegative is also ErrRange
+		if !errors.Is(ErrNegative, ErrRange) {
+			t.Fatalf("ErrNegative should be ErrRange")
+		}
+	})
+
 	t.Run("rand", func(t *testing.T) {
 		values := make(chan Uint128)
 		go generate128s(1000, values)
@@ -88,6 +141,99 @@ func BenchmarkUint128String(b *testing.B) {
 	})
 }
 
+// TestAppend unit tests for AppendDecimal, AppendHex and AppendHexFixed functions.
+func TestAppend(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		if expected, got := "u=0", string(AppendDecimal([]byte("u="), Zero())); got != expected {
+			t.Errorf("AppendDecimal(0) should be %q, got %q", expected, got)
+		}
+		if expected, got := "u=0x0", string(AppendHex([]byte("u="), Zero(), false, true)); got != expected {
+			t.Errorf("AppendHex(0) should be %q, got %q", expected, got)
+		}
+		if expected, got := "u=0X00000000000000000000000000000001", string(AppendHexFixed([]byte("u="), One(), true, true)); got != expected {
+			t.Errorf("AppendHexFixed(1) should be %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			if expected, got := x.Big().String(), string(AppendDecimal(nil, x)); got != expected {
+				t.Fatalf("AppendDecimal mismatch:\n\t(-) expected %q\n\t(+)   actual %q", expected, got)
+			}
+			for _, f := range []struct {
+				format string
+				upper  bool
+				prefix bool
+			}{
+				{"%x", false, false},
+				{"%X", true, false},
+				{"%#x", false, true},
+				{"%#X", true, true},
+			} {
+				if expected, got := fmt.Sprintf(f.format, x.Big()), string(AppendHex(nil, x, f.upper, f.prefix)); got != expected {
+					t.Fatalf("AppendHex(%v, %v) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", f.upper, f.prefix, expected, got)
+				}
+				fixed, prefix := fmt.Sprintf("%032x", x.Big()), "0x"
+				if f.upper {
+					fixed, prefix = strings.ToUpper(fixed), "0X"
+				}
+				if f.prefix {
+					fixed = prefix + fixed
+				}
+				if expected, got := fixed, string(AppendHexFixed(nil, x, f.upper, f.prefix)); got != expected {
+					t.Fatalf("AppendHexFixed(%v, %v) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", f.upper, f.prefix, expected, got)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkAppend performance tests for AppendDecimal and AppendHex functions.
+func BenchmarkAppend(b *testing.B) {
+	b.ReportAllocs()
+
+	x := rand128()
+	buf := make([]byte, 0, 128)
+
+	b.Run("AppendDecimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = AppendDecimal(buf[:0], x)
+		}
+	})
+
+	b.Run("String", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = x.String()
+		}
+	})
+
+	b.Run("fmt.Sprint", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprint(x)
+		}
+	})
+
+	b.Run("AppendHex", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = AppendHex(buf[:0], x, false, false)
+		}
+	})
+
+	b.Run("AppendHexFixed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = AppendHexFixed(buf[:0], x, false, false)
+		}
+	})
+
+	b.Run("fmt.Sprintf_x", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprintf("%x", x)
+		}
+	})
+}
+
 // TestUint128Format unit tests for Uint128.Format() method
 func TestUint128Format(t *testing.T) {
 	t.Run("manual", func(t *testing.T) {
@@ -108,6 +254,60 @@ func TestUint128Format(t *testing.T) {
 	})
 }
 
+// TestUint128Scan unit tests for Uint128.Scan() method
+func TestUint128Scan(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		var u, v Uint128
+		if _, err := fmt.Sscanf("ff 0x10", "%x %v", &u, &v); err != nil {
+			t.Fatalf("Sscanf failed: %v", err)
+		} else if !u.Equals64(255) || !v.Equals64(16) {
+			t.Fatalf("Sscanf mismatch: actual %v, %v", u, v)
+		}
+
+		if _, err := fmt.Sscanf("-1", "%d", &u); !errors.Is(err, ErrNegative) {
+			t.Fatalf("Sscanf unexpected error: %v", err)
+		}
+		if _, err := fmt.Sscanf("12", "%b", &u); err != nil || !u.Equals64(1) {
+			t.Fatalf("Sscanf(%q) unexpected result: %v, %v", "12", u, err)
+		}
+		if _, err := fmt.Sscanf("z", "%d", &u); !errors.Is(err, ErrSyntax) {
+			t.Fatalf("Sscanf unexpected error: %v", err)
+		}
+		if _, err := fmt.Sscanf("1", "%c", &u); err == nil {
+			t.Fatalf("Sscanf expected error")
+		}
+	})
+
+	t.Run("trailing", func(t *testing.T) {
+		var u, v Uint128
+		if _, err := fmt.Sscanf("10ms", "%dms", &u); err != nil || !u.Equals64(10) {
+			t.Fatalf("Sscanf(%q) unexpected result: %v, %v", "10ms", u, err)
+		}
+		if _, err := fmt.Sscanf("5-3", "%d-%d", &u, &v); err != nil || !u.Equals64(5) || !v.Equals64(3) {
+			t.Fatalf("Sscanf(%q) unexpected result: %v, %v, %v", "5-3", u, v, err)
+		}
+		if _, err := fmt.Sscanf("fg", "%xg", &u); err != nil || !u.Equals64(15) {
+			t.Fatalf("Sscanf(%q) unexpected result: %v, %v", "fg", u, err)
+		}
+		if _, err := fmt.Sscanf("0x1_0h 0b11,", "%vh %v,", &u, &v); err != nil || !u.Equals64(16) || !v.Equals64(3) {
+			t.Fatalf("Sscanf(%q) unexpected result: %v, %v, %v", "0x1_0h 0b11,", u, v, err)
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			var u Uint128
+			if _, err := fmt.Sscanf(fmt.Sprintf("%o", x), "%o", &u); err != nil {
+				t.Fatalf("Sscanf(%#o) failed: %v", x, err)
+			} else if !u.Equals(x) {
+				t.Fatalf("Sscanf(%#o) mismatch: actual %#o", x, u)
+			}
+		}
+	})
+}
+
 // TestStoreLoad unit tests for bytes load/store functions
 func TestStoreLoad(t *testing.T) {
 	t.Run("rand", func(t *testing.T) {
@@ -136,6 +336,92 @@ func TestStoreLoad(t *testing.T) {
 	})
 }
 
+// TestReadWrite unit tests for io.Reader/io.Writer functions
+func TestReadWrite(t *testing.T) {
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			var buf bytes.Buffer
+
+			// little-endian
+			if err := WriteLittleEndian(&buf, x); err != nil {
+				t.Fatalf("WriteLittleEndian(%#x) failed: %v", x, err)
+			}
+			if got, err := ReadLittleEndian(&buf); err != nil || got != x {
+				t.Fatalf("ReadLittleEndian is not the inverse of WriteLittleEndian for %#x, got %#x (%v)", x, got, err)
+			}
+
+			// big-endian
+			if err := WriteBigEndian(&buf, x); err != nil {
+				t.Fatalf("WriteBigEndian(%#x) failed: %v", x, err)
+			}
+			if got, err := ReadBigEndian(&buf); err != nil || got != x {
+				t.Fatalf("ReadBigEndian is not the inverse of WriteBigEndian for %#x, got %#x (%v)", x, got, err)
+			}
+
+			// encoding/binary compatibility
+			if err := binary.Write(&buf, binary.LittleEndian, x); err != nil {
+				t.Fatalf("binary.Write(%#x) failed: %v", x, err)
+			}
+			if got, err := ReadLittleEndian(&buf); err != nil || got != x {
+				t.Fatalf("ReadLittleEndian is not the inverse of binary.Write for %#x, got %#x (%v)", x, got, err)
+			}
+			if err := WriteLittleEndian(&buf, x); err != nil {
+				t.Fatalf("WriteLittleEndian(%#x) failed: %v", x, err)
+			}
+			var got Uint128
+			if err := binary.Read(&buf, binary.LittleEndian, &got); err != nil || got != x {
+				t.Fatalf("binary.Read is not the inverse of WriteLittleEndian for %#x, got %#x (%v)", x, got, err)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if n := binary.Size(Uint128{}); n != 16 {
+			t.Fatalf("binary.Size should be 16, got %d", n)
+		}
+
+		for n := 0; n < 16; n++ {
+			expected := io.ErrUnexpectedEOF
+			if n == 0 {
+				expected = io.EOF
+			}
+			data := make([]byte, n)
+			if _, err := ReadLittleEndian(bytes.NewReader(data)); err != expected {
+				t.Fatalf("ReadLittleEndian(%d bytes) should fail with %v, got %v", n, expected, err)
+			}
+			if _, err := ReadBigEndian(bytes.NewReader(data)); err != expected {
+				t.Fatalf("ReadBigEndian(%d bytes) should fail with %v, got %v", n, expected, err)
+			}
+		}
+
+		w := &limitedWriter{n: 5}
+		if err := WriteBigEndian(w, Max()); err != io.ErrShortWrite {
+			t.Fatalf("WriteBigEndian should fail with %v, got %v", io.ErrShortWrite, err)
+		}
+		if err := WriteLittleEndian(w, Max()); err != io.ErrShortWrite {
+			t.Fatalf("WriteLittleEndian should fail with %v, got %v", io.ErrShortWrite, err)
+		}
+	})
+}
+
+// limitedWriter accepts n bytes and fails with io.ErrShortWrite then.
+type limitedWriter struct {
+	n int
+}
+
+// Write implements the io.Writer interface.
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, io.ErrShortWrite
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
 // TestJSON unit tests for marshaling functions
 func TestJSON(t *testing.T) {
 	type Foo struct {
@@ -147,14 +433,14 @@ func TestJSON(t *testing.T) {
 
 		// expected non-empty string
 		err := json.Unmarshal([]byte(`{"bar":""}`), &tmp)
-		if err == nil {
-			t.Fatalf("should fail on BAD JSON")
+		if !errors.Is(err, ErrSyntax) {
+			t.Fatalf("should fail on BAD JSON with ErrSyntax, got %v", err)
 		}
 
 		// expected positive integer in range [0, 2^128)
 		err = json.Unmarshal([]byte(`{"bar":"-1"}`), &tmp)
-		if err == nil {
-			t.Fatalf("should fail on BAD JSON")
+		if !errors.Is(err, ErrNegative) {
+			t.Fatalf("should fail on BAD JSON with ErrNegative, got %v", err)
 		}
 	})
 
@@ -0,0 +1,177 @@
+package uint128
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrPermutation indicates that indexes are not a permutation.
+var ErrPermutation = errors.New("invalid permutation")
+
+// DepositBits returns the lower bits of u deposited to the positions of set
+// bits of mask, from the least significant one (the PDEP instruction).
+func (u Uint128) DepositBits(mask Uint128) Uint128 {
+	c := uint(bits.OnesCount64(mask.Lo))
+	return Uint128{
+		Lo: pdep64(u.Lo, mask.Lo),
+		Hi: pdep64(u.Rsh(c).Lo, mask.Hi),
+	}
+}
+
+// ExtractBits returns the bits of u at the positions of set bits of mask
+// packed to the lower bits of the result (the PEXT instruction).
+func (u Uint128) ExtractBits(mask Uint128) Uint128 {
+	c := uint(bits.OnesCount64(mask.Lo))
+	lo := Uint128{Lo: pext64(u.Lo, mask.Lo)}
+	return lo.Or(From64(pext64(u.Hi, mask.Hi)).Lsh(c))
+}
+
+// pdep64Generic is pure Go implementation of pdep64.
+func pdep64Generic(x, m uint64) (r uint64) {
+	for ; m != 0; m &= m - 1 {
+		r |= m & -m & -(x & 1) // branch-free
+		x >>= 1
+	}
+	return
+}
+
+// pext64Generic is pure Go implementation of pext64.
+func pext64Generic(x, m uint64) (r uint64) {
+	for b := uint64(1); m != 0; b <<= 1 {
+		if x&m&-m != 0 {
+			r |= b
+		}
+		m &= m - 1
+	}
+	return
+}
+
+// DeltaSwap returns the value with bits i and i+shift swapped
+// for each set bit i of mask. This is a single stage of butterfly network.
+// The mask must not have set bits i and i+shift at the same time.
+func (u Uint128) DeltaSwap(mask Uint128, shift uint) Uint128 {
+	t := u.Rsh(shift).Xor(u).And(mask)
+	return u.Xor(t).Xor(t.Lsh(shift))
+}
+
+// benesShifts128 are delta swap shifts of 128-bit Benes network stages.
+var benesShifts128 = [13]uint{64, 32, 16, 8, 4, 2, 1, 2, 4, 8, 16, 32, 64}
+
+// Permutation128 is a precomputed arbitrary bit permutation.
+// It is implemented as Benes network: 13 delta swap stages.
+type Permutation128 struct {
+	masks [len(benesShifts128)]Uint128
+}
+
+// NewPermutation128 returns the permutation such that the bit i
+// of the result is the bit src[i] of the source value.
+// It fails with ErrPermutation if src is not a permutation of [0, 128).
+func NewPermutation128(src []int) (*Permutation128, error) {
+	dest, ok := invertPermutation(src, 128)
+	if !ok {
+		return nil, ErrPermutation
+	}
+
+	p := &Permutation128{}
+	benesRoute(dest, 0, 0, len(p.masks)-1, func(stage, pos int) {
+		p.masks[stage] = p.masks[stage].SetBit(uint(pos), 1)
+	})
+	return p, nil
+}
+
+// Apply returns the permuted value.
+func (p *Permutation128) Apply(u Uint128) Uint128 {
+	m := &p.masks[0]
+	t := (u.Lo ^ u.Hi) & m.Lo // shift 64
+	u.Lo ^= t
+	u.Hi ^= t
+	for i := 1; i < len(p.masks)-1; i++ {
+		s, m := benesShifts128[i], &p.masks[i]
+		t := ((u.Lo >> s) ^ u.Lo) & m.Lo
+		u.Lo ^= t ^ (t << s)
+		t = ((u.Hi >> s) ^ u.Hi) & m.Hi
+		u.Hi ^= t ^ (t << s)
+	}
+	m = &p.masks[len(p.masks)-1]
+	t = (u.Lo ^ u.Hi) & m.Lo // shift 64
+	u.Lo ^= t
+	u.Hi ^= t
+	return u
+}
+
+// invertPermutation returns dest such that dest[src[i]] = i.
+// It returns false if src is not a permutation of [0, n).
+func invertPermutation(src []int, n int) ([]int, bool) {
+	if len(src) != n {
+		return nil, false
+	}
+	dest := make([]int, n)
+	for i := range dest {
+		dest[i] = -1
+	}
+	for i, j := range src {
+		if j < 0 || j >= n || dest[j] >= 0 {
+			return nil, false
+		}
+		dest[j] = i
+	}
+	return dest, true
+}
+
+// benesRoute computes Benes network switches for the block of len(dest)
+// positions starting at base, where input j should go to output dest[j].
+// The first and last stages of the block are swaps with shift len(dest)/2.
+// The set callback is called for each switch to be crossed.
+// This is the classic "looping" algorithm.
+func benesRoute(dest []int, base, first, last int, set func(stage, pos int)) {
+	n := len(dest)
+	if n == 2 {
+		if dest[0] != 0 {
+			set(first, base)
+		}
+		return
+	}
+
+	h := n / 2
+	inv := make([]int, n)
+	for j, o := range dest {
+		inv[o] = j
+	}
+
+	// side of each input: 0 - upper subnetwork, 1 - lower subnetwork
+	side := make([]int8, n)
+	for j := range side {
+		side[j] = -1
+	}
+	for j := range side {
+		for k := j; side[k] < 0; {
+			side[k] = 0
+			// partner output must come from the lower subnetwork
+			p := inv[dest[k]^h]
+			side[p] = 1
+			// partner input must go to the upper subnetwork
+			k = p ^ h
+		}
+	}
+
+	upper := make([]int, h)
+	lower := make([]int, h)
+	for j, o := range dest {
+		if side[j] == 0 {
+			upper[j&(h-1)] = o & (h - 1)
+		} else {
+			lower[j&(h-1)] = o & (h - 1)
+		}
+	}
+	for i := 0; i < h; i++ {
+		if side[i] != 0 {
+			set(first, base+i)
+		}
+		if side[inv[i]] != 0 {
+			set(last, base+i)
+		}
+	}
+
+	benesRoute(upper, base, first+1, last-1, set)
+	benesRoute(lower, base+h, first+1, last-1, set)
+}
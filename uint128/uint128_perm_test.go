@@ -0,0 +1,134 @@
+package uint128
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// naiveDeposit is bit-by-bit reference implementation of DepositBits.
+func naiveDeposit(x, mask Uint128) (r Uint128) {
+	k := uint(0)
+	for i := uint(0); i < 128; i++ {
+		if mask.Bit(i) != 0 {
+			r = r.SetBit(i, x.Bit(k))
+			k++
+		}
+	}
+	return
+}
+
+// naiveExtract is bit-by-bit reference implementation of ExtractBits.
+func naiveExtract(x, mask Uint128) (r Uint128) {
+	k := uint(0)
+	for i := uint(0); i < 128; i++ {
+		if mask.Bit(i) != 0 {
+			r = r.SetBit(k, x.Bit(i))
+			k++
+		}
+	}
+	return
+}
+
+// naivePermute is bit-by-bit reference implementation of permutation.
+func naivePermute(x Uint128, src []int) (r Uint128) {
+	for i, j := range src {
+		r = r.SetBit(uint(i), x.Bit(uint(j)))
+	}
+	return
+}
+
+// TestDepositExtract unit tests for DepositBits and ExtractBits.
+func TestDepositExtract(t *testing.T) {
+	xvalues := make(chan Uint128)
+	go generate128s(100, xvalues)
+	for x := range xvalues {
+		masks := make(chan Uint128)
+		go generate128s(100, masks)
+		for m := range masks {
+			if got, expected := x.DepositBits(m), naiveDeposit(x, m); got != expected {
+				t.Fatalf("%#x.DepositBits(%#x) should be %#x, got %#x", x, m, expected, got)
+			}
+			if got, expected := x.ExtractBits(m), naiveExtract(x, m); got != expected {
+				t.Fatalf("%#x.ExtractBits(%#x) should be %#x, got %#x", x, m, expected, got)
+			}
+			if got, expected := pdep64Generic(x.Lo, m.Lo), naiveDeposit(x, m.And64(^uint64(0))).Lo; got != expected {
+				t.Fatalf("pdep64Generic(%#x, %#x) should be %#x, got %#x", x.Lo, m.Lo, expected, got)
+			}
+			if got, expected := pext64Generic(x.Lo, m.Lo), naiveExtract(x, m.And64(^uint64(0))).Lo; got != expected {
+				t.Fatalf("pext64Generic(%#x, %#x) should be %#x, got %#x", x.Lo, m.Lo, expected, got)
+			}
+		}
+	}
+}
+
+// TestPermutation unit tests for DeltaSwap and Permutation128.
+func TestPermutation(t *testing.T) {
+	t.Run("DeltaSwap", func(t *testing.T) {
+		values := make(chan Uint128)
+		go generate128s(1000, values)
+		for x := range values {
+			for _, s := range []uint{1, 2, 4, 8, 16, 32, 64} {
+				mask := x.Reverse().And(Max().Rsh(s)).AndNot(x.Reverse().Lsh(s)) // no overlaps
+				src := make([]int, 128)
+				for i := range src {
+					src[i] = i
+				}
+				for i := range src {
+					if mask.Bit(uint(i)) != 0 {
+						src[i], src[i+int(s)] = src[i+int(s)], src[i]
+					}
+				}
+				if got, expected := x.DeltaSwap(mask, s), naivePermute(x, src); got != expected {
+					t.Fatalf("%#x.DeltaSwap(%#x, %d) should be %#x, got %#x", x, mask, s, expected, got)
+				}
+			}
+		}
+	})
+
+	t.Run("Benes", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(1))
+		perms := [][]int{
+			rnd.Perm(128),
+			make([]int, 128), // identity
+			make([]int, 128), // reverse
+			make([]int, 128), // rotate
+		}
+		for i := 0; i < 128; i++ {
+			perms[1][i] = i
+			perms[2][i] = 127 - i
+			perms[3][i] = (i + 5) % 128
+		}
+		for k := 0; k < 200; k++ {
+			perms = append(perms, rnd.Perm(128))
+		}
+
+		for _, src := range perms {
+			p, err := NewPermutation128(src)
+			if err != nil {
+				t.Fatalf("NewPermutation128(%v) failed: %v", src, err)
+			}
+			values := make(chan Uint128)
+			go generate128s(100, values)
+			for x := range values {
+				if got, expected := p.Apply(x), naivePermute(x, src); got != expected {
+					t.Fatalf("Apply(%#x) with %v should be %#x, got %#x", x, src, expected, got)
+				}
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		bad := [][]int{
+			nil,
+			make([]int, 127),
+			make([]int, 128), // duplicates
+			append(make([]int, 127), 128),
+			append(make([]int, 127), -1),
+		}
+		for _, src := range bad {
+			if p, err := NewPermutation128(src); err != ErrPermutation || p != nil {
+				t.Fatalf("NewPermutation128(%v) should fail with %v, got %v", src, ErrPermutation, err)
+			}
+		}
+	})
+}
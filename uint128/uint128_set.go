@@ -0,0 +1,93 @@
+package uint128
+
+import (
+	"math/bits"
+)
+
+// The Set* methods below are an in-place alternative to the value API,
+// mirroring math/big: the result is stored to the receiver z which is also
+// returned. The receiver may alias any of the arguments.
+
+// Set sets z to x and returns z.
+func (z *Uint128) Set(x *Uint128) *Uint128 {
+	*z = *x
+	return z
+}
+
+// SetAdd sets z to the sum (x+y) and returns z.
+// Wrap-around semantic is used here.
+func (z *Uint128) SetAdd(x, y *Uint128) *Uint128 {
+	var c uint64
+	z.Lo, c = bits.Add64(x.Lo, y.Lo, 0)
+	z.Hi, _ = bits.Add64(x.Hi, y.Hi, c)
+	return z
+}
+
+// SetSub sets z to the difference (x-y) and returns z.
+// Wrap-around semantic is used here.
+func (z *Uint128) SetSub(x, y *Uint128) *Uint128 {
+	var b uint64
+	z.Lo, b = bits.Sub64(x.Lo, y.Lo, 0)
+	z.Hi, _ = bits.Sub64(x.Hi, y.Hi, b)
+	return z
+}
+
+// SetMul sets z to the product (x*y) and returns z.
+// Wrap-around semantic is used here.
+func (z *Uint128) SetMul(x, y *Uint128) *Uint128 {
+	hi, lo := bits.Mul64(x.Lo, y.Lo)
+	hi += x.Hi*y.Lo + x.Lo*y.Hi
+	z.Lo, z.Hi = lo, hi
+	return z
+}
+
+// SetQuo sets z to the quotient (x/y) and returns z.
+// It panics if y is zero.
+func (z *Uint128) SetQuo(x, y *Uint128) *Uint128 {
+	*z, _ = x.QuoRem(*y)
+	return z
+}
+
+// SetRem sets z to the remainder (x%y) and returns z.
+// It panics if y is zero.
+func (z *Uint128) SetRem(x, y *Uint128) *Uint128 {
+	_, *z = x.QuoRem(*y)
+	return z
+}
+
+// SetQuoRem sets z to the quotient (x/y) and r to the remainder (x%y)
+// and returns the pair (z, r). It panics if y is zero.
+func (z *Uint128) SetQuoRem(x, y, r *Uint128) (*Uint128, *Uint128) {
+	*z, *r = x.QuoRem(*y)
+	return z, r
+}
+
+// SetLsh sets z to the left shift (x<<n) and returns z.
+func (z *Uint128) SetLsh(x *Uint128, n uint) *Uint128 {
+	*z = x.Lsh(n)
+	return z
+}
+
+// SetRsh sets z to the right shift (x>>n) and returns z.
+func (z *Uint128) SetRsh(x *Uint128, n uint) *Uint128 {
+	*z = x.Rsh(n)
+	return z
+}
+
+// SetAnd sets z to the bitwise AND (x&y) and returns z.
+func (z *Uint128) SetAnd(x, y *Uint128) *Uint128 {
+	z.Lo, z.Hi = x.Lo&y.Lo, x.Hi&y.Hi
+	return z
+}
+
+// SetOr sets z to the bitwise OR (x|y) and returns z.
+func (z *Uint128) SetOr(x, y *Uint128) *Uint128 {
+	z.Lo, z.Hi = x.Lo|y.Lo, x.Hi|y.Hi
+	return z
+}
+
+// SetXor sets z to the bitwise XOR (x^y) and returns z.
+func (z *Uint128) SetXor(x, y *Uint128) *Uint128 {
+	z.Lo, z.Hi = x.Lo^y.Lo, x.Hi^y.Hi
+	return z
+}
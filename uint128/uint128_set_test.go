@@ -0,0 +1,53 @@
+package uint128
+
+import (
+	"testing"
+)
+
+// TestSet unit tests for in-place Set* methods.
+func TestSet(t *testing.T) {
+	check := func(t *testing.T, name string, x, y, expected Uint128, op func(z, x, y *Uint128) *Uint128) {
+		t.Helper()
+		var z Uint128
+		if got := op(&z, &x, &y); got != &z || !z.Equals(expected) {
+			t.Fatalf("%s(%#x, %#x) should be %#x, got %#x", name, x, y, expected, z)
+		}
+		if zx := x; !op(&zx, &zx, &y).Equals(expected) { // alias x
+			t.Fatalf("%s(%#x, %#x) aliased x should be %#x, got %#x", name, x, y, expected, zx)
+		}
+		if zy := y; !op(&zy, &x, &zy).Equals(expected) { // alias y
+			t.Fatalf("%s(%#x, %#x) aliased y should be %#x, got %#x", name, x, y, expected, zy)
+		}
+	}
+
+	xvalues := make(chan Uint128)
+	go generate128s(100, xvalues)
+	for x := range xvalues {
+		yvalues := make(chan Uint128)
+		go generate128s(100, yvalues)
+		for y := range yvalues {
+			check(t, "Set", x, y, x, func(z, x, _ *Uint128) *Uint128 { return z.Set(x) })
+			check(t, "SetAdd", x, y, x.Add(y), (*Uint128).SetAdd)
+			check(t, "SetSub", x, y, x.Sub(y), (*Uint128).SetSub)
+			check(t, "SetMul", x, y, x.Mul(y), (*Uint128).SetMul)
+			check(t, "SetAnd", x, y, x.And(y), (*Uint128).SetAnd)
+			check(t, "SetOr", x, y, x.Or(y), (*Uint128).SetOr)
+			check(t, "SetXor", x, y, x.Xor(y), (*Uint128).SetXor)
+
+			n := uint(y.Lo % 130)
+			check(t, "SetLsh", x, y, x.Lsh(n), func(z, x, _ *Uint128) *Uint128 { return z.SetLsh(x, n) })
+			check(t, "SetRsh", x, y, x.Rsh(n), func(z, x, _ *Uint128) *Uint128 { return z.SetRsh(x, n) })
+
+			if !y.IsZero() {
+				eq, er := x.QuoRem(y)
+				check(t, "SetQuo", x, y, eq, (*Uint128).SetQuo)
+				check(t, "SetRem", x, y, er, (*Uint128).SetRem)
+
+				var q, r Uint128
+				if zq, zr := q.SetQuoRem(&x, &y, &r); zq != &q || zr != &r || !q.Equals(eq) || !r.Equals(er) {
+					t.Fatalf("SetQuoRem(%#x, %#x) should be (%#x, %#x), got (%#x, %#x)", x, y, eq, er, q, r)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package uint128
+
+// LoadSliceLittleEndian loads 128-bit values from byte slice in little-endian byte order.
+// It loads min(len(dst), len(src)/16) values and returns that number.
+// The values are copied, on little-endian hosts with a single memmove;
+// see ViewSliceLittleEndian for a view without copying.
+func LoadSliceLittleEndian(dst []Uint128, src []byte) int {
+	n := len(src) / 16
+	if len(dst) < n {
+		n = len(dst)
+	}
+	dst = dst[:n]
+	if copyFromBytes(dst, src) {
+		return n
+	}
+	for i := range dst {
+		dst[i] = LoadLittleEndian(src[i*16:])
+	}
+	return n
+}
+
+// LoadSliceBigEndian loads 128-bit values from byte slice in big-endian byte order.
+// It loads min(len(dst), len(src)/16) values and returns that number.
+func LoadSliceBigEndian(dst []Uint128, src []byte) int {
+	n := len(src) / 16
+	if len(dst) < n {
+		n = len(dst)
+	}
+	dst = dst[:n]
+	for i := range dst {
+		dst[i] = LoadBigEndian(src[i*16:])
+	}
+	return n
+}
+
+// StoreSliceLittleEndian stores 128-bit values in byte slice in little-endian byte order.
+// It stores min(len(dst)/16, len(src)) values and returns that number.
+// The values are copied, on little-endian hosts with a single memmove;
+// see ViewBytesLittleEndian for a view without copying.
+func StoreSliceLittleEndian(dst []byte, src []Uint128) int {
+	n := len(dst) / 16
+	if len(src) < n {
+		n = len(src)
+	}
+	src = src[:n]
+	if copyToBytes(dst, src) {
+		return n
+	}
+	for i := range src {
+		StoreLittleEndian(dst[i*16:], src[i])
+	}
+	return n
+}
+
+// StoreSliceBigEndian stores 128-bit values in byte slice in big-endian byte order.
+// It stores min(len(dst)/16, len(src)) values and returns that number.
+func StoreSliceBigEndian(dst []byte, src []Uint128) int {
+	n := len(dst) / 16
+	if len(src) < n {
+		n = len(src)
+	}
+	src = src[:n]
+	for i := range src {
+		StoreBigEndian(dst[i*16:], src[i])
+	}
+	return n
+}
+
+// ViewSliceLittleEndian returns byte slice in little-endian byte order
+// as len(src)/16 128-bit values without copying, e.g. for memory-mapped files.
+// The result aliases src memory, so changes are visible through both.
+// The ok is false if the view is not possible: the host is big-endian,
+// src is not aligned to 8 bytes (4 bytes on 32-bit platforms)
+// or the purego build tag is set. Use LoadSliceLittleEndian then.
+func ViewSliceLittleEndian(src []byte) (dst []Uint128, ok bool) {
+	return viewValues(src)
+}
+
+// ViewBytesLittleEndian returns 128-bit values as len(src)*16 bytes
+// in little-endian byte order without copying.
+// The result aliases src memory, so changes are visible through both.
+// The ok is false if the view is not possible: the host is big-endian
+// or the purego build tag is set. Use StoreSliceLittleEndian then.
+func ViewBytesLittleEndian(src []Uint128) (dst []byte, ok bool) {
+	return viewBytes(src)
+}
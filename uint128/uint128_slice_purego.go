@@ -0,0 +1,24 @@
+//go:build purego
+// +build purego
+
+package uint128
+
+// copyFromBytes always falls back to portable code.
+func copyFromBytes(dst []Uint128, src []byte) bool {
+	return false
+}
+
+// copyToBytes always falls back to portable code.
+func copyToBytes(dst []byte, src []Uint128) bool {
+	return false
+}
+
+// viewBytes is never possible without unsafe.
+func viewBytes(u []Uint128) ([]byte, bool) {
+	return nil, false
+}
+
+// viewValues is never possible without unsafe.
+func viewValues(b []byte) ([]Uint128, bool) {
+	return nil, false
+}
@@ -0,0 +1,86 @@
+package vec
+
+import (
+	"testing"
+)
+
+// DummyOutput is exported to avoid unwanted optimizations
+var DummyOutput int
+
+// BenchmarkVec performance tests for vector operations.
+func BenchmarkVec(b *testing.B) {
+	const K = 1024
+	x, y := randSlice(K), randSlice(K)
+	dst := make([]Uint128, K)
+	mask := make([]int8, K)
+
+	// element by element
+	b.Run("Loop.Add", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := range dst {
+				dst[i] = x[i].Add(y[i])
+			}
+		}
+	})
+
+	b.Run("AddSlices", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			AddSlices(dst, x, y)
+		}
+	})
+
+	// element by element
+	b.Run("Loop.Mul", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := range dst {
+				dst[i] = x[i].Mul(y[0])
+			}
+		}
+	})
+
+	b.Run("MulScalar", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			MulScalar(dst, x, y[0])
+		}
+	})
+
+	// element by element
+	b.Run("Loop.Sum", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			var sum Uint128
+			for i := range x {
+				sum = sum.Add(x[i])
+			}
+			DummyOutput += int(sum.Big().Bit(0))
+		}
+	})
+
+	b.Run("SumWithCarry", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			sum, c := SumWithCarry(x)
+			DummyOutput += int(sum.Big().Bit(0)) + int(c)
+		}
+	})
+
+	// element by element
+	b.Run("Loop.Cmp", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := range mask {
+				mask[i] = int8(x[i].Cmp(y[i]))
+			}
+		}
+	})
+
+	b.Run("Compare", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			Compare(mask, x, y)
+		}
+	})
+
+	b.Run("MinMax", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			min, max := MinMax(x)
+			DummyOutput += int(min.Big().Bit(0)) + int(max.Big().Bit(0))
+		}
+	})
+}
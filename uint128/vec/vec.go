@@ -0,0 +1,163 @@
+// Package vec provides element-wise operations over slices of 128-bit values.
+//
+// All functions process len(dst) elements and panic if any source slice
+// is shorter than dst. The dst may be the same slice as any of the sources.
+// Arithmetic uses the same wrap-around semantic as the uint128 package.
+package vec
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// errShortSource is panic value for a source slice shorter than dst.
+var errShortSource = errors.New("vec: source slice is shorter than dst")
+
+// Uint128 is type alias for 128-bit unsigned integer.
+type Uint128 = uint128.Uint128
+
+// AddSlices sets dst[i] = x[i] + y[i].
+func AddSlices(dst, x, y []Uint128) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		var c uint64
+		dst[i].Lo, c = bits.Add64(x[i].Lo, y[i].Lo, 0)
+		dst[i].Hi, _ = bits.Add64(x[i].Hi, y[i].Hi, c)
+	}
+}
+
+// SubSlices sets dst[i] = x[i] - y[i].
+func SubSlices(dst, x, y []Uint128) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		var b uint64
+		dst[i].Lo, b = bits.Sub64(x[i].Lo, y[i].Lo, 0)
+		dst[i].Hi, _ = bits.Sub64(x[i].Hi, y[i].Hi, b)
+	}
+}
+
+// MulSlices sets dst[i] = x[i] * y[i].
+func MulSlices(dst, x, y []Uint128) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		a, b := x[i], y[i]
+		hi, lo := bits.Mul64(a.Lo, b.Lo)
+		dst[i] = Uint128{Lo: lo, Hi: hi + a.Hi*b.Lo + a.Lo*b.Hi}
+	}
+}
+
+// AddScalar sets dst[i] = x[i] + y.
+func AddScalar(dst, x []Uint128, y Uint128) {
+	if len(x) < len(dst) {
+		panic(errShortSource)
+	}
+	x = x[:len(dst)] // bounds check elimination
+	for i := range dst {
+		var c uint64
+		dst[i].Lo, c = bits.Add64(x[i].Lo, y.Lo, 0)
+		dst[i].Hi, _ = bits.Add64(x[i].Hi, y.Hi, c)
+	}
+}
+
+// SubScalar sets dst[i] = x[i] - y.
+func SubScalar(dst, x []Uint128, y Uint128) {
+	if len(x) < len(dst) {
+		panic(errShortSource)
+	}
+	x = x[:len(dst)] // bounds check elimination
+	for i := range dst {
+		var b uint64
+		dst[i].Lo, b = bits.Sub64(x[i].Lo, y.Lo, 0)
+		dst[i].Hi, _ = bits.Sub64(x[i].Hi, y.Hi, b)
+	}
+}
+
+// MulScalar sets dst[i] = x[i] * y.
+func MulScalar(dst, x []Uint128, y Uint128) {
+	if len(x) < len(dst) {
+		panic(errShortSource)
+	}
+	x = x[:len(dst)] // bounds check elimination
+	for i := range dst {
+		a := x[i]
+		hi, lo := bits.Mul64(a.Lo, y.Lo)
+		dst[i] = Uint128{Lo: lo, Hi: hi + a.Hi*y.Lo + a.Lo*y.Hi}
+	}
+}
+
+// SumWithCarry returns the sum of all x elements without overflow:
+// the total is (carry, sum), where carry is the upper 64 bits.
+func SumWithCarry(x []Uint128) (sum Uint128, carry uint64) {
+	for i := range x {
+		var c uint64
+		sum.Lo, c = bits.Add64(sum.Lo, x[i].Lo, 0)
+		sum.Hi, c = bits.Add64(sum.Hi, x[i].Hi, c)
+		carry += c
+	}
+	return
+}
+
+// MinMax returns the minimum and maximum of x elements.
+// Both are zero if x is empty.
+func MinMax(x []Uint128) (min, max Uint128) {
+	if len(x) == 0 {
+		return
+	}
+
+	min, max = x[0], x[0]
+	for _, v := range x[1:] {
+		if v.Hi < min.Hi || (v.Hi == min.Hi && v.Lo < min.Lo) {
+			min = v
+		} else if v.Hi > max.Hi || (v.Hi == max.Hi && v.Lo > max.Lo) {
+			max = v
+		}
+	}
+	return
+}
+
+// Equal sets mask dst[i] = (x[i] == y[i]).
+func Equal(dst []bool, x, y []Uint128) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		dst[i] = x[i] == y[i]
+	}
+}
+
+// Compare sets mask dst[i] to -1, 0 or +1 if x[i] is
+// less than, equal to or greater than y[i] respectively.
+func Compare(dst []int8, x, y []Uint128) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		a, b := x[i], y[i]
+		var c int8
+		if a.Hi != b.Hi {
+			c = 1
+			if a.Hi < b.Hi {
+				c = -1
+			}
+		} else if a.Lo != b.Lo {
+			c = 1
+			if a.Lo < b.Lo {
+				c = -1
+			}
+		}
+		dst[i] = c
+	}
+}
@@ -0,0 +1,122 @@
+package vec
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// randSlice generates slice of random values, some with zero halves.
+func randSlice(count int) []Uint128 {
+	buf := make([]byte, 128/8)
+	out := make([]Uint128, count)
+	for i := range out {
+		rand.Read(buf)
+		out[i] = uint128.LoadLittleEndian(buf)
+		switch i % 8 {
+		case 1:
+			out[i] = out[i].Rsh(128 / 2) // zero upper half
+		case 2:
+			out[i] = out[i-1] // duplicates
+		case 3:
+			out[i] = uint128.Max()
+		}
+	}
+	return out
+}
+
+// TestVec unit tests for vector operations.
+func TestVec(t *testing.T) {
+	const N = 1000
+	x, y := randSlice(N), randSlice(N)
+	dst := make([]Uint128, N)
+
+	check := func(t *testing.T, name string, op func(x, y Uint128) Uint128, y0 *Uint128) {
+		t.Helper()
+		for i := range dst {
+			yi := y[i]
+			if y0 != nil {
+				yi = *y0
+			}
+			if expected := op(x[i], yi); !dst[i].Equals(expected) {
+				t.Fatalf("%s[%d](%#x, %#x) should be %#x, got %#x", name, i, x[i], yi, expected, dst[i])
+			}
+		}
+	}
+
+	AddSlices(dst, x, y)
+	check(t, "AddSlices", Uint128.Add, nil)
+	SubSlices(dst, x, y)
+	check(t, "SubSlices", Uint128.Sub, nil)
+	MulSlices(dst, x, y)
+	check(t, "MulSlices", Uint128.Mul, nil)
+
+	s := y[7]
+	AddScalar(dst, x, s)
+	check(t, "AddScalar", Uint128.Add, &s)
+	SubScalar(dst, x, s)
+	check(t, "SubScalar", Uint128.Sub, &s)
+	MulScalar(dst, x, s)
+	check(t, "MulScalar", Uint128.Mul, &s)
+
+	// in-place
+	copy(dst, x)
+	AddSlices(dst, dst, y)
+	check(t, "AddSlices(in-place)", Uint128.Add, nil)
+
+	eq := make([]bool, N)
+	Equal(eq, x, y)
+	for i := range eq {
+		if expected := x[i].Equals(y[i]); eq[i] != expected {
+			t.Fatalf("Equal[%d](%#x, %#x) should be %v, got %v", i, x[i], y[i], expected, eq[i])
+		}
+	}
+
+	cmp := make([]int8, N)
+	Compare(cmp, x, y)
+	for i := range cmp {
+		if expected := x[i].Cmp(y[i]); int(cmp[i]) != expected {
+			t.Fatalf("Compare[%d](%#x, %#x) should be %v, got %v", i, x[i], y[i], expected, cmp[i])
+		}
+	}
+
+	// sum
+	expected := new(big.Int)
+	for i := range x {
+		expected.Add(expected, x[i].Big())
+	}
+	sum, carry := SumWithCarry(x)
+	got := new(big.Int).Lsh(new(big.Int).SetUint64(carry), 128)
+	got.Or(got, sum.Big())
+	if expected.Cmp(got) != 0 {
+		t.Fatalf("SumWithCarry should be %#x, got %#x", expected, got)
+	}
+
+	// min/max
+	emin, emax := x[0], x[0]
+	for _, v := range x {
+		if v.Cmp(emin) < 0 {
+			emin = v
+		}
+		if v.Cmp(emax) > 0 {
+			emax = v
+		}
+	}
+	if min, max := MinMax(x); !min.Equals(emin) || !max.Equals(emax) {
+		t.Fatalf("MinMax should be (%#x, %#x), got (%#x, %#x)", emin, emax, min, max)
+	}
+	if min, max := MinMax(nil); !min.IsZero() || !max.IsZero() {
+		t.Fatalf("MinMax(nil) should be zeros, got (%#x, %#x)", min, max)
+	}
+
+	t.Run("short", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatalf("expected panic, got nothing")
+			}
+		}()
+		AddSlices(dst, x, y[:N-1])
+	})
+}
@@ -0,0 +1,48 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package uint256
+
+import (
+	"github.com/Pilatuz/bigz/internal/cpu"
+)
+
+// The assembly implementations, see arith_amd64.s.
+func mulAsm(x, y Uint256) Uint256
+func mul512Asm(x, y Uint256) (hi, lo Uint256)
+func square512Asm(x Uint256) (hi, lo Uint256)
+
+//go:noescape
+func mulSubVWAsm(z, x []uint64, y uint64) (c uint64)
+
+// mul returns the lower 256 bits of the product (x*y).
+func mul(x, y Uint256) Uint256 {
+	if cpu.HasBMI2 && cpu.HasADX {
+		return mulAsm(x, y)
+	}
+	return mulGeneric(x, y)
+}
+
+// mul512 returns the 512-bit product (x*y).
+func mul512(x, y Uint256) (hi, lo Uint256) {
+	if cpu.HasBMI2 && cpu.HasADX {
+		return mul512Asm(x, y)
+	}
+	return mul512Generic(x, y)
+}
+
+// square512 returns the 512-bit square (x*x).
+func square512(x Uint256) (hi, lo Uint256) {
+	if cpu.HasBMI2 && cpu.HasADX {
+		return square512Asm(x)
+	}
+	return mul512Generic(x, x)
+}
+
+// mulSubVW subtracts (x*y) from z[:len(x)] and returns the carry word.
+func mulSubVW(z, x []uint64, y uint64) uint64 {
+	if cpu.HasBMI2 {
+		return mulSubVWAsm(z, x, y)
+	}
+	return mulSubVWGeneric(z, x, y)
+}
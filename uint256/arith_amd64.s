@@ -0,0 +1,220 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+#include "textflag.h"
+
+// func mulAsm(x, y Uint256) Uint256
+// requires BMI2 and ADX
+TEXT ·mulAsm(SB), NOSPLIT, $0-96
+	MOVQ y_Lo_Lo+32(FP), DX
+	MOVQ x_Hi_Hi+24(FP), BX
+	IMULQ DX, BX
+	MULXQ x_Lo_Lo+0(FP), AX, R8
+	MOVQ AX, ret_Lo_Lo+64(FP)
+	MULXQ x_Lo_Hi+8(FP), AX, R9
+	ADDQ AX, R8
+	MULXQ x_Hi_Lo+16(FP), AX, R10
+	ADCQ AX, R9
+	ADCQ BX, R10
+
+	// row 1
+	XORQ AX, AX
+	MOVQ y_Lo_Hi+40(FP), DX
+	MULXQ x_Lo_Lo+0(FP), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ x_Lo_Hi+8(FP), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ x_Hi_Lo+16(FP), AX, BX
+	ADCXQ AX, R10
+	MOVQ R8, ret_Lo_Hi+72(FP)
+
+	// row 2
+	XORQ AX, AX
+	MOVQ y_Hi_Lo+48(FP), DX
+	MULXQ x_Lo_Lo+0(FP), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ x_Lo_Hi+8(FP), AX, BX
+	ADCXQ AX, R10
+	MOVQ R9, ret_Hi_Lo+80(FP)
+
+	// row 3
+	MOVQ y_Hi_Hi+56(FP), AX
+	IMULQ x_Lo_Lo+0(FP), AX
+	ADDQ AX, R10
+	MOVQ R10, ret_Hi_Hi+88(FP)
+	RET
+
+// func mul512Asm(x, y Uint256) (hi, lo Uint256)
+// requires BMI2 and ADX
+TEXT ·mul512Asm(SB), NOSPLIT, $0-128
+	MOVQ $0, CX
+
+	// row 0: R8..R11
+	MOVQ y_Lo_Lo+32(FP), DX
+	MULXQ x_Lo_Lo+0(FP), AX, R8
+	MOVQ AX, lo_Lo_Lo+96(FP)
+	MULXQ x_Lo_Hi+8(FP), AX, R9
+	ADDQ AX, R8
+	MULXQ x_Hi_Lo+16(FP), AX, R10
+	ADCQ AX, R9
+	MULXQ x_Hi_Hi+24(FP), AX, R11
+	ADCQ AX, R10
+	ADCQ CX, R11
+
+	// row 1: R8..R12
+	XORQ R12, R12
+	MOVQ y_Lo_Hi+40(FP), DX
+	MULXQ x_Lo_Lo+0(FP), AX, BX
+	ADCXQ AX, R8
+	ADOXQ BX, R9
+	MULXQ x_Lo_Hi+8(FP), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ x_Hi_Lo+16(FP), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ x_Hi_Hi+24(FP), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	ADCXQ CX, R12
+	MOVQ R8, lo_Lo_Hi+104(FP)
+
+	// row 2: R9..R13
+	XORQ R13, R13
+	MOVQ y_Hi_Lo+48(FP), DX
+	MULXQ x_Lo_Lo+0(FP), AX, BX
+	ADCXQ AX, R9
+	ADOXQ BX, R10
+	MULXQ x_Lo_Hi+8(FP), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ x_Hi_Lo+16(FP), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ x_Hi_Hi+24(FP), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	ADCXQ CX, R13
+	MOVQ R9, lo_Hi_Lo+112(FP)
+
+	// row 3: R10..SI
+	XORQ SI, SI
+	MOVQ y_Hi_Hi+56(FP), DX
+	MULXQ x_Lo_Lo+0(FP), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ x_Lo_Hi+8(FP), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	MULXQ x_Hi_Lo+16(FP), AX, BX
+	ADCXQ AX, R12
+	ADOXQ BX, R13
+	MULXQ x_Hi_Hi+24(FP), AX, BX
+	ADCXQ AX, R13
+	ADOXQ BX, SI
+	ADCXQ CX, SI
+
+	MOVQ R10, lo_Hi_Hi+120(FP)
+	MOVQ R11, hi_Lo_Lo+64(FP)
+	MOVQ R12, hi_Lo_Hi+72(FP)
+	MOVQ R13, hi_Hi_Lo+80(FP)
+	MOVQ SI, hi_Hi_Hi+88(FP)
+	RET
+
+// func square512Asm(x Uint256) (hi, lo Uint256)
+// requires BMI2 and ADX
+TEXT ·square512Asm(SB), NOSPLIT, $0-96
+	MOVQ $0, CX
+
+	// cross products x[i]*x[j], i < j: R8..R13
+	MOVQ x_Lo_Lo+0(FP), DX
+	MULXQ x_Lo_Hi+8(FP), R8, R9
+	MULXQ x_Hi_Lo+16(FP), AX, R10
+	ADDQ AX, R9
+	MULXQ x_Hi_Hi+24(FP), AX, R11
+	ADCQ AX, R10
+	ADCQ CX, R11
+
+	XORQ R12, R12
+	MOVQ x_Lo_Hi+8(FP), DX
+	MULXQ x_Hi_Lo+16(FP), AX, BX
+	ADCXQ AX, R10
+	ADOXQ BX, R11
+	MULXQ x_Hi_Hi+24(FP), AX, BX
+	ADCXQ AX, R11
+	ADOXQ BX, R12
+	ADCXQ CX, R12
+
+	MOVQ x_Hi_Lo+16(FP), DX
+	MULXQ x_Hi_Hi+24(FP), AX, R13
+	ADDQ AX, R12
+	ADCQ CX, R13
+
+	// double the cross products: R8..SI
+	XORQ SI, SI
+	ADDQ R8, R8
+	ADCQ R9, R9
+	ADCQ R10, R10
+	ADCQ R11, R11
+	ADCQ R12, R12
+	ADCQ R13, R13
+	ADCQ CX, SI
+
+	// add the squares x[i]*x[i]
+	MOVQ x_Lo_Lo+0(FP), DX
+	MULXQ DX, AX, BX
+	MOVQ AX, lo_Lo_Lo+64(FP)
+	ADDQ BX, R8
+	MOVQ x_Lo_Hi+8(FP), DX
+	MULXQ DX, AX, BX
+	ADCQ AX, R9
+	ADCQ BX, R10
+	MOVQ x_Hi_Lo+16(FP), DX
+	MULXQ DX, AX, BX
+	ADCQ AX, R11
+	ADCQ BX, R12
+	MOVQ x_Hi_Hi+24(FP), DX
+	MULXQ DX, AX, BX
+	ADCQ AX, R13
+	ADCQ BX, SI
+
+	MOVQ R8, lo_Lo_Hi+72(FP)
+	MOVQ R9, lo_Hi_Lo+80(FP)
+	MOVQ R10, lo_Hi_Hi+88(FP)
+	MOVQ R11, hi_Lo_Lo+32(FP)
+	MOVQ R12, hi_Lo_Hi+40(FP)
+	MOVQ R13, hi_Hi_Lo+48(FP)
+	MOVQ SI, hi_Hi_Hi+56(FP)
+	RET
+
+// func mulSubVWAsm(z, x []uint64, y uint64) (c uint64)
+// requires BMI2
+TEXT ·mulSubVWAsm(SB), NOSPLIT, $0-64
+	MOVQ z_base+0(FP), DI
+	MOVQ x_base+24(FP), SI
+	MOVQ x_len+32(FP), CX
+	MOVQ y+48(FP), DX
+	XORQ BX, BX // carry word
+	XORQ R8, R8 // index
+	JMP  check
+
+loop:
+	MULXQ (SI)(R8*8), AX, R9
+	ADDQ BX, AX
+	ADCQ $0, R9
+	MOVQ (DI)(R8*8), R10
+	SUBQ AX, R10
+	ADCQ $0, R9
+	MOVQ R10, (DI)(R8*8)
+	MOVQ R9, BX
+	INCQ R8
+
+check:
+	CMPQ R8, CX
+	JLT  loop
+
+	MOVQ BX, c+56(FP)
+	RET
@@ -0,0 +1,26 @@
+//go:build amd64 && !purego && go1.18
+// +build amd64,!purego,go1.18
+
+package uint256
+
+import (
+	"testing"
+
+	"github.com/Pilatuz/bigz/internal/cpu"
+)
+
+// FuzzAsm fuzz tests assembly implementations against pure Go ones.
+func FuzzAsm(f *testing.F) {
+	if !cpu.HasBMI2 {
+		f.Skip("BMI2 is not supported")
+	}
+
+	f.Add(uint64(0), uint64(0), uint64(0), uint64(0), uint64(0), uint64(0), uint64(0), uint64(0))
+	f.Add(uint64(1), uint64(2), uint64(3), uint64(4), uint64(5), uint64(6), uint64(7), uint64(8))
+	f.Add(^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0))
+	f.Fuzz(func(t *testing.T, x0, x1, x2, x3, y0, y1, y2, y3 uint64) {
+		x := fromLimbs([4]uint64{x0, x1, x2, x3})
+		y := fromLimbs([4]uint64{y0, y1, y2, y3})
+		checkAsm(t, x, y)
+	})
+}
@@ -0,0 +1,56 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package uint256
+
+import (
+	"testing"
+
+	"github.com/Pilatuz/bigz/internal/cpu"
+)
+
+// checkAsm compares assembly and pure Go implementations.
+func checkAsm(t *testing.T, x, y Uint256) {
+	t.Helper()
+	if cpu.HasBMI2 && cpu.HasADX {
+		if expected, got := mulGeneric(x, y), mulAsm(x, y); !got.Equals(expected) {
+			t.Fatalf("mulAsm(%#x, %#x) should be %#x, got %#x", x, y, expected, got)
+		}
+		ehi, elo := mul512Generic(x, y)
+		if hi, lo := mul512Asm(x, y); !hi.Equals(ehi) || !lo.Equals(elo) {
+			t.Fatalf("mul512Asm(%#x, %#x) should be (%#x, %#x), got (%#x, %#x)", x, y, ehi, elo, hi, lo)
+		}
+		ehi, elo = mul512Generic(x, x)
+		if hi, lo := square512Asm(x); !hi.Equals(ehi) || !lo.Equals(elo) {
+			t.Fatalf("square512Asm(%#x) should be (%#x, %#x), got (%#x, %#x)", x, ehi, elo, hi, lo)
+		}
+	}
+
+	if cpu.HasBMI2 {
+		xn, yn := toLimbs(x), toLimbs(y)
+		for n := 1; n <= len(yn); n++ {
+			ez, z := xn, xn
+			ec := mulSubVWGeneric(ez[:n], yn[:n], x.Hi.Hi)
+			if c := mulSubVWAsm(z[:n], yn[:n], x.Hi.Hi); c != ec || z != ez {
+				t.Fatalf("mulSubVWAsm(%#x, %#x, %#x) should be (%#x, %#x), got (%#x, %#x)", xn[:n], yn[:n], x.Hi.Hi, ez, ec, z, c)
+			}
+		}
+	}
+}
+
+// TestAsm unit tests for assembly implementations.
+func TestAsm(t *testing.T) {
+	if !cpu.HasBMI2 {
+		t.Skip("BMI2 is not supported")
+	}
+
+	xvalues := make(chan Uint256)
+	go generate256s(300, xvalues)
+	for x := range xvalues {
+		yvalues := make(chan Uint256)
+		go generate256s(300, yvalues)
+		for y := range yvalues {
+			checkAsm(t, x, y)
+		}
+	}
+}
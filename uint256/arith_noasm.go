@@ -0,0 +1,24 @@
+//go:build !amd64 || purego
+// +build !amd64 purego
+
+package uint256
+
+// mul returns the lower 256 bits of the product (x*y).
+func mul(x, y Uint256) Uint256 {
+	return mulGeneric(x, y)
+}
+
+// mul512 returns the 512-bit product (x*y).
+func mul512(x, y Uint256) (hi, lo Uint256) {
+	return mul512Generic(x, y)
+}
+
+// square512 returns the 512-bit square (x*x).
+func square512(x Uint256) (hi, lo Uint256) {
+	return mul512Generic(x, x)
+}
+
+// mulSubVW subtracts (x*y) from z[:len(x)] and returns the carry word.
+func mulSubVW(z, x []uint64, y uint64) uint64 {
+	return mulSubVWGeneric(z, x, y)
+}
@@ -2,6 +2,7 @@ package uint256_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -35,9 +36,16 @@ func ExampleFromString() {
 	fmt.Println(u)
 	_, err := uint256.FromString("-1")
 	fmt.Println(err)
+	fmt.Println(errors.Is(err, uint256.ErrNegative), errors.Is(err, uint256.ErrRange), errors.Is(err, uint256.ErrSyntax))
+	_, err = uint256.FromString("abc")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, uint256.ErrNegative), errors.Is(err, uint256.ErrRange), errors.Is(err, uint256.ErrSyntax))
 	// Output:
 	// 1
-	// out of 256-bit range
+	// strconv.FromString: parsing "-1": value out of range: negative
+	// true true false
+	// strconv.FromString: parsing "abc": invalid syntax
+	// false false true
 }
 
 // ExampleUint256_String is an example for Uint256.String.
@@ -1,7 +1,11 @@
 package uint256
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
 	"math/big"
+	"strconv"
 	"testing"
 
 	"github.com/Pilatuz/bigz/uint128"
@@ -126,6 +130,22 @@ func BenchmarkMul(b *testing.B) {
 		}
 	})
 
+	// Mul: 256 * 256, pure Go
+	b.Run("Mul_256_256_generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hi, lo := mul512Generic(xx[i%K], yy[i%K])
+			DummyOutput += int(hi.Lo.Lo&1) + int(lo.Lo.Lo&1)
+		}
+	})
+
+	// Square: 256 * 256
+	b.Run("Square_256", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			hi, lo := Square(xx[i%K])
+			DummyOutput += int(hi.Lo.Lo&1) + int(lo.Lo.Lo&1)
+		}
+	})
+
 	// Uint256: 256 * 256
 	b.Run("Uint256_256_256", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -134,6 +154,14 @@ func BenchmarkMul(b *testing.B) {
 		}
 	})
 
+	// Uint256: 256 * 256, pure Go
+	b.Run("Uint256_256_256_generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := mulGeneric(xx[i%K], yy[i%K])
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
 	// Uint256: 256 * 128
 	b.Run("Uint256_256_128", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -235,9 +263,12 @@ func BenchmarkDiv(b *testing.B) {
 	yy := rand256slice(K)
 	xh := rand256slice(K) // 128-bit half
 	yh := rand256slice(K) // 128-bit half
+	y3 := rand256slice(K) // 192-bit
 	for i := 0; i < K; i++ {
 		xh[i].Hi = uint128.Zero()
 		yh[i].Hi = uint128.Zero()
+		y3[i].Hi.Hi = 0
+		y3[i].Hi.Lo |= 1 // avoid zero
 
 		// avoid zeros
 		if yy[i].Lo.IsZero() {
@@ -296,6 +327,30 @@ func BenchmarkDiv(b *testing.B) {
 		}
 	})
 
+	// Uint256: 256 / 192
+	b.Run("Uint256.Div_256_192", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].Div(y3[i%K])
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	// Uint256: 512 / 128
+	b.Run("Div_512_128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := Div(xh[i%K].Rsh(1), xx[i%K], yh[i%K].Or(xh[i%K]))
+			DummyOutput += int(q.Lo.Lo&1) + int(r.Lo.Lo&1)
+		}
+	})
+
+	// Uint256: 512 / 256
+	b.Run("Div_512_256", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := Div(xx[i%K].Rsh(1), yy[i%K], xx[i%K].Or(yy[i%K]))
+			DummyOutput += int(q.Lo.Lo&1) + int(r.Lo.Lo&1)
+		}
+	})
+
 	// big.Int: 256 / 128
 	b.Run("big.Int.Div_256_128", func(b *testing.B) {
 		xb := make([]*big.Int, K)
@@ -328,3 +383,459 @@ func BenchmarkDiv(b *testing.B) {
 		DummyOutput += int(q.Uint64() & 1)
 	})
 }
+
+// BenchmarkFromString performance tests for FromString.
+func BenchmarkFromString(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+	ss := make([]string, K)
+	for i := 0; i < K; i++ {
+		ss[i] = xx[i].String()
+	}
+
+	// native (just as a reference)
+	b.Run("Native_64", func(b *testing.B) {
+		s64 := make([]string, K)
+		for i := 0; i < K; i++ {
+			s64[i] = strconv.FormatUint(xx[i].Lo.Lo, 10)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			res, _ := strconv.ParseUint(s64[i%K], 10, 64)
+			DummyOutput += int(res & 1)
+		}
+	})
+
+	// Uint256: native decimal parser
+	b.Run("Uint256.FromString", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			res, _ := FromString(ss[i%K])
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	// Uint256: via fmt.Sscan and big.Int (the previous implementation)
+	b.Run("fmt.Sscan+big.Int", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			q := new(big.Int)
+			_, _ = fmt.Sscan(ss[i%K], q)
+			res, _ := FromBigEx(q)
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	// big.Int: SetString
+	b.Run("big.Int.SetString", func(b *testing.B) {
+		b.ReportAllocs()
+		q := new(big.Int)
+		for i := 0; i < b.N; i++ {
+			q, _ = q.SetString(ss[i%K], 10)
+		}
+		DummyOutput += int(q.Uint64() & 1)
+	})
+}
+
+// BenchmarkDivisor performance tests for precomputed Divisor.
+func BenchmarkDivisor(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+	yy := rand256slice(K)[0]
+	y128 := yy.Lo
+	y64 := yy.Lo.Lo | 1 // avoid zero
+	d256 := NewDivisor(yy)
+	d128 := NewDivisor128(y128)
+	d64 := NewDivisor64(y64)
+
+	// Uint256: 256 / 64
+	b.Run("Uint256.QuoRem64_256_64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := xx[i%K].QuoRem64(y64)
+			DummyOutput += int(q.Lo.Lo&1) + int(r&1)
+		}
+	})
+
+	// Divisor: 256 / 64
+	b.Run("Divisor.QuoRem_256_64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := d64.QuoRem(xx[i%K])
+			DummyOutput += int(q.Lo.Lo&1) + int(r.Lo.Lo&1)
+		}
+	})
+
+	// Uint256: 256 / 128
+	b.Run("Uint256.QuoRem128_256_128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := xx[i%K].QuoRem128(y128)
+			DummyOutput += int(q.Lo.Lo&1) + int(r.Lo&1)
+		}
+	})
+
+	// Divisor: 256 / 128
+	b.Run("Divisor.QuoRem_256_128", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := d128.QuoRem(xx[i%K])
+			DummyOutput += int(q.Lo.Lo&1) + int(r.Lo.Lo&1)
+		}
+	})
+
+	// Uint256: 256 / 256
+	b.Run("Uint256.QuoRem_256_256", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := xx[i%K].QuoRem(yy)
+			DummyOutput += int(q.Lo.Lo&1) + int(r.Lo.Lo&1)
+		}
+	})
+
+	// Divisor: 256 / 256
+	b.Run("Divisor.QuoRem_256_256", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q, r := d256.QuoRem(xx[i%K])
+			DummyOutput += int(q.Lo.Lo&1) + int(r.Lo.Lo&1)
+		}
+	})
+}
+
+// BenchmarkSet performance tests for in-place Set* methods.
+func BenchmarkSet(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+	yy := rand256slice(K)
+
+	// value: acc += x
+	b.Run("Add", func(b *testing.B) {
+		var acc Uint256
+		for i := 0; i < b.N; i++ {
+			acc = acc.Add(xx[i%K])
+		}
+		DummyOutput += int(acc.Lo.Lo & 1)
+	})
+
+	// in-place: acc += x
+	b.Run("SetAdd", func(b *testing.B) {
+		var acc Uint256
+		for i := 0; i < b.N; i++ {
+			acc.SetAdd(&acc, &xx[i%K])
+		}
+		DummyOutput += int(acc.Lo.Lo & 1)
+	})
+
+	// value: acc[i] += x[i]
+	b.Run("AddSlice", func(b *testing.B) {
+		acc := make([]Uint256, K)
+		for i := 0; i < b.N; i++ {
+			acc[i%K] = acc[i%K].Add(xx[i%K])
+		}
+		DummyOutput += int(acc[0].Lo.Lo & 1)
+	})
+
+	// in-place: acc[i] += x[i]
+	b.Run("SetAddSlice", func(b *testing.B) {
+		acc := make([]Uint256, K)
+		for i := 0; i < b.N; i++ {
+			acc[i%K].SetAdd(&acc[i%K], &xx[i%K])
+		}
+		DummyOutput += int(acc[0].Lo.Lo & 1)
+	})
+
+	// value: acc = acc*x + y
+	b.Run("MulAdd", func(b *testing.B) {
+		var acc Uint256
+		for i := 0; i < b.N; i++ {
+			acc = acc.Mul(xx[i%K]).Add(yy[i%K])
+		}
+		DummyOutput += int(acc.Lo.Lo & 1)
+	})
+
+	// in-place: acc = acc*x + y
+	b.Run("SetMulAdd", func(b *testing.B) {
+		var acc Uint256
+		for i := 0; i < b.N; i++ {
+			acc.SetMul(&acc, &xx[i%K]).SetAdd(&acc, &yy[i%K])
+		}
+		DummyOutput += int(acc.Lo.Lo & 1)
+	})
+
+	// value: acc = (acc << n) ^ x
+	b.Run("LshXor", func(b *testing.B) {
+		var acc Uint256
+		for i := 0; i < b.N; i++ {
+			acc = acc.Lsh(uint(i & 63)).Xor(xx[i%K])
+		}
+		DummyOutput += int(acc.Lo.Lo & 1)
+	})
+
+	// in-place: acc = (acc << n) ^ x
+	b.Run("SetLshXor", func(b *testing.B) {
+		var acc Uint256
+		for i := 0; i < b.N; i++ {
+			acc.SetLsh(&acc, uint(i&63)).SetXor(&acc, &xx[i%K])
+		}
+		DummyOutput += int(acc.Lo.Lo & 1)
+	})
+}
+
+// BenchmarkLoadSlice performance tests for bulk load and store functions.
+func BenchmarkLoadSlice(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+	buf := make([]byte, 32*K)
+	StoreSliceLittleEndian(buf, xx)
+
+	// per element: x[i] = LoadLittleEndian()
+	b.Run("LoadLittleEndian", func(b *testing.B) {
+		b.SetBytes(32 * K)
+		for i := 0; i < b.N; i++ {
+			for j := range xx {
+				xx[j] = LoadLittleEndian(buf[j*32:])
+			}
+		}
+		DummyOutput += int(xx[0].Lo.Lo & 1)
+	})
+
+	// bulk: LoadSliceLittleEndian()
+	b.Run("LoadSliceLittleEndian", func(b *testing.B) {
+		b.SetBytes(32 * K)
+		for i := 0; i < b.N; i++ {
+			DummyOutput += LoadSliceLittleEndian(xx, buf)
+		}
+	})
+
+	// bulk: LoadSliceBigEndian()
+	b.Run("LoadSliceBigEndian", func(b *testing.B) {
+		b.SetBytes(32 * K)
+		for i := 0; i < b.N; i++ {
+			DummyOutput += LoadSliceBigEndian(xx, buf)
+		}
+	})
+
+	// per element: StoreLittleEndian(x[i])
+	b.Run("StoreLittleEndian", func(b *testing.B) {
+		b.SetBytes(32 * K)
+		for i := 0; i < b.N; i++ {
+			for j := range xx {
+				StoreLittleEndian(buf[j*32:], xx[j])
+			}
+		}
+		DummyOutput += int(buf[0] & 1)
+	})
+
+	// bulk: StoreSliceLittleEndian()
+	b.Run("StoreSliceLittleEndian", func(b *testing.B) {
+		b.SetBytes(32 * K)
+		for i := 0; i < b.N; i++ {
+			DummyOutput += StoreSliceLittleEndian(buf, xx)
+		}
+	})
+}
+
+// BenchmarkReadWrite performance tests for io.Reader/io.Writer functions.
+func BenchmarkReadWrite(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+	buf := make([]byte, 32*K)
+	StoreSliceLittleEndian(buf, xx)
+
+	// reflection: binary.Read
+	b.Run("binary.Read", func(b *testing.B) {
+		r := bytes.NewReader(buf)
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				r.Reset(buf)
+			}
+			var res Uint256
+			_ = binary.Read(r, binary.LittleEndian, &res)
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	// direct: ReadLittleEndian
+	b.Run("ReadLittleEndian", func(b *testing.B) {
+		r := bytes.NewReader(buf)
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				r.Reset(buf)
+			}
+			res, _ := ReadLittleEndian(r)
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	// reflection: binary.Write
+	b.Run("binary.Write", func(b *testing.B) {
+		var w bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				w.Reset()
+			}
+			_ = binary.Write(&w, binary.LittleEndian, xx[i%K])
+		}
+		DummyOutput += w.Len() & 1
+	})
+
+	// direct: WriteLittleEndian
+	b.Run("WriteLittleEndian", func(b *testing.B) {
+		var w bytes.Buffer
+		for i := 0; i < b.N; i++ {
+			if i%K == 0 {
+				w.Reset()
+			}
+			_ = WriteLittleEndian(&w, xx[i%K])
+		}
+		DummyOutput += w.Len() & 1
+	})
+}
+
+// BenchmarkSetBits performance tests for set bit iteration.
+func BenchmarkSetBits(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+
+	// naive: loop over all bits
+	b.Run("Bit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			for j := uint(0); j < 256; j++ {
+				if x.Bit(j) != 0 {
+					DummyOutput += int(j)
+				}
+			}
+		}
+	})
+
+	// callback: ForEachSetBit
+	b.Run("ForEachSetBit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			xx[i%K].ForEachSetBit(func(j int) bool {
+				DummyOutput += j
+				return true
+			})
+		}
+	})
+
+	// select: NthSetBit
+	b.Run("NthSetBit", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += xx[i%K].NthSetBit(i & 127)
+		}
+	})
+}
+
+// BenchmarkPermutation performance tests for bit deposit/extract and permutations.
+func BenchmarkPermutation(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+	mm := rand256slice(K)
+
+	b.Run("DepositBits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].DepositBits(mm[i%K])
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("ExtractBits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].ExtractBits(mm[i%K])
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	src := make([]int, 256)
+	for i := range src {
+		src[i] = 255 - i
+	}
+	p, _ := NewPermutation256(src)
+
+	b.Run("Permutation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := p.Apply(xx[i%K])
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("Reverse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res := xx[i%K].Reverse()
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+}
+
+// BenchmarkCurve performance tests for space-filling curves.
+func BenchmarkCurve(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+
+	b.Run("Interleave3", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			res := Interleave3(x.Lo.Lo, x.Lo.Hi, x.Hi.Lo)
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("Interleave4", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			res := Interleave4(x.Lo.Lo, x.Lo.Hi, x.Hi.Lo, x.Hi.Hi)
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("Deinterleave4", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x, _, _, _ := Deinterleave4(xx[i%K])
+			DummyOutput += int(x & 1)
+		}
+	})
+
+	b.Run("HilbertEncode4", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			res := HilbertEncode([]uint64{x.Lo.Lo, x.Lo.Hi, x.Hi.Lo, x.Hi.Hi}, 64)
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+
+	b.Run("MortonRanges4", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := xx[i%K]
+			min := [4]uint64{x.Lo.Lo >> 1, x.Lo.Hi >> 1, x.Hi.Lo >> 1, x.Hi.Hi >> 1}
+			max := [4]uint64{min[0] + 1000, min[1] + 1000, min[2] + 1000, min[3] + 1000}
+			DummyOutput += len(MortonRanges4(min, max, 64))
+		}
+	})
+}
+
+// BenchmarkFloat performance tests for float conversions.
+func BenchmarkFloat(b *testing.B) {
+	const K = 1024 // should be power of 2
+	xx := rand256slice(K)
+	ff := make([]float64, K)
+	for i, x := range xx {
+		ff[i] = x.Float64()
+	}
+
+	b.Run("Float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DummyOutput += int(xx[i%K].Float64()) & 1
+		}
+	})
+
+	b.Run("Big.Float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f, _ := new(big.Float).SetInt(xx[i%K].Big()).Float64()
+			DummyOutput += int(f) & 1
+		}
+	})
+
+	b.Run("FromFloat64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			res, _ := FromFloat64(ff[i%K])
+			DummyOutput += int(res.Lo.Lo & 1)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package uint256
 
 import (
 	"errors"
+	"math"
 	"math/big"
 	"math/bits"
 
@@ -269,6 +270,18 @@ func (u Uint256) Sub128(v Uint128) Uint256 {
 // with the product bits' upper half returned in hi and the lower
 // half returned in lo.
 func Mul(x, y Uint256) (hi, lo Uint256) {
+	return mul512(x, y)
+}
+
+// Square returns the 512-bit square of x: (hi, lo) = x * x
+// with the product bits' upper half returned in hi and the lower
+// half returned in lo.
+func Square(x Uint256) (hi, lo Uint256) {
+	return square512(x)
+}
+
+// mul512Generic is pure Go implementation of Mul.
+func mul512Generic(x, y Uint256) (hi, lo Uint256) {
 	lo.Hi, lo.Lo = uint128.Mul(x.Lo, y.Lo)
 	hi.Hi, hi.Lo = uint128.Mul(x.Hi, y.Hi)
 	t0, t1 := uint128.Mul(x.Lo, y.Hi)
@@ -287,6 +300,11 @@ func Mul(x, y Uint256) (hi, lo Uint256) {
 // Mul returns multiplication (u*v) of two 256-bit values.
 // Wrap-around semantic is used here: Max().Mul(Max()) == From64(1).
 func (u Uint256) Mul(v Uint256) Uint256 {
+	return mul(u, v)
+}
+
+// mulGeneric is pure Go implementation of Uint256.Mul.
+func mulGeneric(u, v Uint256) Uint256 {
 	hi, lo := uint128.Mul(u.Lo, v.Lo)
 	hi = hi.Add(u.Hi.Mul(v.Lo))
 	hi = hi.Add(u.Lo.Mul(v.Hi))
@@ -345,38 +363,37 @@ func (u Uint256) QuoRem(v Uint256) (Uint256, Uint256) {
 		q, r := u.QuoRem128(v.Lo)
 		return q, From128(r)
 	}
-
-	// generate a "trial quotient," guaranteed to be
-	// within 1 of the actual quotient, then adjust.
-	n := uint(v.Hi.LeadingZeros())
-	u1, v1 := u.Rsh(1), v.Lsh(n)
-	tq, _ := uint128.Div(u1.Hi, u1.Lo, v1.Hi)
-	tq = tq.Rsh(127 - n)
-	if !tq.IsZero() {
-		tq = tq.Sub64(1)
-	}
-
-	// calculate remainder using trial quotient, then
-	// adjust if remainder is greater than divisor
-	q, r := From128(tq), u.Sub(v.Mul128(tq))
-	if r.Cmp(v) >= 0 {
-		q = q.Add128(uint128.One())
-		r = r.Sub(v)
+	if u.Cmp(v) < 0 {
+		return Zero(), u
 	}
 
-	return q, r
+	return quoRemKnuth(u, v)
 }
 
 // QuoRem128 returns quotient (u/v) and remainder (u%v) of 256-bit and 128-bit values.
 func (u Uint256) QuoRem128(v Uint128) (Uint256, Uint128) {
-	if u.Hi.Cmp(v) < 0 {
-		lo, r := uint128.Div(u.Hi, u.Lo, v)
-		return Uint256{Lo: lo}, r
+	if v.Hi == 0 {
+		q, r := u.QuoRem64(v.Lo)
+		return q, uint128.From64(r)
+	}
+	if u.Hi.IsZero() {
+		q, r := u.Lo.QuoRem(v)
+		return From128(q), r
 	}
 
-	hi, r := uint128.Div(uint128.Zero(), u.Hi, v)
-	lo, r := uint128.Div(r, u.Lo, v)
-	return Uint256{Lo: lo, Hi: hi}, r
+	// normalized dividend is 5 limbs, the quotient digits
+	// are computed by 3-by-2 division using the reciprocal
+	s := uint(bits.LeadingZeros64(v.Hi))
+	un := normalize(u, s)
+	dn := v.Lsh(s)
+	rv := reciprocal3by2(dn.Hi, dn.Lo)
+
+	var q Uint256
+	r1, r0 := un[4], un[3]
+	q.Hi.Lo, r1, r0 = div3by2(r1, r0, un[2], dn.Hi, dn.Lo, rv)
+	q.Lo.Hi, r1, r0 = div3by2(r1, r0, un[1], dn.Hi, dn.Lo, rv)
+	q.Lo.Lo, r1, r0 = div3by2(r1, r0, un[0], dn.Hi, dn.Lo, rv)
+	return q, Uint128{Lo: r0, Hi: r1}.Rsh(s)
 }
 
 // QuoRem64 returns quotient (u/v) and remainder (u%v) of 256-bit and 64-bit values.
@@ -399,37 +416,124 @@ func Div(hi, lo, y Uint256) (quo, rem Uint256) {
 		panic(errors.New("integer overflow"))
 	}
 
-	s := uint(y.LeadingZeros())
-	y = y.Lsh(s)
-
-	un32 := hi.Lsh(s).Or(lo.Rsh(256 - s))
-	un10 := lo.Lsh(s)
-	q1, rhat := un32.QuoRem128(y.Hi)
-	r1 := From128(rhat)
+	yn := toLimbs(y)
+	n := len(yn)
+	for yn[n-1] == 0 {
+		n--
+	}
 
-	for !q1.Hi.IsZero() || q1.Mul128(y.Lo).Cmp(Uint256{Hi: r1.Lo, Lo: un10.Hi}) > 0 {
-		q1 = q1.Sub128(uint128.One())
-		r1 = r1.Add128(y.Hi)
-		if !r1.Hi.IsZero() {
-			break
+	var q [4]uint64
+	if n == 1 {
+		// hi < y, so hi is a single limb
+		x, r := toLimbs(lo), hi.Lo.Lo
+		for i := 3; i >= 0; i-- {
+			q[i], r = bits.Div64(r, x[i], y.Lo.Lo)
 		}
+		return fromLimbs(q), From64(r)
+	}
+
+	// normalized dividend is 8 limbs (hi, lo)<<s, the limbs above
+	// 4+n are zero since hi < y, so the quotient fits 4 limbs
+	s := uint(bits.LeadingZeros64(yn[n-1]))
+	ln, hn := normalize(lo, s), normalize(hi, s)
+	var un [8]uint64
+	copy(un[:4], ln[:4])
+	un[4] = ln[4] | hn[0]
+	copy(un[5:], hn[1:])
+
+	dn := toLimbs(y.Lsh(s))
+	divKnuth(q[:], un[:4+n], dn[:n], reciprocal3by2(dn[n-1], dn[n-2]))
+	return fromLimbs(q), denormalize(un[:n+1], s)
+}
+
+// quoRemKnuth returns quotient (u/v) and remainder (u%v) of two 256-bit values,
+// the divisor v must have at least two significant 64-bit limbs.
+func quoRemKnuth(u, v Uint256) (Uint256, Uint256) {
+	vn := toLimbs(v)
+	n := len(vn)
+	for vn[n-1] == 0 {
+		n--
+	}
+
+	s := uint(bits.LeadingZeros64(vn[n-1]))
+	un := normalize(u, s)
+	dn := toLimbs(v.Lsh(s))
+
+	var q [4]uint64
+	divKnuth(q[:5-n], un[:], dn[:n], reciprocal3by2(dn[n-1], dn[n-2]))
+	return fromLimbs(q), denormalize(un[:n+1], s)
+}
+
+// divKnuth divides normalized dividend un by normalized divisor dn using
+// Knuth's algorithm D (TAOCP vol. 2, 4.3.1), all values are little-endian limbs.
+// The divisor must have at least two limbs and its top limb must have the
+// highest bit set, v is the 3-by-2 reciprocal of its top two limbs.
+// The top len(dn) limbs of un must be less than dn.
+// The len(un)-len(dn) quotient limbs are stored to q and the normalized
+// remainder is left in the lower len(dn) limbs of un.
+func divKnuth(q, un, dn []uint64, v uint64) {
+	n := len(dn)
+	d1, d0 := dn[n-1], dn[n-2]
+	if n == 2 {
+		// 3-by-2 division gives exact quotient digit and remainder
+		r1, r0 := un[len(un)-1], un[len(un)-2]
+		for j := len(un) - 3; j >= 0; j-- {
+			q[j], r1, r0 = div3by2(r1, r0, un[j], d1, d0, v)
+		}
+		un[0], un[1], un[2] = r0, r1, 0
+		return
 	}
 
-	un21 := Uint256{Hi: un32.Lo, Lo: un10.Hi}.Sub(q1.Mul(y))
-	q0, rhat := un21.QuoRem128(y.Hi)
-	r0 := From128(rhat)
+	for j := len(un) - n - 1; j >= 0; j-- {
+		u2, u1, u0 := un[j+n], un[j+n-1], un[j+n-2]
 
-	for !q0.Hi.IsZero() || q0.Mul128(y.Lo).Cmp(Uint256{Hi: r0.Lo, Lo: un10.Lo}) > 0 {
-		q0 = q0.Sub128(uint128.One())
-		r0 = r0.Add128(y.Hi)
-		if !r0.Hi.IsZero() {
-			break
+		// estimate quotient digit by the top three dividend limbs
+		// and the top two divisor limbs: the estimate is at most one too large
+		qhat := uint64(math.MaxUint64)
+		if u2 != d1 || u1 != d0 {
+			qhat, _, _ = div3by2(u2, u1, u0, d1, d0, v)
+		}
+		var c uint64
+
+		// multiply and subtract
+		var borrow uint64
+		c = mulSubVW(un[j:j+n], dn, qhat)
+		un[j+n], borrow = bits.Sub64(un[j+n], c, 0)
+
+		// add back (rare)
+		if borrow != 0 {
+			qhat--
+			c = 0
+			for i := 0; i < n; i++ {
+				un[j+i], c = bits.Add64(un[j+i], dn[i], c)
+			}
+			un[j+n] += c
 		}
+
+		q[j] = qhat
+	}
+}
+
+// mulSubVWGeneric is pure Go implementation of mulSubVW.
+func mulSubVWGeneric(z, x []uint64, y uint64) (c uint64) {
+	for i, xi := range x {
+		hi, lo := bits.Mul64(xi, y)
+		var cc uint64
+		lo, cc = bits.Add64(lo, c, 0)
+		hi += cc
+		z[i], cc = bits.Sub64(z[i], lo, 0)
+		c = hi + cc
 	}
+	return c
+}
 
-	return Uint256{Hi: q1.Lo, Lo: q0.Lo},
-		Uint256{Hi: un21.Lo, Lo: un10.Lo}.
-			Sub(q0.Mul(y)).Rsh(s)
+// denormalize returns len(un)-1 lower limbs shifted right by s bits.
+func denormalize(un []uint64, s uint) (r Uint256) {
+	var x [4]uint64
+	for i := 0; i < len(un)-1; i++ {
+		x[i] = un[i]>>s | un[i+1]<<(64-s) // zero if s == 0
+	}
+	return fromLimbs(x)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,338 @@
+package uint256
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// digits is the alphabet used for text conversions in bases 2..62.
+// For bases up to 36 lower-case letters are used (the same as strconv does),
+// for bases above 36 upper-case letters represent digit values 36..61.
+const digits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// MaxBase is the largest base supported by FormatBase, AppendBase and ParseBase.
+const MaxBase = uint128.MaxBase
+
+// bigBase is the largest power of a base that fits into uint64
+// and the number of digits in that power, i.e. base^ndigits.
+type bigBase struct {
+	b       uint64 // base^ndigits
+	ndigits int    // number of digits
+}
+
+// bigBases is the table of the largest powers for each base 2..62.
+var bigBases = func() (out [MaxBase + 1]bigBase) {
+	for base := uint64(2); base <= uint64(MaxBase); base++ {
+		b, n := base, 1
+		for {
+			hi, lo := bits.Mul64(b, base)
+			if hi != 0 {
+				break
+			}
+			b, n = lo, n+1
+		}
+		out[base] = bigBase{b: b, ndigits: n}
+	}
+	return
+}()
+
+// FormatBase returns the string representation of 256-bit value in the given base,
+// for 2 <= base <= 62. The result uses the lower-case letters 'a' to 'z'
+// for digit values 10 to 35, and the upper-case letters 'A' to 'Z'
+// for digit values 36 to 61. It panics if base is out of range.
+func FormatBase(u Uint256, base int) string {
+	var buf [256]byte // base 2 is the longest
+	return string(AppendBase(buf[:0], u, base))
+}
+
+// AppendBase appends the string form of 256-bit value in the given base,
+// as generated by FormatBase, to dst and returns the extended buffer.
+func AppendBase(dst []byte, u Uint256, base int) []byte {
+	if base < 2 || base > MaxBase {
+		panic(errors.New("uint256: illegal AppendBase/FormatBase base"))
+	}
+
+	if u.Hi.IsZero() {
+		return uint128.AppendBase(dst, u.Lo, base) // lower 128-bit
+	}
+
+	return appendDigits(dst, u, digits[:base], 0)
+}
+
+// ParseBase interprets a string s in the given base (0, 2 to 62)
+// and returns the corresponding 256-bit value.
+//
+// The conventions of strconv.ParseUint are followed: if base is zero,
+// the base is implied by the string's prefix ("0b", "0o", "0x" or "0"),
+// and underscores are permitted as digit separators. For bases up to 36
+// letters are case-insensitive, for bases above 36 the upper-case letters
+// represent digit values 36 to 61.
+//
+// The errors that ParseBase returns have concrete type *strconv.NumError.
+// If s is empty or contains invalid digits, err.Err = ErrSyntax
+// and the returned value is zero; if the value corresponding to s cannot be
+// represented by 256 bits, err.Err = ErrRange and the returned value is Max.
+func ParseBase(s string, base int) (Uint256, error) {
+	return parseBase("ParseBase", s, base)
+}
+
+// parseBase is the implementation of ParseBase, fn is used for error reporting.
+func parseBase(fn string, s string, base int) (Uint256, error) {
+	if s == "" {
+		return Zero(), syntaxError(fn, s)
+	}
+
+	base0 := base == 0
+	s0 := s
+	switch {
+	case 2 <= base && base <= MaxBase:
+		// valid base, nothing to do
+	case base == 0:
+		base, s = detectBase(s)
+	default:
+		return Zero(), baseError(fn, s0, base)
+	}
+
+	// decimal numbers are the most common case
+	if base == 10 && (!base0 || strings.IndexByte(s, '_') < 0) {
+		return parseDecimal(fn, s)
+	}
+
+	bb := bigBases[base]
+	var u Uint256
+	var acc uint64 // accumulated chunk
+	var n int      // number of digits in chunk
+	underscores := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '_' && base0 {
+			underscores = true
+			continue
+		}
+		d := digitValue(c, base)
+		if d >= uint64(base) {
+			return Zero(), syntaxError(fn, s0)
+		}
+
+		acc = acc*uint64(base) + d
+		if n++; n == bb.ndigits {
+			var carry uint64
+			if u, carry = mulAdd64(u, bb.b, acc); carry != 0 {
+				return Max(), rangeError(fn, s0)
+			}
+			acc, n = 0, 0
+		}
+	}
+	if n != 0 {
+		m := uint64(1)
+		for ; n != 0; n-- {
+			m *= uint64(base)
+		}
+		var carry uint64
+		if u, carry = mulAdd64(u, m, acc); carry != 0 {
+			return Max(), rangeError(fn, s0)
+		}
+	}
+
+	if underscores && !underscoreOK(s0) {
+		return Zero(), syntaxError(fn, s0)
+	}
+
+	return u, nil
+}
+
+// pow10 contains powers of ten that fit into uint64.
+var pow10 = [...]uint64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+}
+
+// parseDecimal parses a string of decimal digits (no prefix, no underscores).
+// Digits are processed in 19-digit chunks, each chunk is accumulated
+// in uint64 and then merged into the result with a single multiplication.
+// The overflow is detected exactly, no allocations are made unless error.
+func parseDecimal(fn string, s string) (Uint256, error) {
+	if s == "" {
+		return Zero(), syntaxError(fn, s)
+	}
+
+	var u Uint256
+	for i := 0; i < len(s); {
+		n := len(s) - i
+		if n > 19 {
+			n = 19 // largest power of 10 that fits in a uint64
+		}
+
+		var acc uint64
+		for _, c := range []byte(s[i : i+n]) {
+			d := c - '0'
+			if d > 9 {
+				return Zero(), syntaxError(fn, s)
+			}
+			acc = acc*10 + uint64(d)
+		}
+
+		var carry uint64
+		if u, carry = mulAdd64(u, pow10[n], acc); carry != 0 {
+			return Max(), rangeError(fn, s)
+		}
+		i += n
+	}
+
+	return u, nil
+}
+
+// appendDigits appends digits of 256-bit value to dst. The base is defined by
+// the alphabet length, the result is padded with alphabet[0] to width digits.
+func appendDigits(dst []byte, u Uint256, alphabet string, width int) []byte {
+	var buf [256]byte // base 2 is the longest
+	base := uint64(len(alphabet))
+	bb := bigBases[base]
+	i := len(buf)
+	for {
+		q, r := u.QuoRem64(bb.b)
+		var n int
+		for ; r != 0; r /= base {
+			i--
+			n++
+			buf[i] = alphabet[r%base]
+		}
+		if q.IsZero() {
+			break
+		}
+		for ; n < bb.ndigits; n++ {
+			i--
+			buf[i] = alphabet[0] // leading zeros of the inner chunk
+		}
+		u = q
+	}
+	for i == len(buf) || len(buf)-i < width {
+		i--
+		buf[i] = alphabet[0] // at least one digit
+	}
+	return append(dst, buf[i:]...)
+}
+
+// mulAdd64 returns u*m+a and the 64-bit overflow word.
+func mulAdd64(u Uint256, m, a uint64) (Uint256, uint64) {
+	h0, l0 := bits.Mul64(u.Lo.Lo, m)
+	h1, l1 := bits.Mul64(u.Lo.Hi, m)
+	h2, l2 := bits.Mul64(u.Hi.Lo, m)
+	h3, l3 := bits.Mul64(u.Hi.Hi, m)
+
+	var c uint64
+	l0, c = bits.Add64(l0, a, 0)
+	l1, c = bits.Add64(l1, h0, c)
+	l2, c = bits.Add64(l2, h1, c)
+	l3, c = bits.Add64(l3, h2, c)
+	return Uint256{
+		Lo: Uint128{Lo: l0, Hi: l1},
+		Hi: Uint128{Lo: l2, Hi: l3},
+	}, h3 + c
+}
+
+// detectBase detects base by the string's prefix and returns the rest of string.
+// The string is expected to be non-empty.
+func detectBase(s string) (int, string) {
+	if s[0] != '0' {
+		return 10, s
+	}
+
+	if len(s) >= 3 {
+		switch lower(s[1]) {
+		case 'b':
+			return 2, s[2:]
+		case 'o':
+			return 8, s[2:]
+		case 'x':
+			return 16, s[2:]
+		}
+	}
+
+	return 8, s[1:]
+}
+
+// digitValue returns the value of a digit character in the given base.
+// The result is greater or equal to base if the character is not a valid digit.
+func digitValue(c byte, base int) uint64 {
+	switch {
+	case '0' <= c && c <= '9':
+		return uint64(c - '0')
+	case 'a' <= c && c <= 'z':
+		return uint64(c-'a') + 10
+	case 'A' <= c && c <= 'Z':
+		if base <= 36 {
+			return uint64(c-'A') + 10 // case insensitive
+		}
+		return uint64(c-'A') + 36
+	}
+	return uint64(MaxBase) // invalid digit
+}
+
+// lower returns the lower-case ASCII letter.
+func lower(c byte) byte {
+	return c | ('x' - 'X')
+}
+
+// underscoreOK reports whether the underscores in s are allowed.
+// Underscore must appear only between digits or between a base prefix and a digit.
+func underscoreOK(s string) bool {
+	// saw tracks the last character (class) we saw:
+	// ^ for beginning of number,
+	// 0 for a digit or base prefix,
+	// _ for an underscore,
+	// ! for none of the above.
+	saw := '^'
+	i := 0
+
+	// optional base prefix
+	hex := false
+	if len(s) >= 2 && s[0] == '0' && (lower(s[1]) == 'b' || lower(s[1]) == 'o' || lower(s[1]) == 'x') {
+		i = 2
+		saw = '0' // base prefix counts as a digit for "underscore as digit separator"
+		hex = lower(s[1]) == 'x'
+	}
+
+	// number proper
+	for ; i < len(s); i++ {
+		// digits are always okay
+		if '0' <= s[i] && s[i] <= '9' || hex && 'a' <= lower(s[i]) && lower(s[i]) <= 'f' {
+			saw = '0'
+			continue
+		}
+		// underscore must follow digit
+		if s[i] == '_' {
+			if saw != '0' {
+				return false
+			}
+			saw = '_'
+			continue
+		}
+		// underscore must also be followed by digit
+		if saw == '_' {
+			return false
+		}
+		// saw non-digit, non-underscore
+		saw = '!'
+	}
+	return saw != '_'
+}
+
+// syntaxError creates *strconv.NumError for invalid syntax.
+func syntaxError(fn, str string) *strconv.NumError {
+	return &strconv.NumError{Func: fn, Num: str, Err: ErrSyntax}
+}
+
+// rangeError creates *strconv.NumError for out of range values.
+func rangeError(fn, str string) *strconv.NumError {
+	return &strconv.NumError{Func: fn, Num: str, Err: ErrRange}
+}
+
+// baseError creates *strconv.NumError for invalid base.
+func baseError(fn, str string, base int) *strconv.NumError {
+	return &strconv.NumError{Func: fn, Num: str, Err: errors.New("invalid base " + strconv.Itoa(base))}
+}
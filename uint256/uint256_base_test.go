@@ -0,0 +1,153 @@
+package uint256
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// TestFormatBase unit tests for FormatBase and AppendBase functions.
+func TestFormatBase(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		if expected, got := "0", FormatBase(Zero(), 62); got != expected {
+			t.Fatalf("FormatBase(0, 62) should be %q, got %q", expected, got)
+		}
+		if expected, got := "YHJSKWDa6oz1al1yMhwzwM8llg7hJNUca2J5RoW8xP1", FormatBase(Max(), 62); got != expected {
+			t.Fatalf("FormatBase(Max, 62) should be %q, got %q", expected, got)
+		}
+		if expected, got := "6dp5qcb22im238nr3wvp0ic7q99w035jmy2iw7i6n43d37jtof", FormatBase(Max(), 36); got != expected {
+			t.Fatalf("FormatBase(Max, 36) should be %q, got %q", expected, got)
+		}
+		if expected, got := "id=1z", string(AppendBase([]byte("id="), From64(71), 36)); got != expected {
+			t.Fatalf("AppendBase(71, 36) should be %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("bad_base", func(t *testing.T) {
+		for _, base := range []int{-1, 0, 1, 63} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Fatalf("FormatBase(%d) expected panic, got nothing", base)
+					}
+				}()
+				FormatBase(One(), base)
+			}()
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(100, values)
+		for x := range values {
+			for base := 2; base <= MaxBase; base++ {
+				if expected, got := x.Big().Text(base), FormatBase(x, base); got != expected {
+					t.Fatalf("FormatBase(%#x, %d) mismatch:\n\t(-) expected %q\n\t(+)   actual %q", x, base, expected, got)
+				}
+			}
+		}
+	})
+}
+
+// TestParseBase unit tests for ParseBase function.
+func TestParseBase(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		tests := []struct {
+			s    string
+			base int
+			u    Uint256
+			err  error
+		}{
+			{"", 10, Zero(), strconv.ErrSyntax},
+			{"0", 0, Zero(), nil},
+			{"0x_ff", 0, From64(255), nil},
+			{"0XFF", 0, From64(255), nil},
+			{"0b1010", 0, From64(10), nil},
+			{"0o17", 0, From64(15), nil},
+			{"017", 0, From64(15), nil},
+			{"1_000_000", 0, From64(1000000), nil},
+			{"1__0", 0, Zero(), strconv.ErrSyntax},
+			{"_10", 0, Zero(), strconv.ErrSyntax},
+			{"10_", 0, Zero(), strconv.ErrSyntax},
+			{"1_0", 10, Zero(), strconv.ErrSyntax},
+			{"0x", 0, Zero(), strconv.ErrSyntax},
+			{"+1", 10, Zero(), strconv.ErrSyntax},
+			{"-1", 10, Zero(), strconv.ErrSyntax},
+			{"12", 2, Zero(), strconv.ErrSyntax},
+			{"1Z", 36, From64(71), nil},
+			{"1z", 36, From64(71), nil},
+			{"1Z", 62, From64(123), nil},
+			{"YHJSKWDa6oz1al1yMhwzwM8llg7hJNUca2J5RoW8xP1", 62, Max(), nil},
+			{"YHJSKWDa6oz1al1yMhwzwM8llg7hJNUca2J5RoW8xP2", 62, Max(), strconv.ErrRange},
+			{"115792089237316195423570985008687907853269984665640564039457584007913129639935", 10, Max(), nil},
+			{"115792089237316195423570985008687907853269984665640564039457584007913129639936", 10, Max(), strconv.ErrRange},
+			{"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 0, Max(), nil},
+			{"0x1_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000_0000", 0, Max(), strconv.ErrRange},
+		}
+
+		for _, tt := range tests {
+			u, err := ParseBase(tt.s, tt.base)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseBase(%q, %d) unexpected error: %v", tt.s, tt.base, err)
+			}
+			if !u.Equals(tt.u) {
+				t.Fatalf("ParseBase(%q, %d) should be %#x, got %#x", tt.s, tt.base, tt.u, u)
+			}
+		}
+	})
+
+	t.Run("bad_base", func(t *testing.T) {
+		_, err := ParseBase("1", 63)
+		if ne, ok := err.(*strconv.NumError); !ok || ne.Err.Error() != "invalid base 63" {
+			t.Fatalf("ParseBase(%q, 63) unexpected error: %v", "1", err)
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(100, values)
+		for x := range values {
+			for base := 2; base <= MaxBase; base++ {
+				if got, err := ParseBase(x.Big().Text(base), base); err != nil {
+					t.Fatalf("ParseBase(%#x, %d) failed: %v", x, base, err)
+				} else if !got.Equals(x) {
+					t.Fatalf("ParseBase(%#x, %d) mismatch, got %#x", x, base, got)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkFormatBase performance tests for FormatBase and ParseBase functions.
+func BenchmarkFormatBase(b *testing.B) {
+	b.ReportAllocs()
+
+	x := rand256()
+	xb := x.Big()
+	s := FormatBase(x, 36)
+	buf := make([]byte, 0, 256)
+
+	b.Run("AppendBase", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = AppendBase(buf[:0], x, 36)
+		}
+	})
+
+	b.Run("big.Int.Append", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf = xb.Append(buf[:0], 36)
+		}
+	})
+
+	b.Run("ParseBase", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseBase(s, 36)
+		}
+	})
+
+	b.Run("big.Int.SetString", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = xb.SetString(s, 36)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package uint256
+
+import (
+	"github.com/Pilatuz/bigz/uint128"
+)
+
+// The bit accessors below never panic: bits at index 256 and above
+// are read as zero and are silently dropped on write.
+
+// Mask returns the value with the lower n bits set.
+// The result is Max() if n >= 256.
+func Mask(n uint) Uint256 {
+	if n >= 256 {
+		return Max()
+	}
+	return Max().Rsh(256 - n)
+}
+
+// bitAt returns the value with only bit i set.
+// The result is zero if i >= 256.
+func bitAt(i uint) Uint256 {
+	if i < 128 {
+		return Uint256{Lo: uint128.One().Lsh(i)}
+	}
+	return Uint256{Hi: uint128.One().Lsh(i - 128)} // zero if i >= 256
+}
+
+// Bit returns the value of the i-th bit, 0 or 1.
+// The result is 0 if i >= 256.
+func (u Uint256) Bit(i uint) uint {
+	if i < 128 {
+		return u.Lo.Bit(i)
+	}
+	return u.Hi.Bit(i - 128) // zero if i >= 256
+}
+
+// SetBit returns the value with the i-th bit set to b, which must be 0 or 1.
+// The value is unchanged if i >= 256.
+func (u Uint256) SetBit(i uint, b uint) Uint256 {
+	if b&1 != 0 {
+		return u.Or(bitAt(i))
+	}
+	return u.AndNot(bitAt(i))
+}
+
+// ClearBit returns the value with the i-th bit cleared.
+// The value is unchanged if i >= 256.
+func (u Uint256) ClearBit(i uint) Uint256 {
+	return u.AndNot(bitAt(i))
+}
+
+// FlipBit returns the value with the i-th bit inverted.
+// The value is unchanged if i >= 256.
+func (u Uint256) FlipBit(i uint) Uint256 {
+	return u.Xor(bitAt(i))
+}
+
+// Extract returns the bit field of given width starting at bit offset,
+// i.e. (u >> offset) & Mask(width). Bits beyond 256 are read as zero.
+func (u Uint256) Extract(offset, width uint) Uint256 {
+	return u.Rsh(offset).And(Mask(width))
+}
+
+// Extract64 returns the bit field of given width starting at bit offset
+// as uint64. The width is limited to 64 bits.
+func (u Uint256) Extract64(offset, width uint) uint64 {
+	if width > 64 {
+		width = 64
+	}
+	return u.Extract(offset, width).Lo.Lo
+}
+
+// Insert returns the value with the bit field of given width starting at
+// bit offset replaced by the lower width bits of v.
+// Bits beyond 256 are dropped.
+func (u Uint256) Insert(offset, width uint, v Uint256) Uint256 {
+	m := Mask(width).Lsh(offset)
+	return u.AndNot(m).Or(v.Lsh(offset).And(m))
+}
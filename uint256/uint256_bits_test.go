@@ -0,0 +1,84 @@
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestBits unit tests for bit accessors.
+func TestBits(t *testing.T) {
+	one := big.NewInt(1)
+	bigMask := func(n uint) *big.Int {
+		if n > 256 {
+			n = 256
+		}
+		m := new(big.Int).Lsh(one, n)
+		return m.Sub(m, one)
+	}
+	max := Max().Big()
+
+	for n := uint(0); n <= 200; n++ {
+		if got, expected := Mask(n), FromBig(bigMask(n)); got != expected {
+			t.Fatalf("Mask(%d) should be %#x, got %#x", n, expected, got)
+		}
+	}
+
+	values := make(chan Uint256)
+	go generate256s(100, values)
+	for x := range values {
+		bx := x.Big()
+		for i := uint(0); i < 270; i++ {
+			var expected uint
+			if i < 256 {
+				expected = bx.Bit(int(i))
+			}
+			if got := x.Bit(i); got != expected {
+				t.Fatalf("%#x.Bit(%d) should be %d, got %d", x, i, expected, got)
+			}
+
+			for _, b := range []uint{0, 1} {
+				e := new(big.Int).SetBit(bx, int(i), b)
+				e.And(e, max)
+				if got := x.SetBit(i, b); got.Big().Cmp(e) != 0 {
+					t.Fatalf("%#x.SetBit(%d, %d) should be %#x, got %#x", x, i, b, e, got)
+				}
+			}
+			if got, expected := x.ClearBit(i), x.SetBit(i, 0); got != expected {
+				t.Fatalf("%#x.ClearBit(%d) should be %#x, got %#x", x, i, expected, got)
+			}
+			if got, expected := x.FlipBit(i), x.SetBit(i, x.Bit(i)^1); got != expected {
+				t.Fatalf("%#x.FlipBit(%d) should be %#x, got %#x", x, i, expected, got)
+			}
+		}
+
+		for _, offset := range []uint{0, 1, 7, 63, 64, 65, 100, 127, 128, 200, 255, 256, 300} {
+			for _, width := range []uint{0, 1, 8, 63, 64, 65, 127, 128, 129, 255, 256, 400} {
+				e := new(big.Int).Rsh(bx, offset)
+				e.And(e, bigMask(width))
+				if got := x.Extract(offset, width); got.Big().Cmp(e) != 0 {
+					t.Fatalf("%#x.Extract(%d, %d) should be %#x, got %#x", x, offset, width, e, got)
+				}
+				if got, expected := x.Extract64(offset, width), e.Uint64(); got != expected {
+					t.Fatalf("%#x.Extract64(%d, %d) should be %#x, got %#x", x, offset, width, expected, got)
+				}
+
+				// insert reversed value and extract it back
+				v := x.Reverse()
+				m := new(big.Int).Lsh(bigMask(width), offset)
+				e = new(big.Int).Lsh(v.Big(), offset)
+				e.And(e, m)
+				e.Or(e, new(big.Int).AndNot(bx, m))
+				e.And(e, max)
+				got := x.Insert(offset, width, v)
+				if got.Big().Cmp(e) != 0 {
+					t.Fatalf("%#x.Insert(%d, %d, %#x) should be %#x, got %#x", x, offset, width, v, e, got)
+				}
+				if offset < 256 && width+offset <= 256 {
+					if back := got.Extract(offset, width); back != v.And(Mask(width)) {
+						t.Fatalf("%#x.Insert(%d, %d, %#x).Extract() should be %#x, got %#x", x, offset, width, v, v.And(Mask(width)), back)
+					}
+				}
+			}
+		}
+	}
+}
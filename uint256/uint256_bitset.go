@@ -0,0 +1,140 @@
+package uint256
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// ForEachSetBit calls fn for each set bit index in ascending order.
+// The iteration stops if fn returns false.
+func (u Uint256) ForEachSetBit(fn func(i int) bool) {
+	for w, x := range [4]uint64{u.Lo.Lo, u.Lo.Hi, u.Hi.Lo, u.Hi.Hi} {
+		for ; x != 0; x &= x - 1 {
+			if !fn(64*w + bits.TrailingZeros64(x)) {
+				return
+			}
+		}
+	}
+}
+
+// NthSetBit returns the index of the n-th set bit, counting from zero
+// (also known as "select"). The result is -1 if there are not enough set bits.
+func (u Uint256) NthSetBit(n int) int {
+	if n < 0 {
+		return -1
+	}
+	if c := u.Lo.OnesCount(); n >= c {
+		if i := u.Hi.NthSetBit(n - c); i >= 0 {
+			return 128 + i
+		}
+		return -1
+	}
+	return u.Lo.NthSetBit(n)
+}
+
+// Rank returns the number of set bits below the i-th bit, i.e. in [0, i).
+// The result is OnesCount() if i >= 256.
+func (u Uint256) Rank(i uint) int {
+	return u.And(Mask(i)).OnesCount()
+}
+
+// Bitset256 is a set of integers in [0, 256) represented as 256-bit mask.
+// The zero value is an empty set.
+type Bitset256 Uint256
+
+// NewBitset256 returns the set containing the given integers.
+// Integers out of [0, 256) range are ignored.
+func NewBitset256(values ...int) Bitset256 {
+	var s Bitset256
+	for _, i := range values {
+		s = s.Add(i)
+	}
+	return s
+}
+
+// Add returns the set with i added.
+// The set is unchanged if i is out of [0, 256) range.
+func (s Bitset256) Add(i int) Bitset256 {
+	if i < 0 {
+		return s
+	}
+	return Bitset256(Uint256(s).SetBit(uint(i), 1))
+}
+
+// Remove returns the set with i removed.
+func (s Bitset256) Remove(i int) Bitset256 {
+	if i < 0 {
+		return s
+	}
+	return Bitset256(Uint256(s).ClearBit(uint(i)))
+}
+
+// Contains reports whether i is in the set.
+func (s Bitset256) Contains(i int) bool {
+	return i >= 0 && Uint256(s).Bit(uint(i)) != 0
+}
+
+// Len returns the number of integers in the set.
+func (s Bitset256) Len() int {
+	return Uint256(s).OnesCount()
+}
+
+// IsEmpty reports whether the set is empty.
+func (s Bitset256) IsEmpty() bool {
+	return Uint256(s).IsZero()
+}
+
+// Union returns the set of integers in s or t.
+func (s Bitset256) Union(t Bitset256) Bitset256 {
+	return Bitset256(Uint256(s).Or(Uint256(t)))
+}
+
+// Intersect returns the set of integers in both s and t.
+func (s Bitset256) Intersect(t Bitset256) Bitset256 {
+	return Bitset256(Uint256(s).And(Uint256(t)))
+}
+
+// Difference returns the set of integers in s but not in t.
+func (s Bitset256) Difference(t Bitset256) Bitset256 {
+	return Bitset256(Uint256(s).AndNot(Uint256(t)))
+}
+
+// IsSubset reports whether all integers of s are in t.
+func (s Bitset256) IsSubset(t Bitset256) bool {
+	return Uint256(s).AndNot(Uint256(t)).IsZero()
+}
+
+// ForEach calls fn for each integer of the set in ascending order.
+// The iteration stops if fn returns false.
+func (s Bitset256) ForEach(fn func(i int) bool) {
+	Uint256(s).ForEachSetBit(fn)
+}
+
+// Select returns the n-th smallest integer of the set, counting from zero.
+// The result is -1 if the set has not enough integers.
+func (s Bitset256) Select(n int) int {
+	return Uint256(s).NthSetBit(n)
+}
+
+// Rank returns the number of integers in the set less than i.
+func (s Bitset256) Rank(i int) int {
+	if i <= 0 {
+		return 0
+	}
+	return Uint256(s).Rank(uint(i))
+}
+
+// String returns the set literal, e.g. "{1, 5, 255}".
+func (s Bitset256) String() string {
+	buf := make([]byte, 0, 2+4*s.Len())
+	buf = append(buf, '{')
+	s.ForEach(func(i int) bool {
+		if len(buf) > 1 {
+			buf = append(buf, ',', ' ')
+		}
+		buf = strconv.AppendInt(buf, int64(i), 10)
+		return true
+	})
+	buf = append(buf, '}')
+	return string(buf)
+}
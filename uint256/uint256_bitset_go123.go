@@ -0,0 +1,20 @@
+//go:build go1.23
+// +build go1.23
+
+package uint256
+
+import (
+	"iter"
+)
+
+// Ones returns an iterator over set bit indexes in ascending order.
+func (u Uint256) Ones() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		u.ForEachSetBit(yield)
+	}
+}
+
+// All returns an iterator over integers of the set in ascending order.
+func (s Bitset256) All() iter.Seq[int] {
+	return Uint256(s).Ones()
+}
@@ -0,0 +1,125 @@
+package uint256
+
+import (
+	"testing"
+)
+
+// setBits returns set bit indexes using naive loop over all bits.
+func setBits(u Uint256) []int {
+	var out []int
+	for i := 0; i < 256; i++ {
+		if u.Bit(uint(i)) != 0 {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// TestSetBits unit tests for set bit iteration, NthSetBit and Rank.
+func TestSetBits(t *testing.T) {
+	values := make(chan Uint256)
+	go generate256s(1000, values)
+	for x := range values {
+		expected := setBits(x)
+
+		var got []int
+		x.ForEachSetBit(func(i int) bool {
+			got = append(got, i)
+			return true
+		})
+		if !equalInts(got, expected) {
+			t.Fatalf("%#x.ForEachSetBit() should be %v, got %v", x, expected, got)
+		}
+
+		// stop early
+		got = got[:0]
+		x.ForEachSetBit(func(i int) bool {
+			got = append(got, i)
+			return len(got) < 3
+		})
+		if len(expected) > 3 {
+			expected = expected[:3]
+		}
+		if !equalInts(got, expected) {
+			t.Fatalf("%#x.ForEachSetBit() stopped should be %v, got %v", x, expected, got)
+		}
+
+		expected = setBits(x)
+		for n := -1; n <= len(expected); n++ {
+			e := -1
+			if n >= 0 && n < len(expected) {
+				e = expected[n]
+			}
+			if got := x.NthSetBit(n); got != e {
+				t.Fatalf("%#x.NthSetBit(%d) should be %d, got %d", x, n, e, got)
+			}
+		}
+
+		rank := 0
+		for i := uint(0); i <= 270; i++ {
+			if got := x.Rank(i); got != rank {
+				t.Fatalf("%#x.Rank(%d) should be %d, got %d", x, i, rank, got)
+			}
+			rank += int(x.Bit(i))
+		}
+	}
+}
+
+// TestBitset unit tests for Bitset256 type.
+func TestBitset(t *testing.T) {
+	s := NewBitset256(1, 5, 255, -1, 256, 5)
+	if got, expected := s.String(), "{1, 5, 255}"; got != expected {
+		t.Fatalf("String() should be %q, got %q", expected, got)
+	}
+	if got, expected := (Bitset256{}).String(), "{}"; got != expected {
+		t.Fatalf("String() should be %q, got %q", expected, got)
+	}
+	if s.Len() != 3 || s.IsEmpty() || !(Bitset256{}).IsEmpty() {
+		t.Fatalf("%v.Len() should be 3, got %d", s, s.Len())
+	}
+	for i := -2; i < 260; i++ {
+		expected := i == 1 || i == 5 || i == 255
+		if got := s.Contains(i); got != expected {
+			t.Fatalf("%v.Contains(%d) should be %t, got %t", s, i, expected, got)
+		}
+	}
+	if got := s.Select(2); got != 255 {
+		t.Fatalf("%v.Select(2) should be 255, got %d", s, got)
+	}
+	if got := s.Rank(-1); got != 0 {
+		t.Fatalf("%v.Rank(-1) should be 0, got %d", s, got)
+	}
+	if got := s.Rank(6); got != 2 {
+		t.Fatalf("%v.Rank(6) should be 2, got %d", s, got)
+	}
+
+	tt := NewBitset256(5, 64, 100)
+	check := func(name string, got Bitset256, expected string) {
+		t.Helper()
+		if got.String() != expected {
+			t.Fatalf("%s should be %s, got %v", name, expected, got)
+		}
+	}
+	check("Union", s.Union(tt), "{1, 5, 64, 100, 255}")
+	check("Intersect", s.Intersect(tt), "{5}")
+	check("Difference", s.Difference(tt), "{1, 255}")
+	check("Add", s.Add(64).Add(-1).Add(300), "{1, 5, 64, 255}")
+	check("Remove", s.Remove(5).Remove(-1).Remove(300), "{1, 255}")
+
+	if !s.IsSubset(s) || !s.Intersect(tt).IsSubset(tt) || s.IsSubset(tt) || !(Bitset256{}).IsSubset(tt) {
+		t.Fatalf("IsSubset failed")
+	}
+}
+
+// equalInts reports whether two slices are equal.
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,358 @@
+package uint256
+
+import (
+	"errors"
+	"sort"
+)
+
+// Interleave3 returns the 3D Morton code (Z-order) of (x, y, z):
+// bit i of x goes to bit 3i, bit i of y goes to bit 3i+1,
+// bit i of z goes to bit 3i+2. The upper 64 bits are always zero.
+func Interleave3(x, y, z uint64) Uint256 {
+	// 64 bits are split to 22/21/21 or 21/22/21 or 21/21/22 bit chunks,
+	// so every chunk fits a single 64-bit limb of the result
+	return Uint256{
+		Lo: Uint128{
+			Lo: spread22(x) | spread21(y)<<1 | spread21(z)<<2,
+			Hi: spread21(x>>22)<<2 | spread22(y>>21) | spread21(z>>21)<<1,
+		},
+		Hi: Uint128{
+			Lo: spread21(x>>43)<<1 | spread21(y>>43)<<2 | spread22(z>>42),
+		},
+	}
+}
+
+// Deinterleave3 is the inverse of Interleave3.
+func Deinterleave3(u Uint256) (x, y, z uint64) {
+	l0, l1, l2 := u.Lo.Lo, u.Lo.Hi, u.Hi.Lo
+	x = compact22(l0) | compact21(l1>>2)<<22 | compact21(l2>>1)<<43
+	y = compact21(l0>>1) | compact22(l1)<<21 | compact21(l2>>2)<<43
+	z = compact21(l0>>2) | compact21(l1>>1)<<21 | compact22(l2)<<42
+	return
+}
+
+// Interleave4 returns the 4D Morton code (Z-order) of (x, y, z, w):
+// bit i of x goes to bit 4i, bit i of y goes to bit 4i+1, etc.
+func Interleave4(x, y, z, w uint64) Uint256 {
+	limb := func(s uint) uint64 {
+		return spread16(x>>s) | spread16(y>>s)<<1 |
+			spread16(z>>s)<<2 | spread16(w>>s)<<3
+	}
+	return Uint256{
+		Lo: Uint128{Lo: limb(0), Hi: limb(16)},
+		Hi: Uint128{Lo: limb(32), Hi: limb(48)},
+	}
+}
+
+// Deinterleave4 is the inverse of Interleave4.
+func Deinterleave4(u Uint256) (x, y, z, w uint64) {
+	coord := func(s uint) uint64 {
+		return compact16(u.Lo.Lo>>s) | compact16(u.Lo.Hi>>s)<<16 |
+			compact16(u.Hi.Lo>>s)<<32 | compact16(u.Hi.Hi>>s)<<48
+	}
+	return coord(0), coord(1), coord(2), coord(3)
+}
+
+// spread21 spreads the lower 21 bits of x to every third bit.
+func spread21(x uint64) uint64 {
+	x &= 0x00000000001FFFFF
+	x = (x | x<<32) & 0x001F00000000FFFF
+	x = (x | x<<16) & 0x001F0000FF0000FF
+	x = (x | x<<8) & 0x100F00F00F00F00F
+	x = (x | x<<4) & 0x10C30C30C30C30C3
+	x = (x | x<<2) & 0x1249249249249249
+	return x
+}
+
+// spread22 spreads the lower 22 bits of x to every third bit.
+func spread22(x uint64) uint64 {
+	return spread21(x) | (x>>21)<<63
+}
+
+// compact21 is the inverse of spread21: it packs every third bit of x.
+func compact21(x uint64) uint64 {
+	x &= 0x1249249249249249
+	x = (x | x>>2) & 0x10C30C30C30C30C3
+	x = (x | x>>4) & 0x100F00F00F00F00F
+	x = (x | x>>8) & 0x001F0000FF0000FF
+	x = (x | x>>16) & 0x001F00000000FFFF
+	x = (x | x>>32) & 0x00000000001FFFFF
+	return x
+}
+
+// compact22 is the inverse of spread22.
+func compact22(x uint64) uint64 {
+	return compact21(x) | (x>>63)<<21
+}
+
+// spread16 spreads the lower 16 bits of x to every fourth bit.
+func spread16(x uint64) uint64 {
+	x &= 0x000000000000FFFF
+	x = (x | x<<24) & 0x000000FF000000FF
+	x = (x | x<<12) & 0x000F000F000F000F
+	x = (x | x<<6) & 0x0303030303030303
+	x = (x | x<<3) & 0x1111111111111111
+	return x
+}
+
+// compact16 is the inverse of spread16: it packs every fourth bit of x.
+func compact16(x uint64) uint64 {
+	x &= 0x1111111111111111
+	x = (x | x>>3) & 0x0303030303030303
+	x = (x | x>>6) & 0x000F000F000F000F
+	x = (x | x>>12) & 0x000000FF000000FF
+	x = (x | x>>24) & 0x000000000000FFFF
+	return x
+}
+
+// KeyRange is an inclusive range of keys.
+type KeyRange struct {
+	Min, Max Uint256
+}
+
+// Contains reports whether the key is in the range.
+func (r KeyRange) Contains(key Uint256) bool {
+	return r.Min.Cmp(key) <= 0 && key.Cmp(r.Max) <= 0
+}
+
+// MortonRanges3 splits the 3D bounding box [min, max] (inclusive) into
+// sorted non-overlapping ranges of Interleave3 keys.
+// The box is subdivided as an octree while the number of ranges does not
+// exceed maxRanges, so the ranges may cover some points outside the box.
+// DefaultMaxRanges is used if maxRanges <= 0. The result is nil if min > max.
+func MortonRanges3(min, max [3]uint64, maxRanges int) []KeyRange {
+	var lo, hi [4]uint64
+	copy(lo[:], min[:])
+	copy(hi[:], max[:])
+	return mortonRanges(3, &lo, &hi, maxRanges, func(p *[4]uint64) Uint256 {
+		return Interleave3(p[0], p[1], p[2])
+	})
+}
+
+// MortonRanges4 splits the 4D bounding box [min, max] (inclusive) into
+// sorted non-overlapping ranges of Interleave4 keys, see MortonRanges3.
+func MortonRanges4(min, max [4]uint64, maxRanges int) []KeyRange {
+	return mortonRanges(4, &min, &max, maxRanges, func(p *[4]uint64) Uint256 {
+		return Interleave4(p[0], p[1], p[2], p[3])
+	})
+}
+
+// DefaultMaxRanges is the limit of MortonRanges* result used if maxRanges <= 0.
+// Unbounded subdivision of a box with unaligned edges goes down to single
+// points, so the number of ranges would grow with the box perimeter.
+const DefaultMaxRanges = 1024
+
+// mortonNode is an orthant tree node: a cube of 2^shift size at lo corner.
+type mortonNode struct {
+	lo    [4]uint64
+	shift uint
+}
+
+// mortonRanges splits the box [min, max] of given dimensions
+// into key ranges, see MortonRanges3.
+func mortonRanges(dims int, min, max *[4]uint64, maxRanges int, key func(*[4]uint64) Uint256) []KeyRange {
+	for i := 0; i < dims; i++ {
+		if min[i] > max[i] {
+			return nil
+		}
+	}
+	if maxRanges <= 0 {
+		maxRanges = DefaultMaxRanges
+	}
+
+	// nodeRange returns key range of the node
+	nodeRange := func(n *mortonNode) KeyRange {
+		ext := ^uint64(0) >> (64 - n.shift) // zero if shift == 0
+		hi := n.lo
+		for i := 0; i < dims; i++ {
+			hi[i] |= ext
+		}
+		return KeyRange{Min: key(&n.lo), Max: key(&hi)}
+	}
+
+	var out []KeyRange
+	level := []mortonNode{{shift: 64}}
+	for len(level) != 0 {
+		var full []KeyRange
+		var next []mortonNode
+		for k := range level {
+			n := &level[k]
+			s := n.shift - 1
+			for c := 0; c < 1<<uint(dims); c++ {
+				child := mortonNode{lo: n.lo, shift: s}
+				inside, outside := true, false
+				for i := 0; i < dims; i++ {
+					child.lo[i] |= uint64(c>>uint(i)&1) << s
+					l, h := child.lo[i], child.lo[i]|^uint64(0)>>(64-s)
+					outside = outside || h < min[i] || l > max[i]
+					inside = inside && min[i] <= l && h <= max[i]
+				}
+				if outside {
+					continue
+				}
+				if inside {
+					full = append(full, nodeRange(&child))
+				} else {
+					next = append(next, child)
+				}
+			}
+		}
+
+		if len(out)+len(full)+len(next) > maxRanges {
+			// too many ranges, use the current level as is
+			for k := range level {
+				out = append(out, nodeRange(&level[k]))
+			}
+			break
+		}
+		out = append(out, full...)
+		level = next
+	}
+
+	return mergeRanges(out)
+}
+
+// mergeRanges sorts ranges and merges adjacent and overlapping ones.
+func mergeRanges(r []KeyRange) []KeyRange {
+	if len(r) == 0 {
+		return r
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].Min.Cmp(r[j].Min) < 0
+	})
+
+	out := r[:1]
+	for _, c := range r[1:] {
+		last := &out[len(out)-1]
+		if !last.Max.Equals(Max()) && c.Min.Cmp(last.Max.Add(One())) > 0 {
+			out = append(out, c)
+		} else if c.Max.Cmp(last.Max) > 0 {
+			last.Max = c.Max
+		}
+	}
+	return out
+}
+
+// errHilbertOrder is panic value for too many bits of Hilbert index.
+var errHilbertOrder = errors.New("hilbert: index does not fit 256 bits")
+
+// HilbertEncode returns the Hilbert curve index of the point with
+// len(coords) coordinates of order bits each. Coordinates are truncated
+// to order bits. It panics if len(coords)*order exceeds 256 or order exceeds 64.
+// This is J. Skilling's algorithm, "Programming the Hilbert curve", 2004.
+func HilbertEncode(coords []uint64, order uint) Uint256 {
+	n := uint(len(coords))
+	if order > 64 || n*order > 256 {
+		panic(errHilbertOrder)
+	}
+	if n == 0 || order == 0 {
+		return Zero()
+	}
+
+	var buf [16]uint64
+	x := buf[:0]
+	if n > uint(len(buf)) {
+		x = make([]uint64, 0, n)
+	}
+	ext := ^uint64(0) >> (64 - order)
+	for _, c := range coords {
+		x = append(x, c&ext)
+	}
+
+	// inverse undo
+	for k := order - 1; k > 0; k-- {
+		p := uint64(1)<<k - 1
+		x0 := x[0] ^ p&-(x[0]>>k&1) // i == 0
+		for i := 1; i < len(x); i++ {
+			x0, x[i] = hilbertStep(x0, x[i], k, p)
+		}
+		x[0] = x0
+	}
+
+	// gray encode
+	for i := 1; i < len(x); i++ {
+		x[i] ^= x[i-1]
+	}
+	var t uint64
+	for k := order - 1; k > 0; k-- {
+		t ^= (uint64(1)<<k - 1) & -(x[n-1] >> k & 1)
+	}
+
+	// transposed to index: the most significant bit of x[0] goes first
+	var u Uint256
+	m := hilbertMask(n, order)
+	for i := range x {
+		u = u.Or(From64(x[i] ^ t).DepositBits(m.Lsh(n - 1 - uint(i))))
+	}
+	return u
+}
+
+// HilbertDecode is the inverse of HilbertEncode: it stores len(coords)
+// coordinates of order bits each of the point with given Hilbert index.
+// It panics if len(coords)*order exceeds 256 or order exceeds 64.
+func HilbertDecode(index Uint256, coords []uint64, order uint) {
+	n := uint(len(coords))
+	if order > 64 || n*order > 256 {
+		panic(errHilbertOrder)
+	}
+	if n == 0 || order == 0 {
+		for i := range coords {
+			coords[i] = 0
+		}
+		return
+	}
+
+	// index to transposed
+	x := coords
+	m := hilbertMask(n, order)
+	for i := range x {
+		x[i] = index.ExtractBits(m.Lsh(n - 1 - uint(i))).Lo.Lo
+	}
+
+	// gray decode
+	t := x[n-1] >> 1
+	for i := n - 1; i > 0; i-- {
+		x[i] ^= x[i-1]
+	}
+	x[0] ^= t
+
+	// undo excess work
+	for k := uint(1); k < order; k++ {
+		p := uint64(1)<<k - 1
+		x0 := x[0]
+		for i := n - 1; i > 0; i-- {
+			x0, x[i] = hilbertStep(x0, x[i], k, p)
+		}
+		x[0] = x0 ^ p&-(x0>>k&1) // i == 0
+	}
+}
+
+// hilbertStep inverts the lower bits p of x0 if the bit k of xi is set,
+// otherwise exchanges the lower bits p of x0 and xi. It is branch-free.
+func hilbertStep(x0, xi uint64, k uint, p uint64) (uint64, uint64) {
+	b := -(xi >> k & 1)     // all ones if the bit is set
+	t := (x0 ^ xi) & p &^ b // exchange
+	return x0 ^ p&b ^ t, xi ^ t
+}
+
+// hilbertMask returns the mask of every n-th bit, order bits total.
+func hilbertMask(n, order uint) Uint256 {
+	m := One()
+	for k := uint(1); k < order; k *= 2 {
+		m = m.Or(m.Lsh(k * n))
+	}
+	return m.And(Mask(n * order))
+}
+
+// ToGray returns the reflected binary Gray code of u, i.e. u ^ (u >> 1).
+func (u Uint256) ToGray() Uint256 {
+	return u.Xor(u.Rsh(1))
+}
+
+// FromGray is the inverse of ToGray.
+func (u Uint256) FromGray() Uint256 {
+	for s := uint(1); s < 256; s <<= 1 {
+		u = u.Xor(u.Rsh(s))
+	}
+	return u
+}
@@ -0,0 +1,204 @@
+package uint256
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestMorton unit tests for Morton codes.
+func TestMorton(t *testing.T) {
+	values := make(chan Uint256)
+	go generate256s(1000, values)
+	for u := range values {
+		x, y, z, w := u.Lo.Lo, u.Lo.Hi, u.Hi.Lo, u.Hi.Hi
+
+		var e3, e4 Uint256 // naive
+		for i := uint(0); i < 64; i++ {
+			e3 = e3.SetBit(3*i, uint(x>>i)&1)
+			e3 = e3.SetBit(3*i+1, uint(y>>i)&1)
+			e3 = e3.SetBit(3*i+2, uint(z>>i)&1)
+			e4 = e4.SetBit(4*i, uint(x>>i)&1)
+			e4 = e4.SetBit(4*i+1, uint(y>>i)&1)
+			e4 = e4.SetBit(4*i+2, uint(z>>i)&1)
+			e4 = e4.SetBit(4*i+3, uint(w>>i)&1)
+		}
+		if got := Interleave3(x, y, z); got != e3 {
+			t.Fatalf("Interleave3(%#x, %#x, %#x) should be %#x, got %#x", x, y, z, e3, got)
+		}
+		if gx, gy, gz := Deinterleave3(e3); gx != x || gy != y || gz != z {
+			t.Fatalf("Deinterleave3(%#x) should be (%#x, %#x, %#x), got (%#x, %#x, %#x)", e3, x, y, z, gx, gy, gz)
+		}
+		if got := Interleave4(x, y, z, w); got != e4 {
+			t.Fatalf("Interleave4(%#x, %#x, %#x, %#x) should be %#x, got %#x", x, y, z, w, e4, got)
+		}
+		if gx, gy, gz, gw := Deinterleave4(e4); gx != x || gy != y || gz != z || gw != w {
+			t.Fatalf("Deinterleave4(%#x) should be (%#x, %#x, %#x, %#x), got (%#x, %#x, %#x, %#x)", e4, x, y, z, w, gx, gy, gz, gw)
+		}
+	}
+}
+
+// checkRanges checks that ranges are sorted and merged,
+// cover all points of the box and no others if exact.
+func checkRanges(t *testing.T, ranges []KeyRange, maxRanges int, dims int, min, max [4]uint64, key func(p *[4]uint64) Uint256) {
+	t.Helper()
+	if maxRanges > 0 && len(ranges) > maxRanges {
+		t.Fatalf("box %v-%v: too many ranges: %d > %d", min, max, len(ranges), maxRanges)
+	}
+	for i := 1; i < len(ranges); i++ {
+		if ranges[i-1].Max.Add(One()).Cmp(ranges[i].Min) >= 0 {
+			t.Fatalf("box %v-%v: ranges are not sorted or merged: %v", min, max, ranges)
+		}
+	}
+
+	const N = 12
+	var p [4]uint64
+	var walk func(d int)
+	walk = func(d int) {
+		if d == dims {
+			k := key(&p)
+			covered := false
+			for _, r := range ranges {
+				covered = covered || r.Contains(k)
+			}
+			inside := true
+			for i := 0; i < dims; i++ {
+				inside = inside && min[i] <= p[i] && p[i] <= max[i]
+			}
+			if inside && !covered {
+				t.Fatalf("box %v-%v: point %v is not covered", min, max, p)
+			}
+			if !inside && covered && maxRanges == 0 {
+				t.Fatalf("box %v-%v: point %v is covered", min, max, p)
+			}
+			return
+		}
+		for p[d] = 0; p[d] < N; p[d]++ {
+			walk(d + 1)
+		}
+	}
+	walk(0)
+}
+
+// TestMortonRanges unit tests for MortonRanges3 and MortonRanges4.
+func TestMortonRanges(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 30; k++ {
+		var min, max [4]uint64
+		for i := range min {
+			min[i] = uint64(rnd.Intn(8))
+			max[i] = min[i] + uint64(rnd.Intn(4))
+		}
+		for _, maxRanges := range []int{0, 1, 5, 20} {
+			var min3, max3 [3]uint64
+			copy(min3[:], min[:])
+			copy(max3[:], max[:])
+			checkRanges(t, MortonRanges3(min3, max3, maxRanges), maxRanges, 3, min, max, func(p *[4]uint64) Uint256 {
+				return Interleave3(p[0], p[1], p[2])
+			})
+			checkRanges(t, MortonRanges4(min, max, maxRanges), maxRanges, 4, min, max, func(p *[4]uint64) Uint256 {
+				return Interleave4(p[0], p[1], p[2], p[3])
+			})
+		}
+	}
+
+	// full and empty
+	full := [4]uint64{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+	if r := MortonRanges4([4]uint64{}, full, 0); len(r) != 1 || !r[0].Min.IsZero() || r[0].Max != Max() {
+		t.Fatalf("MortonRanges4(full) should be single full range, got %v", r)
+	}
+	if r := MortonRanges3([3]uint64{1, 1, 1}, [3]uint64{0, 5, 5}, 0); r != nil {
+		t.Fatalf("MortonRanges3(empty) should be nil, got %v", r)
+	}
+
+	// unaligned edges are limited by default
+	min, max := [4]uint64{1, 3, 5, 7}, [4]uint64{^uint64(0) - 5, ^uint64(0) - 1, ^uint64(0) - 3, ^uint64(0) - 7}
+	r := MortonRanges4(min, max, 0)
+	if len(r) == 0 || len(r) > DefaultMaxRanges {
+		t.Fatalf("MortonRanges4(unaligned) should have at most %d ranges, got %d", DefaultMaxRanges, len(r))
+	}
+	for _, p := range [][4]uint64{min, max} {
+		key := Interleave4(p[0], p[1], p[2], p[3])
+		covered := false
+		for _, kr := range r {
+			covered = covered || kr.Contains(key)
+		}
+		if !covered {
+			t.Fatalf("MortonRanges4(unaligned) does not cover %v", p)
+		}
+	}
+}
+
+// TestHilbert unit tests for Hilbert curve.
+func TestHilbert(t *testing.T) {
+	t.Run("adjacency", func(t *testing.T) {
+		for n := 1; n <= 5; n++ {
+			for order := uint(1); uint(n)*order <= 12; order++ {
+				coords := make([]uint64, n)
+				prev := make([]uint64, n)
+				for i := uint64(0); i < 1<<(uint(n)*order); i++ {
+					HilbertDecode(From64(i), coords, order)
+					if got := HilbertEncode(coords, order); !got.Equals(From64(i)) {
+						t.Fatalf("HilbertEncode(%v, %d) should be %d, got %d", coords, order, i, got)
+					}
+					dist := uint64(0)
+					for k := range coords {
+						if coords[k] >= 1<<order {
+							t.Fatalf("HilbertDecode(%d, %d) is out of range: %v", i, order, coords)
+						}
+						if coords[k] > prev[k] {
+							dist += coords[k] - prev[k]
+						} else {
+							dist += prev[k] - coords[k]
+						}
+					}
+					if i != 0 && dist != 1 {
+						t.Fatalf("HilbertDecode(%d, %d)=%v is not adjacent to %v", i, order, coords, prev)
+					}
+					copy(prev, coords)
+				}
+			}
+		}
+	})
+
+	t.Run("rand", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for u := range values {
+			for _, c := range []struct {
+				n     int
+				order uint
+			}{{1, 64}, {3, 64}, {4, 64}, {5, 51}, {256, 1}} {
+				coords := make([]uint64, c.n)
+				index := u.And(Mask(uint(c.n) * c.order))
+				HilbertDecode(index, coords, c.order)
+				if got := HilbertEncode(coords, c.order); got != index {
+					t.Fatalf("HilbertEncode(HilbertDecode(%#x)) with %d/%d should be the same, got %#x", index, c.n, c.order, got)
+				}
+			}
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != errHilbertOrder {
+				t.Fatalf("HilbertEncode should panic with %v, got %v", errHilbertOrder, r)
+			}
+		}()
+		HilbertEncode(make([]uint64, 5), 52)
+	})
+}
+
+// TestGray unit tests for Gray codes.
+func TestGray(t *testing.T) {
+	values := make(chan Uint256)
+	go generate256s(1000, values)
+	for u := range values {
+		g := u.ToGray()
+		if got := g.FromGray(); got != u {
+			t.Fatalf("%#x.ToGray().FromGray() should be %#x, got %#x", u, u, got)
+		}
+		if d := u.Add(One()).ToGray().Xor(g).OnesCount(); d != 1 {
+			t.Fatalf("%#x.ToGray() and next differ by %d bits", u, d)
+		}
+	}
+}
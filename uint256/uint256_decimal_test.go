@@ -0,0 +1,210 @@
+package uint256
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+// TestFormatDecimal unit tests for FormatDecimal function.
+func TestFormatDecimal(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		for _, c := range []struct {
+			u        Uint256
+			decimals int
+			opts     DecimalOptions
+			expected string
+		}{
+			{From64(1500000), 6, DecimalOptions{}, "1.500000"},
+			{From64(1500000), 6, DecimalOptions{TrimZeros: true}, "1.5"},
+			{From64(1000000), 6, DecimalOptions{TrimZeros: true}, "1"},
+			{From64(5), 6, DecimalOptions{}, "0.000005"},
+			{Zero(), 6, DecimalOptions{}, "0.000000"},
+			{Zero(), 6, DecimalOptions{TrimZeros: true}, "0"},
+			{From64(12345), 0, DecimalOptions{}, "12345"},
+			{From64(12345), -2, DecimalOptions{}, "12345"},
+			{From64(1234567891), 3, DecimalOptions{Separator: ","}, "1,234,567.891"},
+			{From64(123456), 3, DecimalOptions{Separator: ","}, "123.456"},
+			{From64(1234567), 0, DecimalOptions{Separator: "'"}, "1'234'567"},
+			{From64(1234567), 0, DecimalOptions{Separator: " "}, "1 234 567"},
+			{From64(1255), 3, DecimalOptions{FixedPrecision: true, Precision: 2}, "1.26"},
+			{From64(1255), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundHalfEven}, "1.26"},
+			{From64(1245), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundHalfEven}, "1.24"},
+			{From64(1245), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundHalfDown}, "1.24"},
+			{From64(1245), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundTowardZero}, "1.24"},
+			{From64(1249), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundTowardZero}, "1.24"},
+			{From64(1241), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundUp}, "1.25"},
+			{From64(999500), 3, DecimalOptions{FixedPrecision: true, Precision: 0, Separator: ","}, "1,000"},
+			{From64(999500), 3, DecimalOptions{FixedPrecision: true, Precision: -1}, "1000"},
+			{From64(15), 1, DecimalOptions{FixedPrecision: true, Precision: 4}, "1.5000"},
+			{From64(15), 1, DecimalOptions{FixedPrecision: true, Precision: 4, TrimZeros: true}, "1.5"},
+			{From64(1250), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundUnnecessary}, "1.25"},
+			{From64(1257), 3, DecimalOptions{FixedPrecision: true, Precision: 2, Rounding: RoundUnnecessary}, "1.257"},
+			{From64(1000), 3, DecimalOptions{FixedPrecision: true, Precision: 0, Rounding: RoundUnnecessary}, "1"},
+			{Max(), 18, DecimalOptions{TrimZeros: true, Separator: ","}, "115,792,089,237,316,195,423,570,985,008,687,907,853,269,984,665,640,564,039,457.584007913129639935"},
+			{Max(), 77, DecimalOptions{FixedPrecision: true, Precision: 0}, "1"},
+			{Max(), 78, DecimalOptions{FixedPrecision: true, Precision: 2}, "0.12"},
+			{Max(), 90, DecimalOptions{FixedPrecision: true, Precision: 10}, "0.0000000000"},
+			{From64(5), 3, DecimalOptions{FixedPrecision: true, Precision: 2}, "0.01"},
+			{From64(9), 3, DecimalOptions{FixedPrecision: true, Precision: 1}, "0.0"},
+			{One(), 1 << 30, DecimalOptions{FixedPrecision: true, Precision: 2}, "0.00"},
+		} {
+			if got := FormatDecimal(c.u, c.decimals, c.opts); got != c.expected {
+				t.Fatalf("FormatDecimal(%s, %d, %+v) should be %q, got %q", c.u, c.decimals, c.opts, c.expected, got)
+			}
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		values := make(chan Uint256)
+		go generate256s(1000, values)
+		for u := range values {
+			for _, decimals := range []int{0, 6, 18, 40} {
+				for _, mode := range []RoundingMode{RoundHalfUp, RoundHalfEven, RoundHalfDown, RoundTowardZero, RoundUp} {
+					for _, prec := range []int{0, 2, 9} {
+						opts := DecimalOptions{FixedPrecision: true, Precision: prec, Rounding: mode}
+						got := FormatDecimal(u, decimals, opts)
+						expected := bigFormatDecimal(u.Big(), decimals, prec, mode)
+						if got != expected {
+							t.Fatalf("FormatDecimal(%s, %d, %+v) should be %q, got %q", u, decimals, opts, expected, got)
+						}
+
+						// round trip
+						if prec >= decimals {
+							if back, err := ParseDecimal(got, decimals, RoundUnnecessary); err != nil || !back.Equals(u) {
+								t.Fatalf("ParseDecimal(%q, %d) should be %s, got (%s, %v)", got, decimals, u, back, err)
+							}
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+// bigFormatDecimal is reference implementation of FormatDecimal with fixed precision.
+func bigFormatDecimal(u *big.Int, decimals, prec int, mode RoundingMode) string {
+	// u·10^prec / 10^decimals, or multiplied if prec > decimals
+	x := new(big.Int).Set(u)
+	if prec > decimals {
+		x.Mul(x, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec-decimals)), nil))
+	} else if prec < decimals {
+		d := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-prec)), nil)
+		q, r := new(big.Int).QuoRem(x, d, new(big.Int))
+		c := r.Lsh(r, 1).Cmp(d)
+		switch {
+		case r.Sign() == 0:
+		case mode == RoundHalfUp && c >= 0,
+			mode == RoundHalfEven && (c > 0 || (c == 0 && q.Bit(0) != 0)),
+			mode == RoundHalfDown && c > 0,
+			mode == RoundUp:
+			q.Add(q, big.NewInt(1))
+		}
+		x = q
+	}
+
+	s := x.String()
+	for len(s) <= prec {
+		s = "0" + s
+	}
+	if prec == 0 {
+		return s
+	}
+	return s[:len(s)-prec] + "." + s[len(s)-prec:]
+}
+
+// TestParseDecimal unit tests for ParseDecimal function.
+func TestParseDecimal(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		for _, c := range []struct {
+			s        string
+			decimals int
+			mode     RoundingMode
+			expected Uint256
+		}{
+			{"1.5", 6, RoundHalfUp, From64(1500000)},
+			{"1", 6, RoundHalfUp, From64(1000000)},
+			{".5", 2, RoundHalfUp, From64(50)},
+			{"7.", 2, RoundHalfUp, From64(700)},
+			{"007.10", 2, RoundUnnecessary, From64(710)},
+			{"0.000001", 6, RoundUnnecessary, From64(1)},
+			{"1.2300", 2, RoundUnnecessary, From64(123)},
+			{"1.235", 2, RoundHalfUp, From64(124)},
+			{"1.225", 2, RoundHalfEven, From64(122)},
+			{"1.235", 2, RoundHalfEven, From64(124)},
+			{"1.2251", 2, RoundHalfEven, From64(123)},
+			{"1.235", 2, RoundHalfDown, From64(123)},
+			{"1.2351", 2, RoundHalfDown, From64(124)},
+			{"1.239", 2, RoundTowardZero, From64(123)},
+			{"1.231", 2, RoundUp, From64(124)},
+			{"1.230", 2, RoundUp, From64(123)},
+			{"9.995", 2, RoundHalfUp, From64(1000)},
+			{"0.4", 0, RoundHalfUp, Zero()},
+			{"12345", -1, RoundHalfUp, From64(12345)},
+			{"0.00", 1 << 30, RoundUnnecessary, Zero()},
+			{"115792089237316195423570985008687907853269984665640564039457.584007913129639935", 18, RoundUnnecessary, Max()},
+			{"115792089237316195423570985008687907853269984665640564039457.5840079131296399354", 18, RoundHalfUp, Max()},
+		} {
+			got, err := ParseDecimal(c.s, c.decimals, c.mode)
+			if err != nil || !got.Equals(c.expected) {
+				t.Fatalf("ParseDecimal(%q, %d, %v) should be %s, got (%s, %v)", c.s, c.decimals, c.mode, c.expected, got, err)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, c := range []struct {
+			s        string
+			decimals int
+			mode     RoundingMode
+			expected error
+		}{
+			{"", 6, RoundHalfUp, ErrSyntax},
+			{".", 6, RoundHalfUp, ErrSyntax},
+			{"1.2.3", 6, RoundHalfUp, ErrSyntax},
+			{"-1", 6, RoundHalfUp, ErrSyntax},
+			{"+1", 6, RoundHalfUp, ErrSyntax},
+			{"1,000", 6, RoundHalfUp, ErrSyntax},
+			{"1e6", 6, RoundHalfUp, ErrSyntax},
+			{" 1", 6, RoundHalfUp, ErrSyntax},
+			{"1.2345", 2, RoundUnnecessary, ErrPrecision},
+			{"0.0000001", 6, RoundUnnecessary, ErrPrecision},
+			{"1.0000000000000000001", EtherDecimals, RoundUnnecessary, ErrPrecision},
+			{"115792089237316195423570985008687907853269984665640564039457.584007913129639936", 18, RoundHalfUp, ErrRange},
+			{"115792089237316195423570985008687907853269984665640564039457.5840079131296399355", 18, RoundHalfUp, ErrRange},
+			{"115792089237316195423570985008687907853269984665640564039458", 18, RoundHalfUp, ErrRange},
+			{"1", 78, RoundHalfUp, ErrRange},
+			{"1", 1 << 30, RoundHalfUp, ErrRange},
+		} {
+			_, err := ParseDecimal(c.s, c.decimals, c.mode)
+			var ne *strconv.NumError
+			if !errors.As(err, &ne) || ne.Func != "ParseDecimal" || ne.Num != c.s || !errors.Is(err, c.expected) {
+				t.Fatalf("ParseDecimal(%q, %d, %v) should fail with %v, got %v", c.s, c.decimals, c.mode, c.expected, err)
+			}
+		}
+	})
+}
+
+// TestEther unit tests for Ethereum unit helpers.
+func TestEther(t *testing.T) {
+	oneEther := From64(1_000_000_000_000_000_000)
+	if expected, got := "1", FormatEther(oneEther); got != expected {
+		t.Fatalf("FormatEther(1e18) should be %q, got %q", expected, got)
+	}
+	if expected, got := "0.000000000000000001", FormatEther(One()); got != expected {
+		t.Fatalf("FormatEther(1) should be %q, got %q", expected, got)
+	}
+	if expected, got := "30.5", FormatGwei(From64(30_500_000_000)); got != expected {
+		t.Fatalf("FormatGwei(30.5e9) should be %q, got %q", expected, got)
+	}
+	if got, err := ParseEther("1.5"); err != nil || !got.Equals(From64(1_500_000_000_000_000_000)) {
+		t.Fatalf("ParseEther(1.5) should be 1.5e18, got (%s, %v)", got, err)
+	}
+	if got, err := ParseGwei("30.5"); err != nil || !got.Equals(From64(30_500_000_000)) {
+		t.Fatalf("ParseGwei(30.5) should be 30.5e9, got (%s, %v)", got, err)
+	}
+	if _, err := ParseGwei("0.0000000001"); !errors.Is(err, ErrPrecision) {
+		t.Fatalf("ParseGwei(1e-10) should fail with %v, got %v", ErrPrecision, err)
+	}
+}
@@ -0,0 +1,219 @@
+package uint256
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// Divisor is a precomputed divisor for fast repeated division by an invariant.
+// The reciprocal of normalized divisor is computed once, then the division
+// is done using multiplications only, see N. Möller and T. Granlund,
+// "Improved division by invariant integers", IEEE Transactions on Computers, 2011.
+//
+// Divisor is immutable and safe for concurrent use.
+// The zero value is invalid and panics on division, use NewDivisor.
+type Divisor struct {
+	d     Uint256   // original divisor
+	dn    [4]uint64 // normalized divisor limbs, i.e. d<<shift (little-endian)
+	n     int       // number of significant limbs
+	shift uint      // normalization shift
+	v     uint64    // reciprocal: 2-by-1 if n == 1, 3-by-2 otherwise
+}
+
+// NewDivisor precomputes 256-bit divisor d.
+// It panics if divisor is zero.
+func NewDivisor(d Uint256) Divisor {
+	if d.IsZero() {
+		panic(errors.New("integer divide by zero"))
+	}
+
+	x := toLimbs(d)
+	n := len(x)
+	for x[n-1] == 0 {
+		n--
+	}
+
+	s := uint(bits.LeadingZeros64(x[n-1]))
+	dn := toLimbs(d.Lsh(s))
+	v := reciprocal2by1(dn[n-1])
+	if n > 1 {
+		v = reciprocal3by2(dn[n-1], dn[n-2])
+	}
+
+	return Divisor{
+		d:     d,
+		dn:    dn,
+		n:     n,
+		shift: s,
+		v:     v,
+	}
+}
+
+// NewDivisor128 precomputes 128-bit divisor d.
+// It panics if divisor is zero.
+func NewDivisor128(d Uint128) Divisor {
+	return NewDivisor(From128(d))
+}
+
+// NewDivisor64 precomputes 64-bit divisor d.
+// It panics if divisor is zero.
+func NewDivisor64(d uint64) Divisor {
+	return NewDivisor(From64(d))
+}
+
+// Value returns the original divisor value.
+func (d Divisor) Value() Uint256 {
+	return d.d
+}
+
+// Div returns division (u/d) of 256-bit value by precomputed divisor.
+func (d Divisor) Div(u Uint256) Uint256 {
+	q, _ := d.QuoRem(u)
+	return q
+}
+
+// Mod returns modulo (u%d) of 256-bit value by precomputed divisor.
+func (d Divisor) Mod(u Uint256) Uint256 {
+	_, r := d.QuoRem(u)
+	return r
+}
+
+// QuoRem returns quotient (u/d) and remainder (u%d) of 256-bit value by precomputed divisor.
+// It panics if d is the zero value Divisor{}, i.e. was not created by NewDivisor.
+func (d Divisor) QuoRem(u Uint256) (Uint256, Uint256) {
+	if d.n <= 1 {
+		if d.n == 0 {
+			panic(errors.New("integer divide by zero"))
+		}
+		q, r := d.quoRem64(u)
+		return q, From64(r)
+	}
+
+	un := normalize(u, d.shift)
+	var q [4]uint64
+	divKnuth(q[:5-d.n], un[:], d.dn[:d.n], d.v)
+	return fromLimbs(q), denormalize(un[:d.n+1], d.shift)
+}
+
+// quoRem64 returns quotient and remainder of 256-bit value by 64-bit precomputed divisor.
+func (d *Divisor) quoRem64(u Uint256) (q Uint256, r uint64) {
+	s, dn, v := d.shift, d.dn[0], d.v
+	r = u.Hi.Hi >> (64 - s) // zero if s == 0
+	q.Hi.Hi, r = div2by1(r, u.Hi.Hi<<s|u.Hi.Lo>>(64-s), dn, v)
+	q.Hi.Lo, r = div2by1(r, u.Hi.Lo<<s|u.Lo.Hi>>(64-s), dn, v)
+	q.Lo.Hi, r = div2by1(r, u.Lo.Hi<<s|u.Lo.Lo>>(64-s), dn, v)
+	q.Lo.Lo, r = div2by1(r, u.Lo.Lo<<s, dn, v)
+	return q, r >> s
+}
+
+// normalize returns 256-bit value shifted left by s bits as 5 limbs (little-endian).
+func normalize(u Uint256, s uint) (un [5]uint64) {
+	x := toLimbs(u)
+	un[4] = x[3] >> (64 - s) // zero if s == 0
+	for i := 3; i > 0; i-- {
+		un[i] = x[i]<<s | x[i-1]>>(64-s)
+	}
+	un[0] = x[0] << s
+	return
+}
+
+// toLimbs returns 256-bit value as 64-bit limbs (little-endian).
+func toLimbs(u Uint256) [4]uint64 {
+	return [4]uint64{u.Lo.Lo, u.Lo.Hi, u.Hi.Lo, u.Hi.Hi}
+}
+
+// fromLimbs returns 256-bit value from 64-bit limbs (little-endian).
+func fromLimbs(x [4]uint64) Uint256 {
+	return Uint256{
+		Lo: Uint128{Lo: x[0], Hi: x[1]},
+		Hi: Uint128{Lo: x[2], Hi: x[3]},
+	}
+}
+
+// reciprocal2by1 computes the reciprocal of normalized 64-bit divisor:
+// v = floor((2^128-1)/d) - 2^64.
+func reciprocal2by1(d uint64) uint64 {
+	v, _ := bits.Div64(^d, ^uint64(0), d)
+	return v
+}
+
+// reciprocal3by2 computes the reciprocal of normalized 128-bit divisor (d1, d0):
+// v = floor((2^192-1)/(d1, d0)) - 2^64.
+func reciprocal3by2(d1, d0 uint64) uint64 {
+	v := reciprocal2by1(d1)
+	p := d1 * v
+	p += d0
+	if p < d0 {
+		v--
+		if p >= d1 {
+			v--
+			p -= d1
+		}
+		p -= d1
+	}
+
+	t1, t0 := bits.Mul64(v, d0)
+	p += t1
+	if p < t1 {
+		v--
+		if p > d1 || (p == d1 && t0 >= d0) {
+			v--
+		}
+	}
+
+	return v
+}
+
+// div2by1 divides (u1, u0) by normalized divisor d using its reciprocal v.
+// The u1 must be less than d.
+func div2by1(u1, u0, d, v uint64) (q, r uint64) {
+	q1, q0 := bits.Mul64(v, u1)
+	q0, c := bits.Add64(q0, u0, 0)
+	q1, _ = bits.Add64(q1, u1+1, c)
+
+	r = u0 - q1*d
+	var m uint64 // branch-free adjustment, the condition is unpredictable
+	if r > q0 {
+		m = ^uint64(0)
+	}
+	q1 += m
+	r += d & m
+	if r >= d { // unlikely
+		q1++
+		r -= d
+	}
+
+	return q1, r
+}
+
+// div3by2 divides (u2, u1, u0) by normalized divisor (d1, d0) using its reciprocal v.
+// The (u2, u1) must be less than (d1, d0).
+func div3by2(u2, u1, u0, d1, d0, v uint64) (q, r1, r0 uint64) {
+	q1, q0 := bits.Mul64(v, u2)
+	q0, c := bits.Add64(q0, u1, 0)
+	q1, _ = bits.Add64(q1, u2, c)
+
+	r1 = u1 - q1*d1
+	t1, t0 := bits.Mul64(d0, q1)
+	var b uint64
+	r0, b = bits.Sub64(u0, t0, 0)
+	r1, _ = bits.Sub64(r1, t1, b)
+	r0, b = bits.Sub64(r0, d0, 0)
+	r1, _ = bits.Sub64(r1, d1, b)
+	q1++
+
+	var m uint64 // branch-free adjustment, the condition is unpredictable
+	if r1 >= q0 {
+		m = ^uint64(0)
+	}
+	q1 += m
+	r0, c = bits.Add64(r0, d0&m, 0)
+	r1, _ = bits.Add64(r1, d1&m, c)
+	if r1 > d1 || (r1 == d1 && r0 >= d0) { // unlikely
+		q1++
+		r0, b = bits.Sub64(r0, d0, 0)
+		r1, _ = bits.Sub64(r1, d1, b)
+	}
+
+	return q1, r1, r0
+}
@@ -0,0 +1,117 @@
+package uint256
+
+import (
+	"math"
+	"math/big"
+)
+
+// Float64 returns the nearest float64 value of u,
+// halfway values are rounded to even.
+func (u Uint256) Float64() float64 {
+	if u.Hi.IsZero() && u.Lo.Hi == 0 {
+		return float64(u.Lo.Lo) // correctly rounded
+	}
+
+	// the upper 64 bits with sticky bit are rounded exactly the same way
+	s := uint(u.BitLen() - 64)
+	t := u.Rsh(s).Lo.Lo
+	if u.TrailingZeros() < int(s) {
+		t |= 1 // sticky bit
+	}
+	return float64(t) * pow2(s) // exact
+}
+
+// Float32 returns the nearest float32 value of u,
+// halfway values are rounded to even.
+func (u Uint256) Float32() float32 {
+	if u.Hi.IsZero() && u.Lo.Hi == 0 {
+		return float32(u.Lo.Lo) // correctly rounded
+	}
+
+	s := uint(u.BitLen() - 64)
+	t := u.Rsh(s).Lo.Lo
+	if u.TrailingZeros() < int(s) {
+		t |= 1 // sticky bit
+	}
+	return float32(float64(float32(t)) * pow2(s)) // +Inf on overflow
+}
+
+// pow2 returns 2^s as float64. The s must be less than 1024.
+func pow2(s uint) float64 {
+	return math.Float64frombits(uint64(1023+s) << 52)
+}
+
+// FromFloat64 converts float64 to 256-bit value truncating toward zero.
+// Provides ok successful flag as a second return value.
+// If input is NaN or negative then Zero and ok=false returned.
+// If input overflows 256-bit (including +Inf) then Max and ok=false returned.
+func FromFloat64(f float64) (Uint256, bool) {
+	switch {
+	case f != f: // NaN
+		return Zero(), false
+	case f < 0:
+		return Zero(), false // value cannot be negative!
+	case f >= 0x1p256:
+		return Max(), false // value overflows 256-bit!
+	case f < 0x1p64:
+		return From64(uint64(f)), true
+	}
+
+	// f is an integer in [2^64, 2^256)
+	b := math.Float64bits(f)
+	exp := uint(b>>52&0x7FF) - 1075
+	mant := b&(1<<52-1) | 1<<52
+	return From64(mant).Lsh(exp), true
+}
+
+// FromFloat32 converts float32 to 256-bit value truncating toward zero.
+// See FromFloat64 for details.
+func FromFloat32(f float32) (Uint256, bool) {
+	return FromFloat64(float64(f)) // exact
+}
+
+// BigFloat returns the exact *big.Float value of u.
+func (u Uint256) BigFloat() *big.Float {
+	return new(big.Float).SetInt(u.Big())
+}
+
+// BigRat returns the exact *big.Rat value of u.
+func (u Uint256) BigRat() *big.Rat {
+	return new(big.Rat).SetInt(u.Big())
+}
+
+// FromBigFloat converts *big.Float to 256-bit value truncating toward zero.
+// Provides ok successful flag as a second return value.
+// If input is negative then Zero and ok=false returned.
+// If input overflows 256-bit (including +Inf) then Max and ok=false returned.
+// If input is nil then zero 256-bit returned.
+func FromBigFloat(f *big.Float) (Uint256, bool) {
+	switch {
+	case f == nil:
+		return Zero(), true // assuming nil === 0
+	case f.Sign() < 0:
+		return Zero(), false // value cannot be negative!
+	case f.IsInf():
+		return Max(), false // value overflows 256-bit!
+	}
+
+	i, _ := f.Int(nil)
+	return FromBigEx(i)
+}
+
+// FromBigRat converts *big.Rat to 256-bit value truncating toward zero.
+// Provides ok successful flag as a second return value.
+// If input is negative then Zero and ok=false returned.
+// If input overflows 256-bit then Max and ok=false returned.
+// If input is nil then zero 256-bit returned.
+func FromBigRat(r *big.Rat) (Uint256, bool) {
+	switch {
+	case r == nil:
+		return Zero(), true // assuming nil === 0
+	case r.Sign() < 0:
+		return Zero(), false // value cannot be negative!
+	}
+
+	i := new(big.Int).Quo(r.Num(), r.Denom())
+	return FromBigEx(i)
+}
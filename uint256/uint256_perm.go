@@ -0,0 +1,165 @@
+package uint256
+
+import (
+	"errors"
+)
+
+// ErrPermutation indicates that indexes are not a permutation.
+var ErrPermutation = errors.New("invalid permutation")
+
+// DepositBits returns the lower bits of u deposited to the positions of set
+// bits of mask, from the least significant one (the PDEP instruction).
+func (u Uint256) DepositBits(mask Uint256) Uint256 {
+	c := uint(mask.Lo.OnesCount())
+	return Uint256{
+		Lo: u.Lo.DepositBits(mask.Lo),
+		Hi: u.Rsh(c).Lo.DepositBits(mask.Hi),
+	}
+}
+
+// ExtractBits returns the bits of u at the positions of set bits of mask
+// packed to the lower bits of the result (the PEXT instruction).
+func (u Uint256) ExtractBits(mask Uint256) Uint256 {
+	c := uint(mask.Lo.OnesCount())
+	lo := Uint256{Lo: u.Lo.ExtractBits(mask.Lo)}
+	return lo.Or(Uint256{Lo: u.Hi.ExtractBits(mask.Hi)}.Lsh(c))
+}
+
+// DeltaSwap returns the value with bits i and i+shift swapped
+// for each set bit i of mask. This is a single stage of butterfly network.
+// The mask must not have set bits i and i+shift at the same time.
+func (u Uint256) DeltaSwap(mask Uint256, shift uint) Uint256 {
+	t := u.Rsh(shift).Xor(u).And(mask)
+	return u.Xor(t).Xor(t.Lsh(shift))
+}
+
+// benesShifts256 are delta swap shifts of 256-bit Benes network stages.
+var benesShifts256 = [15]uint{128, 64, 32, 16, 8, 4, 2, 1, 2, 4, 8, 16, 32, 64, 128}
+
+// Permutation256 is a precomputed arbitrary bit permutation.
+// It is implemented as Benes network: 15 delta swap stages.
+type Permutation256 struct {
+	masks [len(benesShifts256)][4]uint64 // limbs
+}
+
+// NewPermutation256 returns the permutation such that the bit i
+// of the result is the bit src[i] of the source value.
+// It fails with ErrPermutation if src is not a permutation of [0, 256).
+func NewPermutation256(src []int) (*Permutation256, error) {
+	dest, ok := invertPermutation(src, 256)
+	if !ok {
+		return nil, ErrPermutation
+	}
+
+	p := &Permutation256{}
+	benesRoute(dest, 0, 0, len(p.masks)-1, func(stage, pos int) {
+		p.masks[stage][pos/64] |= 1 << uint(pos%64)
+	})
+	return p, nil
+}
+
+// Apply returns the permuted value.
+func (p *Permutation256) Apply(u Uint256) Uint256 {
+	x := [4]uint64{u.Lo.Lo, u.Lo.Hi, u.Hi.Lo, u.Hi.Hi}
+	for i := range p.masks {
+		m := &p.masks[i]
+		switch s := benesShifts256[i]; s {
+		case 128:
+			t := (x[0] ^ x[2]) & m[0]
+			x[0], x[2] = x[0]^t, x[2]^t
+			t = (x[1] ^ x[3]) & m[1]
+			x[1], x[3] = x[1]^t, x[3]^t
+		case 64:
+			t := (x[0] ^ x[1]) & m[0]
+			x[0], x[1] = x[0]^t, x[1]^t
+			t = (x[2] ^ x[3]) & m[2]
+			x[2], x[3] = x[2]^t, x[3]^t
+		default:
+			for k := range x {
+				t := ((x[k] >> s) ^ x[k]) & m[k]
+				x[k] ^= t ^ (t << s)
+			}
+		}
+	}
+	return Uint256{
+		Lo: Uint128{Lo: x[0], Hi: x[1]},
+		Hi: Uint128{Lo: x[2], Hi: x[3]},
+	}
+}
+
+// invertPermutation returns dest such that dest[src[i]] = i.
+// It returns false if src is not a permutation of [0, n).
+func invertPermutation(src []int, n int) ([]int, bool) {
+	if len(src) != n {
+		return nil, false
+	}
+	dest := make([]int, n)
+	for i := range dest {
+		dest[i] = -1
+	}
+	for i, j := range src {
+		if j < 0 || j >= n || dest[j] >= 0 {
+			return nil, false
+		}
+		dest[j] = i
+	}
+	return dest, true
+}
+
+// benesRoute computes Benes network switches for the block of len(dest)
+// positions starting at base, where input j should go to output dest[j].
+// The first and last stages of the block are swaps with shift len(dest)/2.
+// The set callback is called for each switch to be crossed.
+// This is the classic "looping" algorithm.
+func benesRoute(dest []int, base, first, last int, set func(stage, pos int)) {
+	n := len(dest)
+	if n == 2 {
+		if dest[0] != 0 {
+			set(first, base)
+		}
+		return
+	}
+
+	h := n / 2
+	inv := make([]int, n)
+	for j, o := range dest {
+		inv[o] = j
+	}
+
+	// side of each input: 0 - upper subnetwork, 1 - lower subnetwork
+	side := make([]int8, n)
+	for j := range side {
+		side[j] = -1
+	}
+	for j := range side {
+		for k := j; side[k] < 0; {
+			side[k] = 0
+			// partner output must come from the lower subnetwork
+			p := inv[dest[k]^h]
+			side[p] = 1
+			// partner input must go to the upper subnetwork
+			k = p ^ h
+		}
+	}
+
+	upper := make([]int, h)
+	lower := make([]int, h)
+	for j, o := range dest {
+		if side[j] == 0 {
+			upper[j&(h-1)] = o & (h - 1)
+		} else {
+			lower[j&(h-1)] = o & (h - 1)
+		}
+	}
+	for i := 0; i < h; i++ {
+		if side[i] != 0 {
+			set(first, base+i)
+		}
+		if side[inv[i]] != 0 {
+			set(last, base+i)
+		}
+	}
+
+	benesRoute(upper, base, first+1, last-1, set)
+	benesRoute(lower, base+h, first+1, last-1, set)
+}
@@ -0,0 +1,136 @@
+package uint256
+
+import (
+	"strconv"
+)
+
+// RoundingMode determines how the quotient is rounded by DivRound.
+type RoundingMode byte
+
+// Rounding modes of DivRound.
+const (
+	RoundHalfUp      RoundingMode = iota // to nearest, halves up
+	RoundHalfEven                        // to nearest, halves to even
+	RoundHalfDown                        // to nearest, halves down
+	RoundTowardZero                      // down, i.e. truncated
+	RoundUp                              // up, i.e. ceiling
+	RoundUnnecessary                     // exact, inexact results are rejected
+)
+
+// String returns the name of the rounding mode.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfUp:
+		return "HalfUp"
+	case RoundHalfEven:
+		return "HalfEven"
+	case RoundHalfDown:
+		return "HalfDown"
+	case RoundTowardZero:
+		return "TowardZero"
+	case RoundUp:
+		return "Up"
+	case RoundUnnecessary:
+		return "Unnecessary"
+	}
+	return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// DivCeil returns the division (u/v) rounded up.
+// It panics if v is zero.
+func (u Uint256) DivCeil(v Uint256) Uint256 {
+	q, r := u.QuoRem(v)
+	if !r.IsZero() {
+		q = q.Add(One()) // no overflow since v > 1
+	}
+	return q
+}
+
+// DivRound returns the division (u/v) rounded according to the mode.
+// Unknown modes are the same as RoundTowardZero. If the mode is
+// RoundUnnecessary and v does not divide u then ok=false
+// and the result is truncated.
+// It panics if v is zero.
+func (u Uint256) DivRound(v Uint256, mode RoundingMode) (q Uint256, ok bool) {
+	q, r := u.QuoRem(v)
+	if r.IsZero() {
+		return q, true
+	}
+
+	// compare remainder with half of divisor
+	// without overflow: 2*r <=> v is the same as r <=> v-r
+	var up bool
+	switch c := r.Cmp(v.Sub(r)); mode {
+	case RoundHalfUp:
+		up = c >= 0
+	case RoundHalfEven:
+		up = c > 0 || (c == 0 && q.Lo.Lo&1 != 0)
+	case RoundHalfDown:
+		up = c > 0
+	case RoundUp:
+		up = true
+	case RoundUnnecessary:
+		return q, false
+	}
+	if up {
+		q = q.Add(One()) // no overflow since v > 1
+	}
+	return q, true
+}
+
+// RoundDownTo returns the largest multiple of m less than or equal to u.
+// It panics if m is zero.
+func (u Uint256) RoundDownTo(m Uint256) Uint256 {
+	return u.Sub(u.Mod(m))
+}
+
+// RoundUpTo returns the smallest multiple of m greater than or equal to u.
+// If the result overflows 256-bit then ok=false and the result is wrapped around.
+// It panics if m is zero.
+func (u Uint256) RoundUpTo(m Uint256) (res Uint256, ok bool) {
+	r := u.Mod(m)
+	if r.IsZero() {
+		return u, true
+	}
+	res, carry := Add(u, m.Sub(r), 0)
+	return res, carry == 0
+}
+
+// IsPowerOfTwo reports whether u is a power of two.
+func (u Uint256) IsPowerOfTwo() bool {
+	return u.OnesCount() == 1
+}
+
+// NextPowerOfTwo returns the smallest power of two greater than or equal to u.
+// The result is 1 if u is zero. If the result overflows 256-bit
+// then ok=false and the result is zero.
+func (u Uint256) NextPowerOfTwo() (res Uint256, ok bool) {
+	if u.Cmp(One()) <= 0 {
+		return One(), true
+	}
+	n := uint(u.Sub(One()).BitLen())
+	return One().Lsh(n), n < 256
+}
+
+// AlignDown returns u rounded down to a multiple of align.
+// The align should be a power of two, otherwise it is the same as RoundDownTo.
+// It panics if align is zero.
+func (u Uint256) AlignDown(align Uint256) Uint256 {
+	if !align.IsPowerOfTwo() {
+		return u.RoundDownTo(align)
+	}
+	return u.AndNot(align.Sub(One()))
+}
+
+// AlignUp returns u rounded up to a multiple of align.
+// The align should be a power of two, otherwise it is the same as RoundUpTo.
+// If the result overflows 256-bit then ok=false and the result is wrapped around.
+// It panics if align is zero.
+func (u Uint256) AlignUp(align Uint256) (res Uint256, ok bool) {
+	if !align.IsPowerOfTwo() {
+		return u.RoundUpTo(align)
+	}
+	m := align.Sub(One())
+	res, carry := Add(u, m, 0)
+	return res.AndNot(m), carry == 0
+}
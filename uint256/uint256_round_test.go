@@ -0,0 +1,132 @@
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestRound unit tests for rounding and alignment helpers.
+func TestRound(t *testing.T) {
+	one := big.NewInt(1)
+	max := Max().Big()
+
+	// bigDivRound is reference implementation of DivRound
+	bigDivRound := func(x, y *big.Int, mode RoundingMode) *big.Int {
+		q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+		c := new(big.Int).Lsh(r, 1).Cmp(y)
+		switch {
+		case r.Sign() == 0:
+		case mode == RoundHalfUp && c >= 0,
+			mode == RoundHalfEven && (c > 0 || (c == 0 && q.Bit(0) != 0)),
+			mode == RoundHalfDown && c > 0,
+			mode == RoundUp:
+			q.Add(q, one)
+		}
+		return q
+	}
+
+	check := func(t *testing.T, name string, x, y Uint256, got Uint256, ok bool, expected *big.Int) {
+		t.Helper()
+		eok := expected.Cmp(max) <= 0
+		if !eok {
+			expected = new(big.Int).And(expected, max) // wrap around
+		}
+		if got.Big().Cmp(expected) != 0 || ok != eok {
+			t.Fatalf("%#x.%s(%#x) should be (%#x, %t), got (%#x, %t)", x, name, y, expected, eok, got, ok)
+		}
+	}
+
+	xvalues := make(chan Uint256)
+	go generate256s(200, xvalues)
+	for x := range xvalues {
+		bx := x.Big()
+
+		// powers of two
+		ep := big.NewInt(1)
+		for ep.Cmp(bx) < 0 {
+			ep.Lsh(ep, 1)
+		}
+		got, ok := x.NextPowerOfTwo()
+		check(t, "NextPowerOfTwo", x, Zero(), got, ok, ep)
+		if got, expected := x.IsPowerOfTwo(), x.OnesCount() == 1; got != expected {
+			t.Fatalf("%#x.IsPowerOfTwo() should be %t, got %t", x, expected, got)
+		}
+
+		yvalues := make(chan Uint256)
+		go generate256s(200, yvalues)
+		for y := range yvalues {
+			if y.IsZero() {
+				continue
+			}
+			by := y.Big()
+
+			for _, mode := range []RoundingMode{RoundHalfUp, RoundHalfEven, RoundHalfDown, RoundTowardZero, RoundUp} {
+				got, ok := x.DivRound(y, mode)
+				check(t, "DivRound/"+mode.String(), x, y, got, ok, bigDivRound(bx, by, mode))
+			}
+
+			quo, rem := new(big.Int).QuoRem(bx, by, new(big.Int))
+			if got, ok := x.DivRound(y, RoundUnnecessary); got.Big().Cmp(quo) != 0 || ok != (rem.Sign() == 0) {
+				t.Fatalf("%#x.DivRound(%#x, Unnecessary) should be (%#x, %t), got (%#x, %t)", x, y, quo, rem.Sign() == 0, got, ok)
+			}
+
+			ceil := new(big.Int).Add(bx, by)
+			ceil.Sub(ceil, one).Quo(ceil, by)
+			check(t, "DivCeil", x, y, x.DivCeil(y), true, ceil)
+
+			down := new(big.Int).Mul(new(big.Int).Quo(bx, by), by)
+			check(t, "RoundDownTo", x, y, x.RoundDownTo(y), true, down)
+			up := new(big.Int).Mul(ceil, by)
+			got, ok := x.RoundUpTo(y)
+			check(t, "RoundUpTo", x, y, got, ok, up)
+
+			// alignment: power of two and arbitrary
+			for _, a := range []Uint256{One().Lsh(uint(y.Lo.Lo % 256)), y} {
+				ba := a.Big()
+				down := new(big.Int).Mul(new(big.Int).Quo(bx, ba), ba)
+				check(t, "AlignDown", x, a, x.AlignDown(a), true, down)
+				up := new(big.Int).Add(bx, ba)
+				up.Sub(up, one).Quo(up, ba).Mul(up, ba)
+				got, ok := x.AlignUp(a)
+				check(t, "AlignUp", x, a, got, ok, up)
+			}
+		}
+	}
+
+	t.Run("manual", func(t *testing.T) {
+		for _, c := range []struct {
+			x, y     uint64
+			mode     RoundingMode
+			expected uint64
+		}{
+			{5, 2, RoundHalfUp, 3},
+			{5, 2, RoundHalfEven, 2},
+			{7, 2, RoundHalfEven, 4},
+			{5, 2, RoundHalfDown, 2},
+			{5, 2, RoundTowardZero, 2},
+			{5, 2, RoundUp, 3},
+			{6, 2, RoundUp, 3},
+			{6, 2, RoundUnnecessary, 3},
+			{5, 2, RoundUnnecessary, 2},
+			{8, 3, RoundHalfDown, 3},
+			{7, 3, RoundHalfUp, 2},
+		} {
+			eok := c.mode != RoundUnnecessary || c.x%c.y == 0
+			if got, ok := From64(c.x).DivRound(From64(c.y), c.mode); !got.Equals(From64(c.expected)) || ok != eok {
+				t.Fatalf("%d.DivRound(%d, %v) should be (%d, %t), got (%d, %t)", c.x, c.y, c.mode, c.expected, eok, got, ok)
+			}
+		}
+		if got, ok := Max().NextPowerOfTwo(); ok || !got.IsZero() {
+			t.Fatalf("Max().NextPowerOfTwo() should overflow, got (%#x, %t)", got, ok)
+		}
+		if got, ok := Zero().NextPowerOfTwo(); !ok || !got.Equals(One()) {
+			t.Fatalf("Zero().NextPowerOfTwo() should be 1, got (%#x, %t)", got, ok)
+		}
+		if got, ok := Max().AlignUp(From64(16)); ok || !got.IsZero() {
+			t.Fatalf("Max().AlignUp(16) should overflow, got (%#x, %t)", got, ok)
+		}
+		if got := RoundingMode(10).String(); got != "RoundingMode(10)" {
+			t.Fatalf("RoundingMode(10).String() should be RoundingMode(10), got %q", got)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package uint256
+
+import (
+	"math/bits"
+)
+
+// The Set* methods below are an in-place alternative to the value API,
+// mirroring math/big: the result is stored to the receiver z which is also
+// returned. The receiver may alias any of the arguments.
+
+// Set sets z to x and returns z.
+func (z *Uint256) Set(x *Uint256) *Uint256 {
+	*z = *x
+	return z
+}
+
+// SetAdd sets z to the sum (x+y) and returns z.
+// Wrap-around semantic is used here.
+func (z *Uint256) SetAdd(x, y *Uint256) *Uint256 {
+	var c uint64
+	z.Lo.Lo, c = bits.Add64(x.Lo.Lo, y.Lo.Lo, 0)
+	z.Lo.Hi, c = bits.Add64(x.Lo.Hi, y.Lo.Hi, c)
+	z.Hi.Lo, c = bits.Add64(x.Hi.Lo, y.Hi.Lo, c)
+	z.Hi.Hi, _ = bits.Add64(x.Hi.Hi, y.Hi.Hi, c)
+	return z
+}
+
+// SetSub sets z to the difference (x-y) and returns z.
+// Wrap-around semantic is used here.
+func (z *Uint256) SetSub(x, y *Uint256) *Uint256 {
+	var b uint64
+	z.Lo.Lo, b = bits.Sub64(x.Lo.Lo, y.Lo.Lo, 0)
+	z.Lo.Hi, b = bits.Sub64(x.Lo.Hi, y.Lo.Hi, b)
+	z.Hi.Lo, b = bits.Sub64(x.Hi.Lo, y.Hi.Lo, b)
+	z.Hi.Hi, _ = bits.Sub64(x.Hi.Hi, y.Hi.Hi, b)
+	return z
+}
+
+// SetMul sets z to the product (x*y) and returns z.
+// Wrap-around semantic is used here.
+func (z *Uint256) SetMul(x, y *Uint256) *Uint256 {
+	*z = mul(*x, *y)
+	return z
+}
+
+// SetQuo sets z to the quotient (x/y) and returns z.
+// It panics if y is zero.
+func (z *Uint256) SetQuo(x, y *Uint256) *Uint256 {
+	*z, _ = x.QuoRem(*y)
+	return z
+}
+
+// SetRem sets z to the remainder (x%y) and returns z.
+// It panics if y is zero.
+func (z *Uint256) SetRem(x, y *Uint256) *Uint256 {
+	_, *z = x.QuoRem(*y)
+	return z
+}
+
+// SetQuoRem sets z to the quotient (x/y) and r to the remainder (x%y)
+// and returns the pair (z, r). It panics if y is zero.
+func (z *Uint256) SetQuoRem(x, y, r *Uint256) (*Uint256, *Uint256) {
+	*z, *r = x.QuoRem(*y)
+	return z, r
+}
+
+// SetLsh sets z to the left shift (x<<n) and returns z.
+func (z *Uint256) SetLsh(x *Uint256, n uint) *Uint256 {
+	*z = x.Lsh(n)
+	return z
+}
+
+// SetRsh sets z to the right shift (x>>n) and returns z.
+func (z *Uint256) SetRsh(x *Uint256, n uint) *Uint256 {
+	*z = x.Rsh(n)
+	return z
+}
+
+// SetAnd sets z to the bitwise AND (x&y) and returns z.
+func (z *Uint256) SetAnd(x, y *Uint256) *Uint256 {
+	z.Lo.Lo, z.Lo.Hi = x.Lo.Lo&y.Lo.Lo, x.Lo.Hi&y.Lo.Hi
+	z.Hi.Lo, z.Hi.Hi = x.Hi.Lo&y.Hi.Lo, x.Hi.Hi&y.Hi.Hi
+	return z
+}
+
+// SetOr sets z to the bitwise OR (x|y) and returns z.
+func (z *Uint256) SetOr(x, y *Uint256) *Uint256 {
+	z.Lo.Lo, z.Lo.Hi = x.Lo.Lo|y.Lo.Lo, x.Lo.Hi|y.Lo.Hi
+	z.Hi.Lo, z.Hi.Hi = x.Hi.Lo|y.Hi.Lo, x.Hi.Hi|y.Hi.Hi
+	return z
+}
+
+// SetXor sets z to the bitwise XOR (x^y) and returns z.
+func (z *Uint256) SetXor(x, y *Uint256) *Uint256 {
+	z.Lo.Lo, z.Lo.Hi = x.Lo.Lo^y.Lo.Lo, x.Lo.Hi^y.Lo.Hi
+	z.Hi.Lo, z.Hi.Hi = x.Hi.Lo^y.Hi.Lo, x.Hi.Hi^y.Hi.Hi
+	return z
+}
@@ -0,0 +1,53 @@
+package uint256
+
+import (
+	"testing"
+)
+
+// TestSet unit tests for in-place Set* methods.
+func TestSet(t *testing.T) {
+	check := func(t *testing.T, name string, x, y, expected Uint256, op func(z, x, y *Uint256) *Uint256) {
+		t.Helper()
+		var z Uint256
+		if got := op(&z, &x, &y); got != &z || !z.Equals(expected) {
+			t.Fatalf("%s(%#x, %#x) should be %#x, got %#x", name, x, y, expected, z)
+		}
+		if zx := x; !op(&zx, &zx, &y).Equals(expected) { // alias x
+			t.Fatalf("%s(%#x, %#x) aliased x should be %#x, got %#x", name, x, y, expected, zx)
+		}
+		if zy := y; !op(&zy, &x, &zy).Equals(expected) { // alias y
+			t.Fatalf("%s(%#x, %#x) aliased y should be %#x, got %#x", name, x, y, expected, zy)
+		}
+	}
+
+	xvalues := make(chan Uint256)
+	go generate256s(100, xvalues)
+	for x := range xvalues {
+		yvalues := make(chan Uint256)
+		go generate256s(100, yvalues)
+		for y := range yvalues {
+			check(t, "Set", x, y, x, func(z, x, _ *Uint256) *Uint256 { return z.Set(x) })
+			check(t, "SetAdd", x, y, x.Add(y), (*Uint256).SetAdd)
+			check(t, "SetSub", x, y, x.Sub(y), (*Uint256).SetSub)
+			check(t, "SetMul", x, y, x.Mul(y), (*Uint256).SetMul)
+			check(t, "SetAnd", x, y, x.And(y), (*Uint256).SetAnd)
+			check(t, "SetOr", x, y, x.Or(y), (*Uint256).SetOr)
+			check(t, "SetXor", x, y, x.Xor(y), (*Uint256).SetXor)
+
+			n := uint(y.Lo.Lo % 260)
+			check(t, "SetLsh", x, y, x.Lsh(n), func(z, x, _ *Uint256) *Uint256 { return z.SetLsh(x, n) })
+			check(t, "SetRsh", x, y, x.Rsh(n), func(z, x, _ *Uint256) *Uint256 { return z.SetRsh(x, n) })
+
+			if !y.IsZero() {
+				eq, er := x.QuoRem(y)
+				check(t, "SetQuo", x, y, eq, (*Uint256).SetQuo)
+				check(t, "SetRem", x, y, er, (*Uint256).SetRem)
+
+				var q, r Uint256
+				if zq, zr := q.SetQuoRem(&x, &y, &r); zq != &q || zr != &r || !q.Equals(eq) || !r.Equals(er) {
+					t.Fatalf("SetQuoRem(%#x, %#x) should be (%#x, %#x), got (%#x, %#x)", x, y, eq, er, q, r)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package uint256
+
+// LoadSliceLittleEndian loads 256-bit values from byte slice in little-endian byte order.
+// It loads min(len(dst), len(src)/32) values and returns that number.
+// The values are copied, on little-endian hosts with a single memmove;
+// see ViewSliceLittleEndian for a view without copying.
+func LoadSliceLittleEndian(dst []Uint256, src []byte) int {
+	n := len(src) / 32
+	if len(dst) < n {
+		n = len(dst)
+	}
+	dst = dst[:n]
+	if copyFromBytes(dst, src) {
+		return n
+	}
+	for i := range dst {
+		dst[i] = LoadLittleEndian(src[i*32:])
+	}
+	return n
+}
+
+// LoadSliceBigEndian loads 256-bit values from byte slice in big-endian byte order.
+// It loads min(len(dst), len(src)/32) values and returns that number.
+func LoadSliceBigEndian(dst []Uint256, src []byte) int {
+	n := len(src) / 32
+	if len(dst) < n {
+		n = len(dst)
+	}
+	dst = dst[:n]
+	for i := range dst {
+		dst[i] = LoadBigEndian(src[i*32:])
+	}
+	return n
+}
+
+// StoreSliceLittleEndian stores 256-bit values in byte slice in little-endian byte order.
+// It stores min(len(dst)/32, len(src)) values and returns that number.
+// The values are copied, on little-endian hosts with a single memmove;
+// see ViewBytesLittleEndian for a view without copying.
+func StoreSliceLittleEndian(dst []byte, src []Uint256) int {
+	n := len(dst) / 32
+	if len(src) < n {
+		n = len(src)
+	}
+	src = src[:n]
+	if copyToBytes(dst, src) {
+		return n
+	}
+	for i := range src {
+		StoreLittleEndian(dst[i*32:], src[i])
+	}
+	return n
+}
+
+// StoreSliceBigEndian stores 256-bit values in byte slice in big-endian byte order.
+// It stores min(len(dst)/32, len(src)) values and returns that number.
+func StoreSliceBigEndian(dst []byte, src []Uint256) int {
+	n := len(dst) / 32
+	if len(src) < n {
+		n = len(src)
+	}
+	src = src[:n]
+	for i := range src {
+		StoreBigEndian(dst[i*32:], src[i])
+	}
+	return n
+}
+
+// ViewSliceLittleEndian returns byte slice in little-endian byte order
+// as len(src)/32 256-bit values without copying, e.g. for memory-mapped files.
+// The result aliases src memory, so changes are visible through both.
+// The ok is false if the view is not possible: the host is big-endian,
+// src is not aligned to 8 bytes (4 bytes on 32-bit platforms)
+// or the purego build tag is set. Use LoadSliceLittleEndian then.
+func ViewSliceLittleEndian(src []byte) (dst []Uint256, ok bool) {
+	return viewValues(src)
+}
+
+// ViewBytesLittleEndian returns 256-bit values as len(src)*32 bytes
+// in little-endian byte order without copying.
+// The result aliases src memory, so changes are visible through both.
+// The ok is false if the view is not possible: the host is big-endian
+// or the purego build tag is set. Use StoreSliceLittleEndian then.
+func ViewBytesLittleEndian(src []Uint256) (dst []byte, ok bool) {
+	return viewBytes(src)
+}
@@ -0,0 +1,24 @@
+//go:build purego
+// +build purego
+
+package uint256
+
+// copyFromBytes always falls back to portable code.
+func copyFromBytes(dst []Uint256, src []byte) bool {
+	return false
+}
+
+// copyToBytes always falls back to portable code.
+func copyToBytes(dst []byte, src []Uint256) bool {
+	return false
+}
+
+// viewBytes is never possible without unsafe.
+func viewBytes(u []Uint256) ([]byte, bool) {
+	return nil, false
+}
+
+// viewValues is never possible without unsafe.
+func viewValues(b []byte) ([]Uint256, bool) {
+	return nil, false
+}
@@ -0,0 +1,105 @@
+package uint256
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSlice unit tests for bulk load and store functions.
+func TestSlice(t *testing.T) {
+	for _, count := range []int{0, 1, 2, 3, 17, 100} {
+		values := rand256slice(count)
+
+		// reference encoding
+		le := make([]byte, 32*count)
+		be := make([]byte, 32*count)
+		for i, v := range values {
+			StoreLittleEndian(le[i*32:], v)
+			StoreBigEndian(be[i*32:], v)
+		}
+
+		buf := make([]byte, 32*count+7) // with extra tail
+		if n := StoreSliceLittleEndian(buf, values); n != count || !bytes.Equal(buf[:n*32], le) {
+			t.Fatalf("StoreSliceLittleEndian(%d) mismatch, n=%d", count, n)
+		}
+		if n := StoreSliceBigEndian(buf, values); n != count || !bytes.Equal(buf[:n*32], be) {
+			t.Fatalf("StoreSliceBigEndian(%d) mismatch, n=%d", count, n)
+		}
+
+		dst := make([]Uint256, count+1) // with extra tail
+		if n := LoadSliceLittleEndian(dst, le); n != count || !equalSlices(dst[:n], values) || !dst[count].IsZero() {
+			t.Fatalf("LoadSliceLittleEndian(%d) mismatch, n=%d", count, n)
+		}
+		if n := LoadSliceBigEndian(dst, be); n != count || !equalSlices(dst[:n], values) || !dst[count].IsZero() {
+			t.Fatalf("LoadSliceBigEndian(%d) mismatch, n=%d", count, n)
+		}
+
+		// unaligned source and destination
+		ubuf := make([]byte, 32*count+1)
+		copy(ubuf[1:], le)
+		if n := LoadSliceLittleEndian(dst, ubuf[1:]); n != count || !equalSlices(dst[:n], values) {
+			t.Fatalf("LoadSliceLittleEndian(%d) unaligned mismatch, n=%d", count, n)
+		}
+		if n := StoreSliceLittleEndian(ubuf[1:], values); n != count || !bytes.Equal(ubuf[1:], le) {
+			t.Fatalf("StoreSliceLittleEndian(%d) unaligned mismatch, n=%d", count, n)
+		}
+
+		// short destination
+		if count > 0 {
+			half := count / 2
+			if n := LoadSliceLittleEndian(dst[:half], le); n != half {
+				t.Fatalf("LoadSliceLittleEndian(%d) short dst should load %d, got %d", count, half, n)
+			}
+			if n := StoreSliceBigEndian(buf[:32*half+31], values); n != half {
+				t.Fatalf("StoreSliceBigEndian(%d) short dst should store %d, got %d", count, half, n)
+			}
+		}
+	}
+}
+
+// TestSliceView unit tests for zero-copy views.
+func TestSliceView(t *testing.T) {
+	if _, ok := ViewBytesLittleEndian(nil); !ok {
+		t.Skip("views are not supported")
+	}
+
+	for _, count := range []int{0, 1, 2, 3, 17, 100} {
+		values := rand256slice(count)
+		le := make([]byte, 32*count)
+		StoreSliceLittleEndian(le, values)
+
+		b, ok := ViewBytesLittleEndian(values)
+		if !ok || !bytes.Equal(b, le) {
+			t.Fatalf("ViewBytesLittleEndian(%d) mismatch, ok=%t", count, ok)
+		}
+		u, ok := ViewSliceLittleEndian(le)
+		if !ok || !equalSlices(u, values) {
+			t.Fatalf("ViewSliceLittleEndian(%d) mismatch, ok=%t", count, ok)
+		}
+
+		// views alias the memory
+		if count > 0 {
+			u, _ = ViewSliceLittleEndian(b)
+			u[0] = u[0].Add(One())
+			if values[0] != u[0] || LoadLittleEndian(b) != u[0] {
+				t.Fatalf("ViewSliceLittleEndian(%d) does not alias memory", count)
+			}
+			if _, ok := ViewSliceLittleEndian(b[1:]); ok {
+				t.Fatalf("ViewSliceLittleEndian(%d) should reject unaligned source", count)
+			}
+		}
+	}
+}
+
+// equalSlices reports whether two slices are equal.
+func equalSlices(a, b []Uint256) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
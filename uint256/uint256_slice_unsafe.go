@@ -0,0 +1,85 @@
+//go:build !purego
+// +build !purego
+
+package uint256
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// hostLittleEndian is true if native byte order is little-endian,
+// so the memory of Uint256 is the same as its little-endian encoding.
+var hostLittleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+// bytesOf reinterprets memory of u as bytes, without copying.
+func bytesOf(u []Uint256) []byte {
+	var b []byte
+	if len(u) != 0 {
+		h := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+		h.Data = uintptr(unsafe.Pointer(&u[0]))
+		h.Len = len(u) * 32
+		h.Cap = h.Len
+	}
+	return b
+}
+
+// valuesOf reinterprets memory of b as len(b)/32 values, without copying.
+// The b must be aligned as Uint256.
+func valuesOf(b []byte) []Uint256 {
+	var u []Uint256
+	if n := len(b) / 32; n != 0 {
+		h := (*reflect.SliceHeader)(unsafe.Pointer(&u))
+		h.Data = uintptr(unsafe.Pointer(&b[0]))
+		h.Len = n
+		h.Cap = n
+	}
+	return u
+}
+
+// viewBytes returns memory of u as little-endian bytes.
+// It returns false if native byte order is not little-endian.
+func viewBytes(u []Uint256) ([]byte, bool) {
+	if !hostLittleEndian {
+		return nil, false
+	}
+	return bytesOf(u), true
+}
+
+// viewValues returns little-endian bytes b as values.
+// It returns false if native byte order is not little-endian
+// or b is not aligned as Uint256.
+func viewValues(b []byte) ([]Uint256, bool) {
+	if !hostLittleEndian {
+		return nil, false
+	}
+	if len(b) != 0 && uintptr(unsafe.Pointer(&b[0]))%unsafe.Alignof(Uint256{}) != 0 {
+		return nil, false
+	}
+	return valuesOf(b), true
+}
+
+// copyFromBytes copies little-endian src bytes into dst memory
+// with a single memmove, which is faster than per-element decoding.
+// It returns false if native byte order is not little-endian.
+func copyFromBytes(dst []Uint256, src []byte) bool {
+	if !hostLittleEndian {
+		return false
+	}
+	copy(bytesOf(dst), src)
+	return true
+}
+
+// copyToBytes copies src memory into little-endian dst bytes
+// with a single memmove, which is faster than per-element encoding.
+// It returns false if native byte order is not little-endian.
+func copyToBytes(dst []byte, src []Uint256) bool {
+	if !hostLittleEndian {
+		return false
+	}
+	copy(dst, bytesOf(src))
+	return true
+}
@@ -5,6 +5,7 @@ package uint256
 import (
 	"crypto/rand"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 
@@ -267,6 +268,46 @@ func TestDiv(t *testing.T) {
 	}
 }
 
+// TestQuoRem unit tests for 256-bit division on edge limb values.
+func TestQuoRem(t *testing.T) {
+	// limb values that trigger trial quotient corrections and add back
+	fixed := []uint64{0, 1, 1 << 63, 1<<63 - 1, math.MaxUint64 - 1, math.MaxUint64}
+	var values []Uint256
+	for _, a := range fixed {
+		for _, b := range fixed {
+			for _, c := range fixed {
+				values = append(values,
+					Uint256{Lo: Uint128{Lo: c, Hi: b}, Hi: Uint128{Lo: a, Hi: a}},
+					Uint256{Lo: Uint128{Lo: c, Hi: c}, Hi: Uint128{Lo: b, Hi: a}},
+					Uint256{Lo: Uint128{Lo: c, Hi: b}, Hi: Uint128{Lo: a}},
+					Uint256{Lo: Uint128{Lo: b, Hi: a}, Hi: Uint128{Lo: c}})
+			}
+		}
+	}
+
+	for _, x := range values {
+		for _, y := range values {
+			if y.IsZero() {
+				continue
+			}
+
+			eq, er := new(big.Int).QuoRem(x.Big(), y.Big(), new(big.Int))
+			if q, r := x.QuoRem(y); eq.Cmp(q.Big()) != 0 || er.Cmp(r.Big()) != 0 {
+				t.Fatalf("mismatch: (%#x QuoRem %#x) should equal (%#x, %#x), got (%#x, %#x)", x, y, eq, er, q, r)
+			}
+
+			if y.Cmp(x) > 0 {
+				xy := new(big.Int).Lsh(x.Big(), 256)
+				xy.Or(xy, y.Big())
+				eq, er := new(big.Int).QuoRem(xy, y.Big(), new(big.Int))
+				if q, r := Div(x, y, y); eq.Cmp(q.Big()) != 0 || er.Cmp(r.Big()) != 0 {
+					t.Fatalf("mismatch: (%#x Div %#x) should equal (%#x, %#x), got (%#x, %#x)", xy, y, eq, er, q, r)
+				}
+			}
+		}
+	}
+}
+
 // TestArithmetic compare Uint256 arithmetic methods to their math/big equivalents
 func TestArithmetic(t *testing.T) {
 	xvalues := make(chan Uint256)
@@ -0,0 +1,169 @@
+// Package vec provides element-wise operations over slices of 256-bit values.
+//
+// All functions process len(dst) elements and panic if any source slice
+// is shorter than dst. The dst may be the same slice as any of the sources.
+// Arithmetic uses the same wrap-around semantic as the uint256 package.
+package vec
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// errShortSource is panic value for a source slice shorter than dst.
+var errShortSource = errors.New("vec: source slice is shorter than dst")
+
+// Uint256 is type alias for 256-bit unsigned integer.
+type Uint256 = uint256.Uint256
+
+// AddSlices sets dst[i] = x[i] + y[i].
+func AddSlices(dst, x, y []Uint256) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		a, b := &x[i], &y[i]
+		var c uint64
+		var r Uint256
+		r.Lo.Lo, c = bits.Add64(a.Lo.Lo, b.Lo.Lo, 0)
+		r.Lo.Hi, c = bits.Add64(a.Lo.Hi, b.Lo.Hi, c)
+		r.Hi.Lo, c = bits.Add64(a.Hi.Lo, b.Hi.Lo, c)
+		r.Hi.Hi, _ = bits.Add64(a.Hi.Hi, b.Hi.Hi, c)
+		dst[i] = r
+	}
+}
+
+// SubSlices sets dst[i] = x[i] - y[i].
+func SubSlices(dst, x, y []Uint256) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		a, b := &x[i], &y[i]
+		var c uint64
+		var r Uint256
+		r.Lo.Lo, c = bits.Sub64(a.Lo.Lo, b.Lo.Lo, 0)
+		r.Lo.Hi, c = bits.Sub64(a.Lo.Hi, b.Lo.Hi, c)
+		r.Hi.Lo, c = bits.Sub64(a.Hi.Lo, b.Hi.Lo, c)
+		r.Hi.Hi, _ = bits.Sub64(a.Hi.Hi, b.Hi.Hi, c)
+		dst[i] = r
+	}
+}
+
+// MulSlices sets dst[i] = x[i] * y[i].
+func MulSlices(dst, x, y []Uint256) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		dst[i] = x[i].Mul(y[i])
+	}
+}
+
+// AddScalar sets dst[i] = x[i] + y.
+func AddScalar(dst, x []Uint256, y Uint256) {
+	if len(x) < len(dst) {
+		panic(errShortSource)
+	}
+	x = x[:len(dst)] // bounds check elimination
+	for i := range dst {
+		a := &x[i]
+		var c uint64
+		var r Uint256
+		r.Lo.Lo, c = bits.Add64(a.Lo.Lo, y.Lo.Lo, 0)
+		r.Lo.Hi, c = bits.Add64(a.Lo.Hi, y.Lo.Hi, c)
+		r.Hi.Lo, c = bits.Add64(a.Hi.Lo, y.Hi.Lo, c)
+		r.Hi.Hi, _ = bits.Add64(a.Hi.Hi, y.Hi.Hi, c)
+		dst[i] = r
+	}
+}
+
+// SubScalar sets dst[i] = x[i] - y.
+func SubScalar(dst, x []Uint256, y Uint256) {
+	if len(x) < len(dst) {
+		panic(errShortSource)
+	}
+	x = x[:len(dst)] // bounds check elimination
+	for i := range dst {
+		a := &x[i]
+		var c uint64
+		var r Uint256
+		r.Lo.Lo, c = bits.Sub64(a.Lo.Lo, y.Lo.Lo, 0)
+		r.Lo.Hi, c = bits.Sub64(a.Lo.Hi, y.Lo.Hi, c)
+		r.Hi.Lo, c = bits.Sub64(a.Hi.Lo, y.Hi.Lo, c)
+		r.Hi.Hi, _ = bits.Sub64(a.Hi.Hi, y.Hi.Hi, c)
+		dst[i] = r
+	}
+}
+
+// MulScalar sets dst[i] = x[i] * y.
+func MulScalar(dst, x []Uint256, y Uint256) {
+	if len(x) < len(dst) {
+		panic(errShortSource)
+	}
+	x = x[:len(dst)] // bounds check elimination
+	for i := range dst {
+		dst[i] = x[i].Mul(y)
+	}
+}
+
+// SumWithCarry returns the sum of all x elements without overflow:
+// the total is (carry, sum), where carry is the upper 64 bits.
+func SumWithCarry(x []Uint256) (sum Uint256, carry uint64) {
+	for i := range x {
+		a := &x[i]
+		var c uint64
+		sum.Lo.Lo, c = bits.Add64(sum.Lo.Lo, a.Lo.Lo, 0)
+		sum.Lo.Hi, c = bits.Add64(sum.Lo.Hi, a.Lo.Hi, c)
+		sum.Hi.Lo, c = bits.Add64(sum.Hi.Lo, a.Hi.Lo, c)
+		sum.Hi.Hi, c = bits.Add64(sum.Hi.Hi, a.Hi.Hi, c)
+		carry += c
+	}
+	return
+}
+
+// MinMax returns the minimum and maximum of x elements.
+// Both are zero if x is empty.
+func MinMax(x []Uint256) (min, max Uint256) {
+	if len(x) == 0 {
+		return
+	}
+
+	min, max = x[0], x[0]
+	for i := 1; i < len(x); i++ {
+		if x[i].Cmp(min) < 0 {
+			min = x[i]
+		} else if x[i].Cmp(max) > 0 {
+			max = x[i]
+		}
+	}
+	return
+}
+
+// Equal sets mask dst[i] = (x[i] == y[i]).
+func Equal(dst []bool, x, y []Uint256) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		dst[i] = x[i] == y[i]
+	}
+}
+
+// Compare sets mask dst[i] to -1, 0 or +1 if x[i] is
+// less than, equal to or greater than y[i] respectively.
+func Compare(dst []int8, x, y []Uint256) {
+	if len(x) < len(dst) || len(y) < len(dst) {
+		panic(errShortSource)
+	}
+	x, y = x[:len(dst)], y[:len(dst)] // bounds check elimination
+	for i := range dst {
+		dst[i] = int8(x[i].Cmp(y[i]))
+	}
+}
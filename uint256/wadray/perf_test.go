@@ -0,0 +1,38 @@
+package wadray
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// DummyOutput is exported to avoid unwanted optimizations
+var DummyOutput Uint256
+
+// BenchmarkWadRay performance tests for WAD and RAY math.
+func BenchmarkWadRay(b *testing.B) {
+	const K = 1024 // should be power of 2
+	r := rand.New(rand.NewSource(1))
+	x := make([]Uint256, K)
+	for i := range x {
+		x[i] = rand256(r).Rsh(128) // no overflows
+	}
+	rate := Ray().Add(x[0].Rsh(100)) // close to one
+
+	b.Run("RayMul", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			DummyOutput, _ = RayMul(x[n%K], x[(n+1)%K])
+		}
+	})
+
+	b.Run("RayDiv", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			DummyOutput, _ = RayDiv(x[n%K], Ray())
+		}
+	})
+
+	b.Run("Rpow/year", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			DummyOutput, _ = Rpow(rate, 365*24*60*60, Ray())
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Package wadray implements the WAD and RAY fixed-point math of the
+// lending protocols (Aave, MakerDAO) on top of 256-bit values.
+//
+// A WAD is a decimal fixed-point number with 18 decimals, i.e. the
+// Uint256 value u represents u/10^18. A RAY has 27 decimals, u/10^27.
+// The functions follow the reference Solidity code: the products and
+// quotients are rounded half up, and a result is reported as overflow
+// (ok=false) exactly when the Solidity code reverts, i.e. when any
+// 256-bit intermediate value overflows, even if the final result fits.
+package wadray
+
+import (
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// Uint256 is type alias for 256-bit unsigned integer.
+type Uint256 = uint256.Uint256
+
+// Decimals of the fixed-point formats.
+const (
+	WadDecimals = 18
+	RayDecimals = 27
+)
+
+// the fixed-point constants
+var (
+	wad      = uint256.From64(1e18)
+	halfWad  = uint256.From64(0.5e18)
+	ray      = uint256.From64(1e18).Mul(uint256.From64(1e9))
+	halfRay  = uint256.From64(1e18).Mul(uint256.From64(0.5e9))
+	wadRatio = uint256.From64(1e9) // RAY/WAD
+)
+
+// Wad returns one as WAD, i.e. 10^18.
+func Wad() Uint256 {
+	return wad
+}
+
+// Ray returns one as RAY, i.e. 10^27.
+func Ray() Uint256 {
+	return ray
+}
+
+// WadMul returns the product a·b of two WADs, rounded half up,
+// i.e. (a·b + WAD/2) / WAD.
+// Provides ok successful flag as a second return value.
+// If the intermediate value overflows then Zero and ok=false returned.
+func WadMul(a, b Uint256) (Uint256, bool) {
+	return mulDivHalfUp(a, b, wad, halfWad)
+}
+
+// WadDiv returns the quotient a/b of two WADs, rounded half up,
+// i.e. (a·WAD + b/2) / b.
+// Provides ok successful flag as a second return value.
+// If b is zero or the intermediate value overflows then Zero and ok=false returned.
+func WadDiv(a, b Uint256) (Uint256, bool) {
+	if b.IsZero() {
+		return Uint256{}, false
+	}
+	return mulDivHalfUp(a, wad, b, b.Rsh(1))
+}
+
+// RayMul returns the product a·b of two RAYs, rounded half up,
+// i.e. (a·b + RAY/2) / RAY.
+// Provides ok successful flag as a second return value.
+// If the intermediate value overflows then Zero and ok=false returned.
+func RayMul(a, b Uint256) (Uint256, bool) {
+	return mulDivHalfUp(a, b, ray, halfRay)
+}
+
+// RayDiv returns the quotient a/b of two RAYs, rounded half up,
+// i.e. (a·RAY + b/2) / b.
+// Provides ok successful flag as a second return value.
+// If b is zero or the intermediate value overflows then Zero and ok=false returned.
+func RayDiv(a, b Uint256) (Uint256, bool) {
+	if b.IsZero() {
+		return Uint256{}, false
+	}
+	return mulDivHalfUp(a, ray, b, b.Rsh(1))
+}
+
+// WadToRay converts WAD to RAY, i.e. a·10^9.
+// Provides ok successful flag as a second return value.
+// If the result overflows then Zero and ok=false returned.
+func WadToRay(a Uint256) (Uint256, bool) {
+	hi, lo := uint256.Mul(a, wadRatio)
+	if !hi.IsZero() {
+		return Uint256{}, false
+	}
+	return lo, true
+}
+
+// RayToWad converts RAY to WAD, rounded half up, i.e. a/10^9.
+func RayToWad(a Uint256) Uint256 {
+	q, r := a.QuoRem64(1e9)
+	if r >= 1e9/2 {
+		q = q.Add(uint256.One()) // no overflow since q < Max/10^9
+	}
+	return q
+}
+
+// Rpow returns x^n, where x is a fixed-point number with the given base,
+// e.g. RAY, by squaring, as the MakerDAO rpow does. Each intermediate
+// product is rounded half up, so the result is the same as Solidity's.
+// 0^0 is one, i.e. base. The base must not be zero.
+// Provides ok successful flag as a second return value.
+// If any intermediate value overflows then Zero and ok=false returned.
+func Rpow(x Uint256, n uint64, base Uint256) (Uint256, bool) {
+	if x.IsZero() {
+		if n == 0 {
+			return base, true
+		}
+		return Uint256{}, true
+	}
+
+	z := base
+	if n%2 != 0 {
+		z = x
+	}
+
+	half := base.Rsh(1)
+	var ok bool
+	for n /= 2; n != 0; n /= 2 {
+		if x, ok = mulDivHalfUp(x, x, base, half); !ok {
+			return Uint256{}, false
+		}
+		if n%2 != 0 {
+			if z, ok = mulDivHalfUp(z, x, base, half); !ok {
+				return Uint256{}, false
+			}
+		}
+	}
+	return z, true
+}
+
+// mulDivHalfUp returns (a·b + half) / d.
+// Provides ok successful flag as a second return value.
+// If a·b + half overflows 256 bits then Zero and ok=false returned.
+func mulDivHalfUp(a, b, d, half Uint256) (Uint256, bool) {
+	hi, lo := uint256.Mul(a, b)
+	sum, carry := uint256.Add(lo, half, 0)
+	if !hi.IsZero() || carry != 0 {
+		return Uint256{}, false
+	}
+	return sum.Div(d), true
+}
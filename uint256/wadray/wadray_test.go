@@ -0,0 +1,231 @@
+package wadray
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/Pilatuz/bigz/uint256"
+)
+
+// The reference implementations below follow the Solidity code
+// of Aave WadRayMath and MakerDAO rpow literally, including their
+// revert conditions, on top of math/big.
+var (
+	bigMax       = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	bigWad       = big.NewInt(1e18)
+	bigRay       = new(big.Int).Mul(big.NewInt(1e18), big.NewInt(1e9))
+	bigRatio     = big.NewInt(1e9)
+	bigHalfWad   = new(big.Int).Rsh(bigWad, 1)
+	bigHalfRay   = new(big.Int).Rsh(bigRay, 1)
+	bigHalfRatio = new(big.Int).Rsh(bigRatio, 1)
+)
+
+// refMul is Aave's wadMul/rayMul:
+//
+//	if iszero(or(iszero(b), iszero(gt(a, div(sub(not(0), HALF), b))))) { revert(0, 0) }
+//	c := div(add(mul(a, b), HALF), ONE)
+func refMul(a, b, one, half *big.Int) (*big.Int, bool) {
+	if b.Sign() != 0 && a.Cmp(new(big.Int).Quo(new(big.Int).Sub(bigMax, half), b)) > 0 {
+		return nil, false
+	}
+	c := new(big.Int).Mul(a, b)
+	c.Add(c, half)
+	return c.Quo(c, one), true
+}
+
+// refDiv is Aave's wadDiv/rayDiv:
+//
+//	if or(iszero(b), iszero(iszero(gt(a, div(sub(not(0), div(b, 2)), ONE))))) { revert(0, 0) }
+//	c := div(add(mul(a, ONE), div(b, 2)), b)
+func refDiv(a, b, one *big.Int) (*big.Int, bool) {
+	if b.Sign() == 0 {
+		return nil, false
+	}
+	half := new(big.Int).Rsh(b, 1)
+	if a.Cmp(new(big.Int).Quo(new(big.Int).Sub(bigMax, half), one)) > 0 {
+		return nil, false
+	}
+	c := new(big.Int).Mul(a, one)
+	c.Add(c, half)
+	return c.Quo(c, b), true
+}
+
+// refRpow is MakerDAO's rpow with its overflow checks.
+func refRpow(x *big.Int, n uint64, base *big.Int) (*big.Int, bool) {
+	if x.Sign() == 0 {
+		if n == 0 {
+			return new(big.Int).Set(base), true
+		}
+		return new(big.Int), true
+	}
+	z := new(big.Int).Set(base)
+	if n%2 != 0 {
+		z.Set(x)
+	}
+	x = new(big.Int).Set(x)
+	half := new(big.Int).Rsh(base, 1)
+	for n /= 2; n != 0; n /= 2 {
+		xx := new(big.Int).Mul(x, x)
+		if xx.Cmp(bigMax) > 0 {
+			return nil, false
+		}
+		xx.Add(xx, half)
+		if xx.Cmp(bigMax) > 0 {
+			return nil, false
+		}
+		x.Quo(xx, base)
+		if n%2 != 0 {
+			zx := new(big.Int).Mul(z, x)
+			if zx.Cmp(bigMax) > 0 {
+				return nil, false
+			}
+			zx.Add(zx, half)
+			if zx.Cmp(bigMax) > 0 {
+				return nil, false
+			}
+			z.Quo(zx, base)
+		}
+	}
+	return z, true
+}
+
+// rand256 generates random 256-bit value with random bit length.
+func rand256(r *rand.Rand) Uint256 {
+	u := Uint256{}
+	u.Lo.Lo, u.Lo.Hi, u.Hi.Lo, u.Hi.Hi = r.Uint64(), r.Uint64(), r.Uint64(), r.Uint64()
+	return u.Rsh(uint(r.Intn(257)))
+}
+
+// check compares the result with the reference.
+func check(t *testing.T, name string, a, b Uint256, got Uint256, ok bool, expected *big.Int, eok bool) {
+	t.Helper()
+	if ok != eok || ok && got.Big().Cmp(expected) != 0 || !ok && !got.IsZero() {
+		t.Fatalf("%s(%s, %s) should be (%v, %t), got (%s, %t)", name, a, b, expected, eok, got, ok)
+	}
+}
+
+// TestWadRay checks the WAD and RAY math against the Solidity reference.
+func TestWadRay(t *testing.T) {
+	r := rand.New(rand.NewSource(50))
+	for i := 0; i < 50000; i++ {
+		a, b := rand256(r), rand256(r)
+		ab, bb := a.Big(), b.Big()
+
+		got, ok := WadMul(a, b)
+		e, eok := refMul(ab, bb, bigWad, bigHalfWad)
+		check(t, "WadMul", a, b, got, ok, e, eok)
+
+		got, ok = RayMul(a, b)
+		e, eok = refMul(ab, bb, bigRay, bigHalfRay)
+		check(t, "RayMul", a, b, got, ok, e, eok)
+
+		got, ok = WadDiv(a, b)
+		e, eok = refDiv(ab, bb, bigWad)
+		check(t, "WadDiv", a, b, got, ok, e, eok)
+
+		got, ok = RayDiv(a, b)
+		e, eok = refDiv(ab, bb, bigRay)
+		check(t, "RayDiv", a, b, got, ok, e, eok)
+
+		got, ok = WadToRay(a)
+		e = new(big.Int).Mul(ab, bigRatio)
+		check(t, "WadToRay", a, Uint256{}, got, ok, e, e.Cmp(bigMax) <= 0)
+
+		// b := div(a, RATIO); if iszero(lt(mod(a, RATIO), div(RATIO, 2))) { b := add(b, 1) }
+		q, m := new(big.Int).QuoRem(ab, bigRatio, new(big.Int))
+		if m.Cmp(bigHalfRatio) >= 0 {
+			q.Add(q, big.NewInt(1))
+		}
+		check(t, "RayToWad", a, Uint256{}, RayToWad(a), true, q, true)
+
+		// rates close to one, as the per-second interest is
+		x := Ray().Add(b.Rsh(uint(128 + r.Intn(128))))
+		n := r.Uint64() >> uint(r.Intn(64))
+		got, ok = Rpow(x, n, Ray())
+		e, eok = refRpow(x.Big(), n, bigRay)
+		check(t, "Rpow", x, uint256.From64(n), got, ok, e, eok)
+
+		n = uint64(r.Intn(300))
+		got, ok = Rpow(a, n, Wad())
+		e, eok = refRpow(ab, n, bigWad)
+		check(t, "Rpow", a, uint256.From64(n), got, ok, e, eok)
+	}
+}
+
+// TestManual checks the known values.
+func TestManual(t *testing.T) {
+	wad := func(s string) Uint256 {
+		u, err := uint256.ParseDecimal(s, WadDecimals, uint256.RoundUnnecessary)
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q) failed: %v", s, err)
+		}
+		return u
+	}
+	ray := func(s string) Uint256 {
+		u, err := uint256.ParseDecimal(s, RayDecimals, uint256.RoundUnnecessary)
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q) failed: %v", s, err)
+		}
+		return u
+	}
+	num := func(s string) Uint256 {
+		u, err := uint256.FromString(s)
+		if err != nil {
+			t.Fatalf("FromString(%q) failed: %v", s, err)
+		}
+		return u
+	}
+	eq := func(name string, got Uint256, ok bool, expected Uint256) {
+		t.Helper()
+		if !ok || !got.Equals(expected) {
+			t.Fatalf("%s should be %s, got (%s, %t)", name, expected, got, ok)
+		}
+	}
+
+	got, ok := WadMul(wad("2.5"), wad("0.5"))
+	eq("WadMul(2.5, 0.5)", got, ok, wad("1.25"))
+	got, ok = WadMul(wad("0.000000000000000001"), wad("0.5"))
+	eq("WadMul(1e-18, 0.5)", got, ok, wad("0.000000000000000001")) // half up
+	got, ok = WadMul(wad("0.000000000000000001"), wad("0.499999999999999999"))
+	eq("WadMul(1e-18, 0.49..)", got, ok, Uint256{})
+	got, ok = WadDiv(wad("1"), wad("3"))
+	eq("WadDiv(1, 3)", got, ok, wad("0.333333333333333333"))
+	got, ok = WadDiv(wad("2"), wad("3"))
+	eq("WadDiv(2, 3)", got, ok, wad("0.666666666666666667"))
+	got, ok = RayMul(ray("1.5"), ray("1.5"))
+	eq("RayMul(1.5, 1.5)", got, ok, ray("2.25"))
+	got, ok = RayDiv(ray("1"), ray("7"))
+	eq("RayDiv(1, 7)", got, ok, ray("0.142857142857142857142857143"))
+	got, ok = WadToRay(wad("1.5"))
+	eq("WadToRay(1.5)", got, ok, ray("1.5"))
+	eq("RayToWad(0.1234567890123456785)", RayToWad(ray("0.1234567890123456785")), true, wad("0.123456789012345679"))
+	eq("RayToWad(0.123456789012345678499999999)", RayToWad(ray("0.123456789012345678499999999")), true, wad("0.123456789012345678"))
+
+	// per-second rates of 2% and 10% a year, as in MakerDAO's DSR
+	const year = 365 * 24 * 60 * 60
+	got, ok = Rpow(num("1000000000627937192491029810"), year, Ray())
+	eq("Rpow(2%)", got, ok, num("1019999999999999999972831879"))
+	got, ok = Rpow(num("1000000003022265980097387650"), year, Ray())
+	eq("Rpow(10%)", got, ok, num("1099999999999999999953897206"))
+	got, ok = Rpow(ray("2"), 10, Ray())
+	eq("Rpow(2, 10)", got, ok, ray("1024"))
+	got, ok = Rpow(wad("3"), 40, Wad())
+	eq("Rpow(3, 40)", got, ok, wad("12157665459056928801"))
+	got, ok = Rpow(Uint256{}, 0, Ray())
+	eq("Rpow(0, 0)", got, ok, Ray())
+	got, ok = Rpow(Uint256{}, 5, Ray())
+	eq("Rpow(0, 5)", got, ok, Uint256{})
+
+	for name, f := range map[string]func() (Uint256, bool){
+		"WadMul(Max, 2)": func() (Uint256, bool) { return WadMul(uint256.Max(), wad("2")) },
+		"WadDiv(1, 0)":   func() (Uint256, bool) { return WadDiv(wad("1"), Uint256{}) },
+		"RayDiv(Max, 1)": func() (Uint256, bool) { return RayDiv(uint256.Max(), ray("1")) },
+		"WadToRay(Max)":  func() (Uint256, bool) { return WadToRay(uint256.Max()) },
+		"Rpow(3, 200)":   func() (Uint256, bool) { return Rpow(wad("3"), 200, Wad()) },
+	} {
+		if got, ok := f(); ok || !got.IsZero() {
+			t.Fatalf("%s should overflow, got (%s, %t)", name, got, ok)
+		}
+	}
+}